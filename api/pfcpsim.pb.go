@@ -23,6 +23,329 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Direction selects the traffic directions for which rules are created
+type Direction int32
+
+const (
+	Direction_BOTH     Direction = 0
+	Direction_UPLINK   Direction = 1
+	Direction_DOWNLINK Direction = 2
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "BOTH",
+		1: "UPLINK",
+		2: "DOWNLINK",
+	}
+	Direction_value = map[string]int32{
+		"BOTH":     0,
+		"UPLINK":   1,
+		"DOWNLINK": 2,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_pfcpsim_proto_enumTypes[0].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_pfcpsim_proto_enumTypes[0]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{0}
+}
+
+// PdnType selects the IP versions of the addresses assigned to UEs
+type PdnType int32
+
+const (
+	PdnType_IPV4   PdnType = 0
+	PdnType_IPV6   PdnType = 1
+	PdnType_IPV4V6 PdnType = 2
+)
+
+// Enum value maps for PdnType.
+var (
+	PdnType_name = map[int32]string{
+		0: "IPV4",
+		1: "IPV6",
+		2: "IPV4V6",
+	}
+	PdnType_value = map[string]int32{
+		"IPV4":   0,
+		"IPV6":   1,
+		"IPV4V6": 2,
+	}
+)
+
+func (x PdnType) Enum() *PdnType {
+	p := new(PdnType)
+	*p = x
+	return p
+}
+
+func (x PdnType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PdnType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pfcpsim_proto_enumTypes[1].Descriptor()
+}
+
+func (PdnType) Type() protoreflect.EnumType {
+	return &file_pfcpsim_proto_enumTypes[1]
+}
+
+func (x PdnType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PdnType.Descriptor instead.
+func (PdnType) EnumDescriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{1}
+}
+
+// TeidAllocation selects how the uplink TEIDs of the sessions are allocated
+type TeidAllocation int32
+
+const (
+	// PER_SESSION uses the base ID of each session as TEID. Overlapping requests may reuse the same TEIDs
+	TeidAllocation_PER_SESSION TeidAllocation = 0
+	// GLOBAL allocates sequentially the TEIDs not used by any active session
+	TeidAllocation_GLOBAL TeidAllocation = 1
+	// UPF_ALLOCATED asks the remote peer to allocate the TEIDs, like teidAllocFlag
+	TeidAllocation_UPF_ALLOCATED TeidAllocation = 2
+)
+
+// Enum value maps for TeidAllocation.
+var (
+	TeidAllocation_name = map[int32]string{
+		0: "PER_SESSION",
+		1: "GLOBAL",
+		2: "UPF_ALLOCATED",
+	}
+	TeidAllocation_value = map[string]int32{
+		"PER_SESSION":   0,
+		"GLOBAL":        1,
+		"UPF_ALLOCATED": 2,
+	}
+)
+
+func (x TeidAllocation) Enum() *TeidAllocation {
+	p := new(TeidAllocation)
+	*p = x
+	return p
+}
+
+func (x TeidAllocation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TeidAllocation) Descriptor() protoreflect.EnumDescriptor {
+	return file_pfcpsim_proto_enumTypes[2].Descriptor()
+}
+
+func (TeidAllocation) Type() protoreflect.EnumType {
+	return &file_pfcpsim_proto_enumTypes[2]
+}
+
+func (x TeidAllocation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TeidAllocation.Descriptor instead.
+func (TeidAllocation) EnumDescriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{2}
+}
+
+// PrecedenceOrder selects the precedence of the PDRs of application filters not specifying one
+type PrecedenceOrder int32
+
+const (
+	// PRECEDENCE_DEFAULT gives all of them the default precedence, 100
+	PrecedenceOrder_PRECEDENCE_DEFAULT PrecedenceOrder = 0
+	// PRECEDENCE_INCREASING gives them increasing precedences starting from 100, in the order they are provided:
+	// earlier filters are matched first
+	PrecedenceOrder_PRECEDENCE_INCREASING PrecedenceOrder = 1
+	// PRECEDENCE_DECREASING gives them decreasing precedences starting from 100, in the order they are provided:
+	// later filters are matched first
+	PrecedenceOrder_PRECEDENCE_DECREASING PrecedenceOrder = 2
+)
+
+// Enum value maps for PrecedenceOrder.
+var (
+	PrecedenceOrder_name = map[int32]string{
+		0: "PRECEDENCE_DEFAULT",
+		1: "PRECEDENCE_INCREASING",
+		2: "PRECEDENCE_DECREASING",
+	}
+	PrecedenceOrder_value = map[string]int32{
+		"PRECEDENCE_DEFAULT":    0,
+		"PRECEDENCE_INCREASING": 1,
+		"PRECEDENCE_DECREASING": 2,
+	}
+)
+
+func (x PrecedenceOrder) Enum() *PrecedenceOrder {
+	p := new(PrecedenceOrder)
+	*p = x
+	return p
+}
+
+func (x PrecedenceOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PrecedenceOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_pfcpsim_proto_enumTypes[3].Descriptor()
+}
+
+func (PrecedenceOrder) Type() protoreflect.EnumType {
+	return &file_pfcpsim_proto_enumTypes[3]
+}
+
+func (x PrecedenceOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PrecedenceOrder.Descriptor instead.
+func (PrecedenceOrder) EnumDescriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{3}
+}
+
+// DestinationInterface selects the Destination Interface of the FARs forwarding the traffic of a direction
+type DestinationInterface int32
+
+const (
+	// DESTINATION_DEFAULT is Core for uplink FARs and Access for downlink FARs
+	DestinationInterface_DESTINATION_DEFAULT DestinationInterface = 0
+	DestinationInterface_DESTINATION_ACCESS  DestinationInterface = 1
+	DestinationInterface_DESTINATION_CORE    DestinationInterface = 2
+	// DESTINATION_SGI_LAN is SGi-LAN or N6-LAN
+	DestinationInterface_DESTINATION_SGI_LAN     DestinationInterface = 3
+	DestinationInterface_DESTINATION_CP_FUNCTION DestinationInterface = 4
+	DestinationInterface_DESTINATION_LI_FUNCTION DestinationInterface = 5
+)
+
+// Enum value maps for DestinationInterface.
+var (
+	DestinationInterface_name = map[int32]string{
+		0: "DESTINATION_DEFAULT",
+		1: "DESTINATION_ACCESS",
+		2: "DESTINATION_CORE",
+		3: "DESTINATION_SGI_LAN",
+		4: "DESTINATION_CP_FUNCTION",
+		5: "DESTINATION_LI_FUNCTION",
+	}
+	DestinationInterface_value = map[string]int32{
+		"DESTINATION_DEFAULT":     0,
+		"DESTINATION_ACCESS":      1,
+		"DESTINATION_CORE":        2,
+		"DESTINATION_SGI_LAN":     3,
+		"DESTINATION_CP_FUNCTION": 4,
+		"DESTINATION_LI_FUNCTION": 5,
+	}
+)
+
+func (x DestinationInterface) Enum() *DestinationInterface {
+	p := new(DestinationInterface)
+	*p = x
+	return p
+}
+
+func (x DestinationInterface) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DestinationInterface) Descriptor() protoreflect.EnumDescriptor {
+	return file_pfcpsim_proto_enumTypes[4].Descriptor()
+}
+
+func (DestinationInterface) Type() protoreflect.EnumType {
+	return &file_pfcpsim_proto_enumTypes[4]
+}
+
+func (x DestinationInterface) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DestinationInterface.Descriptor instead.
+func (DestinationInterface) EnumDescriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{4}
+}
+
+// SeidAllocation selects how the local SEIDs of the sessions are allocated
+type SeidAllocation int32
+
+const (
+	// SEID_SEQUENTIAL allocates increasing SEIDs starting from 1
+	SeidAllocation_SEID_SEQUENTIAL SeidAllocation = 0
+	// SEID_RANDOM allocates random SEIDs
+	SeidAllocation_SEID_RANDOM SeidAllocation = 1
+	// SEID_BASE_ID uses the base ID of each session as SEID. Sessions re-established on Error Indication Reports
+	// get a sequential SEID
+	SeidAllocation_SEID_BASE_ID SeidAllocation = 2
+)
+
+// Enum value maps for SeidAllocation.
+var (
+	SeidAllocation_name = map[int32]string{
+		0: "SEID_SEQUENTIAL",
+		1: "SEID_RANDOM",
+		2: "SEID_BASE_ID",
+	}
+	SeidAllocation_value = map[string]int32{
+		"SEID_SEQUENTIAL": 0,
+		"SEID_RANDOM":     1,
+		"SEID_BASE_ID":    2,
+	}
+)
+
+func (x SeidAllocation) Enum() *SeidAllocation {
+	p := new(SeidAllocation)
+	*p = x
+	return p
+}
+
+func (x SeidAllocation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeidAllocation) Descriptor() protoreflect.EnumDescriptor {
+	return file_pfcpsim_proto_enumTypes[5].Descriptor()
+}
+
+func (SeidAllocation) Type() protoreflect.EnumType {
+	return &file_pfcpsim_proto_enumTypes[5]
+}
+
+func (x SeidAllocation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeidAllocation.Descriptor instead.
+func (SeidAllocation) EnumDescriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{5}
+}
+
 type CreateSessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -31,9 +354,11 @@ type CreateSessionRequest struct {
 	// count represents the number of session
 	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
 	// baseID is used to create incremental IDs for PDRs, FARs, QERs
-	BaseID               int32    `protobuf:"varint,2,opt,name=baseID,proto3" json:"baseID,omitempty"`
-	NodeBAddress         string   `protobuf:"bytes,3,opt,name=nodeBAddress,proto3" json:"nodeBAddress,omitempty"`
-	UeAddressPool        string   `protobuf:"bytes,4,opt,name=ueAddressPool,proto3" json:"ueAddressPool,omitempty"`
+	BaseID        int32  `protobuf:"varint,2,opt,name=baseID,proto3" json:"baseID,omitempty"`
+	NodeBAddress  string `protobuf:"bytes,3,opt,name=nodeBAddress,proto3" json:"nodeBAddress,omitempty"`
+	UeAddressPool string `protobuf:"bytes,4,opt,name=ueAddressPool,proto3" json:"ueAddressPool,omitempty"`
+	// appFilters get a pair of uplink and downlink rules each. Without any, sessions get a single pair
+	// matching all the traffic and enforcing only the session QER
 	AppFilters           []string `protobuf:"bytes,5,rep,name=appFilters,proto3" json:"appFilters,omitempty"`
 	Qfi                  int32    `protobuf:"varint,6,opt,name=qfi,proto3" json:"qfi,omitempty"` // Should be uint8
 	UlTunnelDstIP        string   `protobuf:"bytes,7,opt,name=ulTunnelDstIP,proto3" json:"ulTunnelDstIP,omitempty"`
@@ -42,6 +367,59 @@ type CreateSessionRequest struct {
 	UlAmbr               int32    `protobuf:"varint,10,opt,name=ulAmbr,proto3" json:"ulAmbr,omitempty"`
 	DlAmbr               int32    `protobuf:"varint,11,opt,name=dlAmbr,proto3" json:"dlAmbr,omitempty"`
 	BidirectionalSDFFlag bool     `protobuf:"varint,12,opt,name=bidirectionalSDFFlag,proto3" json:"bidirectionalSDFFlag,omitempty"`
+	// ueAddressPools are used, after ueAddressPool if set, to assign UE addresses
+	UeAddressPools []string `protobuf:"bytes,13,rep,name=ueAddressPools,proto3" json:"ueAddressPools,omitempty"`
+	// ueAddressPoolsRoundRobin distributes UE addresses across the pools in round-robin order,
+	// instead of exhausting each pool before moving to the next one
+	UeAddressPoolsRoundRobin bool `protobuf:"varint,14,opt,name=ueAddressPoolsRoundRobin,proto3" json:"ueAddressPoolsRoundRobin,omitempty"`
+	// concurrency is the maximum number of sessions established in parallel.
+	// Sessions are established one after the other if lower than 2
+	Concurrency int32 `protobuf:"varint,15,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	// direction limits the PDRs, FARs and application QERs created for each application filter
+	// to the given direction. The session QER is created regardless
+	Direction Direction `protobuf:"varint,16,opt,name=direction,proto3,enum=api.Direction" json:"direction,omitempty"`
+	// pdnType selects whether UEs get an IPv4 address (taken from ueAddressPool and ueAddressPools),
+	// an IPv6 address (taken from ueIPv6AddressPool) or both
+	PdnType           PdnType `protobuf:"varint,17,opt,name=pdnType,proto3,enum=api.PdnType" json:"pdnType,omitempty"`
+	UeIPv6AddressPool string  `protobuf:"bytes,18,opt,name=ueIPv6AddressPool,proto3" json:"ueIPv6AddressPool,omitempty"`
+	// ttlMs is the lifetime of the sessions, in milliseconds: sessions are deleted once it elapses.
+	// Sessions last until explicitly deleted if 0
+	TtlMs uint32 `protobuf:"varint,19,opt,name=ttlMs,proto3" json:"ttlMs,omitempty"`
+	// skipSessionQER avoids the session QER, for peers not supporting it: PDRs reference only the application QERs.
+	// If set, ulAmbr and dlAmbr are ignored
+	SkipSessionQER bool           `protobuf:"varint,20,opt,name=skipSessionQER,proto3" json:"skipSessionQER,omitempty"`
+	TeidAllocation TeidAllocation `protobuf:"varint,21,opt,name=teidAllocation,proto3,enum=api.TeidAllocation" json:"teidAllocation,omitempty"`
+	// directionalSDFFlag gives the downlink PDRs the SDF filter of the uplink ones with source and destination swapped,
+	// for peers matching the filter direction strictly. It can't be combined with bidirectionalSDFFlag
+	DirectionalSDFFlag bool `protobuf:"varint,22,opt,name=directionalSDFFlag,proto3" json:"directionalSDFFlag,omitempty"`
+	// peer is the address of the remote peer the sessions are established with, either remotePeerAddress
+	// or one of additionalPeerAddresses. remotePeerAddress is used if empty
+	Peer string `protobuf:"bytes,23,opt,name=peer,proto3" json:"peer,omitempty"`
+	// sessionsPerSecond limits the rate at which sessions are established, to model a realistic CP load.
+	// Sessions are established as fast as possible if 0
+	SessionsPerSecond float64 `protobuf:"fixed64,24,opt,name=sessionsPerSecond,proto3" json:"sessionsPerSecond,omitempty"`
+	// precedenceOrder assigns distinct precedences to the application filters without an explicit one,
+	// so that their match priority is deterministic
+	PrecedenceOrder PrecedenceOrder `protobuf:"varint,25,opt,name=precedenceOrder,proto3,enum=api.PrecedenceOrder" json:"precedenceOrder,omitempty"`
+	// appQERFirst lists the application QER before the session QER in the QER IDs of the PDRs,
+	// for peers sensitive to their order. The session QER comes first if not set
+	AppQERFirst bool `protobuf:"varint,26,opt,name=appQERFirst,proto3" json:"appQERFirst,omitempty"`
+	// ulGbr and dlGbr are the guaranteed bitrates of the session QER, in kbps, which then enforces ulAmbr and dlAmbr
+	// as maximum bitrates. They require the session QER and must not exceed ulAmbr and dlAmbr. No GBR is set if 0
+	UlGbr int32 `protobuf:"varint,27,opt,name=ulGbr,proto3" json:"ulGbr,omitempty"`
+	DlGbr int32 `protobuf:"varint,28,opt,name=dlGbr,proto3" json:"dlGbr,omitempty"`
+	// appUlMbr and appDlMbr are the maximum bitrates of the application QERs, in kbps, for the application filters
+	// not specifying their own. The application QERs don't limit the bitrate if 0
+	AppUlMbr int32 `protobuf:"varint,29,opt,name=appUlMbr,proto3" json:"appUlMbr,omitempty"`
+	AppDlMbr int32 `protobuf:"varint,30,opt,name=appDlMbr,proto3" json:"appDlMbr,omitempty"`
+	// uplinkDstInterface and downlinkDstInterface are the destination interfaces of the uplink and downlink FARs,
+	// e.g. to forward the uplink traffic to the SGi-LAN
+	UplinkDstInterface   DestinationInterface `protobuf:"varint,31,opt,name=uplinkDstInterface,proto3,enum=api.DestinationInterface" json:"uplinkDstInterface,omitempty"`
+	DownlinkDstInterface DestinationInterface `protobuf:"varint,32,opt,name=downlinkDstInterface,proto3,enum=api.DestinationInterface" json:"downlinkDstInterface,omitempty"`
+	// dryRun builds the Session Establishment Requests without sending them, returning them in
+	// CreatedSession.establishmentRequest. Sessions are not recorded and the association is not required.
+	// The F-SEIDs carry the base IDs and pfcpsim allocated TEIDs are the base IDs as well
+	DryRun bool `protobuf:"varint,33,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
 }
 
 func (x *CreateSessionRequest) Reset() {
@@ -160,6 +538,153 @@ func (x *CreateSessionRequest) GetBidirectionalSDFFlag() bool {
 	return false
 }
 
+func (x *CreateSessionRequest) GetUeAddressPools() []string {
+	if x != nil {
+		return x.UeAddressPools
+	}
+	return nil
+}
+
+func (x *CreateSessionRequest) GetUeAddressPoolsRoundRobin() bool {
+	if x != nil {
+		return x.UeAddressPoolsRoundRobin
+	}
+	return false
+}
+
+func (x *CreateSessionRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *CreateSessionRequest) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_BOTH
+}
+
+func (x *CreateSessionRequest) GetPdnType() PdnType {
+	if x != nil {
+		return x.PdnType
+	}
+	return PdnType_IPV4
+}
+
+func (x *CreateSessionRequest) GetUeIPv6AddressPool() string {
+	if x != nil {
+		return x.UeIPv6AddressPool
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetTtlMs() uint32 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+func (x *CreateSessionRequest) GetSkipSessionQER() bool {
+	if x != nil {
+		return x.SkipSessionQER
+	}
+	return false
+}
+
+func (x *CreateSessionRequest) GetTeidAllocation() TeidAllocation {
+	if x != nil {
+		return x.TeidAllocation
+	}
+	return TeidAllocation_PER_SESSION
+}
+
+func (x *CreateSessionRequest) GetDirectionalSDFFlag() bool {
+	if x != nil {
+		return x.DirectionalSDFFlag
+	}
+	return false
+}
+
+func (x *CreateSessionRequest) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetSessionsPerSecond() float64 {
+	if x != nil {
+		return x.SessionsPerSecond
+	}
+	return 0
+}
+
+func (x *CreateSessionRequest) GetPrecedenceOrder() PrecedenceOrder {
+	if x != nil {
+		return x.PrecedenceOrder
+	}
+	return PrecedenceOrder_PRECEDENCE_DEFAULT
+}
+
+func (x *CreateSessionRequest) GetAppQERFirst() bool {
+	if x != nil {
+		return x.AppQERFirst
+	}
+	return false
+}
+
+func (x *CreateSessionRequest) GetUlGbr() int32 {
+	if x != nil {
+		return x.UlGbr
+	}
+	return 0
+}
+
+func (x *CreateSessionRequest) GetDlGbr() int32 {
+	if x != nil {
+		return x.DlGbr
+	}
+	return 0
+}
+
+func (x *CreateSessionRequest) GetAppUlMbr() int32 {
+	if x != nil {
+		return x.AppUlMbr
+	}
+	return 0
+}
+
+func (x *CreateSessionRequest) GetAppDlMbr() int32 {
+	if x != nil {
+		return x.AppDlMbr
+	}
+	return 0
+}
+
+func (x *CreateSessionRequest) GetUplinkDstInterface() DestinationInterface {
+	if x != nil {
+		return x.UplinkDstInterface
+	}
+	return DestinationInterface_DESTINATION_DEFAULT
+}
+
+func (x *CreateSessionRequest) GetDownlinkDstInterface() DestinationInterface {
+	if x != nil {
+		return x.DownlinkDstInterface
+	}
+	return DestinationInterface_DESTINATION_DEFAULT
+}
+
+func (x *CreateSessionRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 type ModifySessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -174,11 +699,23 @@ type ModifySessionRequest struct {
 	BufferFlag    bool     `protobuf:"varint,5,opt,name=bufferFlag,proto3" json:"bufferFlag,omitempty"`
 	NotifyCPFlag  bool     `protobuf:"varint,6,opt,name=notifyCPFlag,proto3" json:"notifyCPFlag,omitempty"`
 	AppFilters    []string `protobuf:"bytes,7,rep,name=appFilters,proto3" json:"appFilters,omitempty"`
-	EndMarkerFlag bool     `protobuf:"varint,8,opt,name=endMarkerFlag,proto3" json:"endMarkerFlag,omitempty"`
-	UlTunnelDstIP string   `protobuf:"bytes,9,opt,name=ulTunnelDstIP,proto3" json:"ulTunnelDstIP,omitempty"`
-	DlTunnelDstIP string   `protobuf:"bytes,10,opt,name=dlTunnelDstIP,proto3" json:"dlTunnelDstIP,omitempty"`
-	UlAmbr        int32    `protobuf:"varint,11,opt,name=ulAmbr,proto3" json:"ulAmbr,omitempty"`
-	DlAmbr        int32    `protobuf:"varint,12,opt,name=dlAmbr,proto3" json:"dlAmbr,omitempty"`
+	// endMarkerFlag requests end markers on the previous downlink tunnel. It is ignored while buffering
+	EndMarkerFlag bool   `protobuf:"varint,8,opt,name=endMarkerFlag,proto3" json:"endMarkerFlag,omitempty"`
+	UlTunnelDstIP string `protobuf:"bytes,9,opt,name=ulTunnelDstIP,proto3" json:"ulTunnelDstIP,omitempty"`
+	DlTunnelDstIP string `protobuf:"bytes,10,opt,name=dlTunnelDstIP,proto3" json:"dlTunnelDstIP,omitempty"`
+	UlAmbr        int32  `protobuf:"varint,11,opt,name=ulAmbr,proto3" json:"ulAmbr,omitempty"`
+	DlAmbr        int32  `protobuf:"varint,12,opt,name=dlAmbr,proto3" json:"dlAmbr,omitempty"`
+	// uplinkEndMarkerFlag updates the uplink FARs as well, requesting end markers on the previous uplink tunnel
+	UplinkEndMarkerFlag bool `protobuf:"varint,13,opt,name=uplinkEndMarkerFlag,proto3" json:"uplinkEndMarkerFlag,omitempty"`
+	// suggestedBufferingPacketsCount is the number of packets the UPF is suggested to buffer. It is used only while buffering
+	SuggestedBufferingPacketsCount int32 `protobuf:"varint,14,opt,name=suggestedBufferingPacketsCount,proto3" json:"suggestedBufferingPacketsCount,omitempty"`
+	// dlDataNotificationDelayMs delays the downlink data notifications. It is used only while buffering
+	DlDataNotificationDelayMs int32 `protobuf:"varint,15,opt,name=dlDataNotificationDelayMs,proto3" json:"dlDataNotificationDelayMs,omitempty"`
+	// pdrUpdates are sent as Update PDRs, along with the changes of the other rules
+	PdrUpdates []*PDRUpdate `protobuf:"bytes,16,rep,name=pdrUpdates,proto3" json:"pdrUpdates,omitempty"`
+	// queryURRIDs, if set, makes the modification only query the usage measured by these URRs of each session,
+	// with Query URR IEs. The other fields but count and baseID are ignored. The usage is returned in Response.usageReports
+	QueryURRIDs []uint32 `protobuf:"varint,17,rep,name=queryURRIDs,proto3" json:"queryURRIDs,omitempty"`
 }
 
 func (x *ModifySessionRequest) Reset() {
@@ -297,6 +834,41 @@ func (x *ModifySessionRequest) GetDlAmbr() int32 {
 	return 0
 }
 
+func (x *ModifySessionRequest) GetUplinkEndMarkerFlag() bool {
+	if x != nil {
+		return x.UplinkEndMarkerFlag
+	}
+	return false
+}
+
+func (x *ModifySessionRequest) GetSuggestedBufferingPacketsCount() int32 {
+	if x != nil {
+		return x.SuggestedBufferingPacketsCount
+	}
+	return 0
+}
+
+func (x *ModifySessionRequest) GetDlDataNotificationDelayMs() int32 {
+	if x != nil {
+		return x.DlDataNotificationDelayMs
+	}
+	return 0
+}
+
+func (x *ModifySessionRequest) GetPdrUpdates() []*PDRUpdate {
+	if x != nil {
+		return x.PdrUpdates
+	}
+	return nil
+}
+
+func (x *ModifySessionRequest) GetQueryURRIDs() []uint32 {
+	if x != nil {
+		return x.QueryURRIDs
+	}
+	return nil
+}
+
 type ConfigureRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -306,6 +878,55 @@ type ConfigureRequest struct {
 	UpfN3Address string `protobuf:"bytes,1,opt,name=upfN3Address,proto3" json:"upfN3Address,omitempty"`
 	// the PFCP agent server address
 	RemotePeerAddress string `protobuf:"bytes,3,opt,name=remotePeerAddress,proto3" json:"remotePeerAddress,omitempty"`
+	// number of times the association setup is retried (with exponential backoff) before giving up
+	AssociationRetries int32 `protobuf:"varint,4,opt,name=associationRetries,proto3" json:"associationRetries,omitempty"`
+	// localN4Address is the source address of the PFCP messages. If not set, the address
+	// of the interface pfcpsim was started with is used
+	LocalN4Address string `protobuf:"bytes,5,opt,name=localN4Address,proto3" json:"localN4Address,omitempty"`
+	// pfcpPort is the PFCP port of the remote peer, used if remotePeerAddress does not specify one.
+	// Default is 8805
+	PfcpPort int32 `protobuf:"varint,6,opt,name=pfcpPort,proto3" json:"pfcpPort,omitempty"`
+	// capturePath, if set, is the pcap file where the PFCP messages exchanged with the remote peer are written.
+	// The file is truncated on association and closed on disassociation
+	CapturePath string `protobuf:"bytes,7,opt,name=capturePath,proto3" json:"capturePath,omitempty"`
+	// cpFunctionFeatures is the 5th octet of the CP Function Features IE advertised in Association Setup Requests.
+	// The IE is omitted if 0
+	CpFunctionFeatures uint32 `protobuf:"varint,8,opt,name=cpFunctionFeatures,proto3" json:"cpFunctionFeatures,omitempty"`
+	// additionalPeerAddresses are remote peers pfcpsim associates with besides remotePeerAddress.
+	// Sessions are established with them only if requested explicitly, see CreateSessionRequest.peer
+	AdditionalPeerAddresses []string `protobuf:"bytes,9,rep,name=additionalPeerAddresses,proto3" json:"additionalPeerAddresses,omitempty"`
+	// maxMissedHeartbeats is the number of consecutive Heartbeat Requests left unanswered after which
+	// the N4 path is considered failed. Default is 1
+	MaxMissedHeartbeats int32 `protobuf:"varint,10,opt,name=maxMissedHeartbeats,proto3" json:"maxMissedHeartbeats,omitempty"`
+	// reestablishOnErrorIndication makes pfcpsim establish again, with the same rules, the sessions reported
+	// by the remote peer with an Error Indication Report. The previous sessions are deleted first
+	ReestablishOnErrorIndication bool `protobuf:"varint,11,opt,name=reestablishOnErrorIndication,proto3" json:"reestablishOnErrorIndication,omitempty"`
+	// csid identifies the PDN connection set of the sessions established by pfcpsim, advertised to the remote peers
+	// with the FQ-CSID IE. It enables DeleteSessionSet to delete the sessions with a single request. Disabled if 0
+	Csid uint32 `protobuf:"varint,12,opt,name=csid,proto3" json:"csid,omitempty"`
+	// nodeIDType is the type of the Node ID advertised to the remote peers: "ipv4", "ipv6" or "fqdn".
+	// If empty, it is inferred from nodeID
+	NodeIDType string `protobuf:"bytes,13,opt,name=nodeIDType,proto3" json:"nodeIDType,omitempty"`
+	// nodeID is the Node ID advertised to the remote peers, matching nodeIDType.
+	// If empty, the local address of the N4 messages is used
+	NodeID string `protobuf:"bytes,14,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	// associationTimeout, establishmentTimeout, modificationTimeout, deletionTimeout and heartbeatTimeout
+	// are the times to wait for the response to the requests of each operation, in milliseconds.
+	// If 0, the default response timeout applies
+	AssociationTimeout   int32 `protobuf:"varint,15,opt,name=associationTimeout,proto3" json:"associationTimeout,omitempty"`
+	EstablishmentTimeout int32 `protobuf:"varint,16,opt,name=establishmentTimeout,proto3" json:"establishmentTimeout,omitempty"`
+	ModificationTimeout  int32 `protobuf:"varint,17,opt,name=modificationTimeout,proto3" json:"modificationTimeout,omitempty"`
+	DeletionTimeout      int32 `protobuf:"varint,18,opt,name=deletionTimeout,proto3" json:"deletionTimeout,omitempty"`
+	HeartbeatTimeout     int32 `protobuf:"varint,19,opt,name=heartbeatTimeout,proto3" json:"heartbeatTimeout,omitempty"`
+	// seidAllocation selects how the local SEIDs of the sessions are allocated. SEIDs used by active sessions are never reused
+	SeidAllocation SeidAllocation `protobuf:"varint,20,opt,name=seidAllocation,proto3,enum=api.SeidAllocation" json:"seidAllocation,omitempty"`
+	// reassociationBackoffMs enables the automatic re-association with the remote peers once the N4 path fails.
+	// It is the time waited before the first attempt, in milliseconds, doubling after every failed attempt. Disabled if 0
+	ReassociationBackoffMs int32 `protobuf:"varint,21,opt,name=reassociationBackoffMs,proto3" json:"reassociationBackoffMs,omitempty"`
+	// enableMessagePriority makes pfcpsim send the Session Establishment, Modification and Deletion Requests
+	// with the Message Priority set in the header to messagePriority, from 0 (the highest) to 15
+	EnableMessagePriority bool   `protobuf:"varint,22,opt,name=enableMessagePriority,proto3" json:"enableMessagePriority,omitempty"`
+	MessagePriority       uint32 `protobuf:"varint,23,opt,name=messagePriority,proto3" json:"messagePriority,omitempty"`
 }
 
 func (x *ConfigureRequest) Reset() {
@@ -354,6 +975,146 @@ func (x *ConfigureRequest) GetRemotePeerAddress() string {
 	return ""
 }
 
+func (x *ConfigureRequest) GetAssociationRetries() int32 {
+	if x != nil {
+		return x.AssociationRetries
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetLocalN4Address() string {
+	if x != nil {
+		return x.LocalN4Address
+	}
+	return ""
+}
+
+func (x *ConfigureRequest) GetPfcpPort() int32 {
+	if x != nil {
+		return x.PfcpPort
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetCapturePath() string {
+	if x != nil {
+		return x.CapturePath
+	}
+	return ""
+}
+
+func (x *ConfigureRequest) GetCpFunctionFeatures() uint32 {
+	if x != nil {
+		return x.CpFunctionFeatures
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetAdditionalPeerAddresses() []string {
+	if x != nil {
+		return x.AdditionalPeerAddresses
+	}
+	return nil
+}
+
+func (x *ConfigureRequest) GetMaxMissedHeartbeats() int32 {
+	if x != nil {
+		return x.MaxMissedHeartbeats
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetReestablishOnErrorIndication() bool {
+	if x != nil {
+		return x.ReestablishOnErrorIndication
+	}
+	return false
+}
+
+func (x *ConfigureRequest) GetCsid() uint32 {
+	if x != nil {
+		return x.Csid
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetNodeIDType() string {
+	if x != nil {
+		return x.NodeIDType
+	}
+	return ""
+}
+
+func (x *ConfigureRequest) GetNodeID() string {
+	if x != nil {
+		return x.NodeID
+	}
+	return ""
+}
+
+func (x *ConfigureRequest) GetAssociationTimeout() int32 {
+	if x != nil {
+		return x.AssociationTimeout
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetEstablishmentTimeout() int32 {
+	if x != nil {
+		return x.EstablishmentTimeout
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetModificationTimeout() int32 {
+	if x != nil {
+		return x.ModificationTimeout
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetDeletionTimeout() int32 {
+	if x != nil {
+		return x.DeletionTimeout
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetHeartbeatTimeout() int32 {
+	if x != nil {
+		return x.HeartbeatTimeout
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetSeidAllocation() SeidAllocation {
+	if x != nil {
+		return x.SeidAllocation
+	}
+	return SeidAllocation_SEID_SEQUENTIAL
+}
+
+func (x *ConfigureRequest) GetReassociationBackoffMs() int32 {
+	if x != nil {
+		return x.ReassociationBackoffMs
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetEnableMessagePriority() bool {
+	if x != nil {
+		return x.EnableMessagePriority
+	}
+	return false
+}
+
+func (x *ConfigureRequest) GetMessagePriority() uint32 {
+	if x != nil {
+		return x.MessagePriority
+	}
+	return 0
+}
+
 type DeleteSessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -410,14 +1171,22 @@ func (x *DeleteSessionRequest) GetBaseID() int32 {
 	return 0
 }
 
-type EmptyRequest struct {
+type ApplicationPFDs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	ApplicationID string `protobuf:"bytes,1,opt,name=applicationID,proto3" json:"applicationID,omitempty"`
+	// each entry generates a PFD with a flow description
+	FlowDescriptions []string `protobuf:"bytes,2,rep,name=flowDescriptions,proto3" json:"flowDescriptions,omitempty"`
+	// each entry generates a PFD with a URL
+	Urls []string `protobuf:"bytes,3,rep,name=urls,proto3" json:"urls,omitempty"`
+	// each entry generates a PFD with a domain name
+	DomainNames []string `protobuf:"bytes,4,rep,name=domainNames,proto3" json:"domainNames,omitempty"`
 }
 
-func (x *EmptyRequest) Reset() {
-	*x = EmptyRequest{}
+func (x *ApplicationPFDs) Reset() {
+	*x = ApplicationPFDs{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pfcpsim_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -425,13 +1194,13 @@ func (x *EmptyRequest) Reset() {
 	}
 }
 
-func (x *EmptyRequest) String() string {
+func (x *ApplicationPFDs) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmptyRequest) ProtoMessage() {}
+func (*ApplicationPFDs) ProtoMessage() {}
 
-func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
+func (x *ApplicationPFDs) ProtoReflect() protoreflect.Message {
 	mi := &file_pfcpsim_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -443,22 +1212,49 @@ func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
-func (*EmptyRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ApplicationPFDs.ProtoReflect.Descriptor instead.
+func (*ApplicationPFDs) Descriptor() ([]byte, []int) {
 	return file_pfcpsim_proto_rawDescGZIP(), []int{4}
 }
 
-type Response struct {
+func (x *ApplicationPFDs) GetApplicationID() string {
+	if x != nil {
+		return x.ApplicationID
+	}
+	return ""
+}
+
+func (x *ApplicationPFDs) GetFlowDescriptions() []string {
+	if x != nil {
+		return x.FlowDescriptions
+	}
+	return nil
+}
+
+func (x *ApplicationPFDs) GetUrls() []string {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *ApplicationPFDs) GetDomainNames() []string {
+	if x != nil {
+		return x.DomainNames
+	}
+	return nil
+}
+
+type PFDManagementRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
-	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Applications []*ApplicationPFDs `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
 }
 
-func (x *Response) Reset() {
-	*x = Response{}
+func (x *PFDManagementRequest) Reset() {
+	*x = PFDManagementRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pfcpsim_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -466,13 +1262,13 @@ func (x *Response) Reset() {
 	}
 }
 
-func (x *Response) String() string {
+func (x *PFDManagementRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response) ProtoMessage() {}
+func (*PFDManagementRequest) ProtoMessage() {}
 
-func (x *Response) ProtoReflect() protoreflect.Message {
+func (x *PFDManagementRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pfcpsim_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -484,195 +1280,2835 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response.ProtoReflect.Descriptor instead.
-func (*Response) Descriptor() ([]byte, []int) {
+// Deprecated: Use PFDManagementRequest.ProtoReflect.Descriptor instead.
+func (*PFDManagementRequest) Descriptor() ([]byte, []int) {
 	return file_pfcpsim_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Response) GetStatusCode() int32 {
+func (x *PFDManagementRequest) GetApplications() []*ApplicationPFDs {
 	if x != nil {
-		return x.StatusCode
+		return x.Applications
 	}
-	return 0
+	return nil
 }
 
-func (x *Response) GetMessage() string {
+type PathFailure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// nodeID of the peer that reported the failure
+	NodeID string `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	// remotePeers are the addresses of the GTP-U peers towards which the path failed
+	RemotePeers []string `protobuf:"bytes,2,rep,name=remotePeers,proto3" json:"remotePeers,omitempty"`
+	// reportedAt is the time the report was received, in RFC3339 format
+	ReportedAt string `protobuf:"bytes,3,opt,name=reportedAt,proto3" json:"reportedAt,omitempty"`
+}
+
+func (x *PathFailure) Reset() {
+	*x = PathFailure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathFailure) ProtoMessage() {}
+
+func (x *PathFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathFailure.ProtoReflect.Descriptor instead.
+func (*PathFailure) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PathFailure) GetNodeID() string {
 	if x != nil {
-		return x.Message
+		return x.NodeID
 	}
 	return ""
 }
 
-var File_pfcpsim_proto protoreflect.FileDescriptor
+func (x *PathFailure) GetRemotePeers() []string {
+	if x != nil {
+		return x.RemotePeers
+	}
+	return nil
+}
 
-var file_pfcpsim_proto_rawDesc = []byte{
-	0x0a, 0x0d, 0x70, 0x66, 0x63, 0x70, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x03, 0x61, 0x70, 0x69, 0x22, 0x96, 0x03, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x6e,
-	0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
-	0x24, 0x0a, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x66, 0x69, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x03, 0x71, 0x66, 0x69, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x6c, 0x54, 0x75, 0x6e,
-	0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
-	0x75, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x12, 0x24, 0x0a,
-	0x0d, 0x64, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73,
-	0x74, 0x49, 0x50, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x65, 0x69, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
-	0x46, 0x6c, 0x61, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x65, 0x69, 0x64,
-	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6c, 0x41,
-	0x6d, 0x62, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x6c, 0x41, 0x6d, 0x62,
-	0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6c, 0x41, 0x6d, 0x62, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x64, 0x6c, 0x41, 0x6d, 0x62, 0x72, 0x12, 0x32, 0x0a, 0x14, 0x62, 0x69, 0x64,
-	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x44, 0x46, 0x46, 0x6c, 0x61,
-	0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x62, 0x69, 0x64, 0x69, 0x72, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x44, 0x46, 0x46, 0x6c, 0x61, 0x67, 0x22, 0x94, 0x03,
-	0x0a, 0x14, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61,
-	0x73, 0x65, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65,
-	0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x65, 0x41, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x1e,
-	0x0a, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x22,
-	0x0a, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x43, 0x50, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x43, 0x50, 0x46, 0x6c,
-	0x61, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
-	0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x65, 0x6e, 0x64, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x46,
-	0x6c, 0x61, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65, 0x6e, 0x64, 0x4d, 0x61,
-	0x72, 0x6b, 0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x6c, 0x54, 0x75,
-	0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0d, 0x75, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x12, 0x24,
-	0x0a, 0x0d, 0x64, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44,
-	0x73, 0x74, 0x49, 0x50, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6c, 0x41, 0x6d, 0x62, 0x72, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x6c, 0x41, 0x6d, 0x62, 0x72, 0x12, 0x16, 0x0a, 0x06,
-	0x64, 0x6c, 0x41, 0x6d, 0x62, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x64, 0x6c,
-	0x41, 0x6d, 0x62, 0x72, 0x22, 0x64, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x75, 0x70, 0x66, 0x4e,
-	0x33, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x75, 0x70, 0x66, 0x4e, 0x33, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2c, 0x0a, 0x11,
-	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50,
-	0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x44, 0x0a, 0x14, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65,
-	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44,
-	0x22, 0x0e, 0x0a, 0x0c, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x45, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xda, 0x02, 0x0a, 0x07, 0x50, 0x46, 0x43, 0x50,
-	0x53, 0x69, 0x6d, 0x12, 0x33, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
-	0x12, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x09, 0x41, 0x73, 0x73, 0x6f,
-	0x63, 0x69, 0x61, 0x74, 0x65, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x0c, 0x44, 0x69, 0x73,
-	0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a,
-	0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0d, 0x4d, 0x6f,
-	0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *PathFailure) GetReportedAt() string {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return ""
 }
 
-var (
-	file_pfcpsim_proto_rawDescOnce sync.Once
-	file_pfcpsim_proto_rawDescData = file_pfcpsim_proto_rawDesc
-)
+type PathFailuresResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func file_pfcpsim_proto_rawDescGZIP() []byte {
-	file_pfcpsim_proto_rawDescOnce.Do(func() {
-		file_pfcpsim_proto_rawDescData = protoimpl.X.CompressGZIP(file_pfcpsim_proto_rawDescData)
-	})
-	return file_pfcpsim_proto_rawDescData
+	Failures []*PathFailure `protobuf:"bytes,1,rep,name=failures,proto3" json:"failures,omitempty"`
 }
 
-var file_pfcpsim_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_pfcpsim_proto_goTypes = []interface{}{
-	(*CreateSessionRequest)(nil), // 0: api.CreateSessionRequest
-	(*ModifySessionRequest)(nil), // 1: api.ModifySessionRequest
-	(*ConfigureRequest)(nil),     // 2: api.ConfigureRequest
-	(*DeleteSessionRequest)(nil), // 3: api.DeleteSessionRequest
-	(*EmptyRequest)(nil),         // 4: api.EmptyRequest
-	(*Response)(nil),             // 5: api.Response
+func (x *PathFailuresResponse) Reset() {
+	*x = PathFailuresResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_pfcpsim_proto_depIdxs = []int32{
-	2, // 0: api.PFCPSim.Configure:input_type -> api.ConfigureRequest
-	4, // 1: api.PFCPSim.Associate:input_type -> api.EmptyRequest
-	4, // 2: api.PFCPSim.Disassociate:input_type -> api.EmptyRequest
-	0, // 3: api.PFCPSim.CreateSession:input_type -> api.CreateSessionRequest
-	1, // 4: api.PFCPSim.ModifySession:input_type -> api.ModifySessionRequest
-	3, // 5: api.PFCPSim.DeleteSession:input_type -> api.DeleteSessionRequest
-	5, // 6: api.PFCPSim.Configure:output_type -> api.Response
-	5, // 7: api.PFCPSim.Associate:output_type -> api.Response
-	5, // 8: api.PFCPSim.Disassociate:output_type -> api.Response
-	5, // 9: api.PFCPSim.CreateSession:output_type -> api.Response
-	5, // 10: api.PFCPSim.ModifySession:output_type -> api.Response
-	5, // 11: api.PFCPSim.DeleteSession:output_type -> api.Response
-	6, // [6:12] is the sub-list for method output_type
-	0, // [0:6] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *PathFailuresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_pfcpsim_proto_init() }
-func file_pfcpsim_proto_init() {
-	if File_pfcpsim_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_pfcpsim_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateSessionRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+func (*PathFailuresResponse) ProtoMessage() {}
+
+func (x *PathFailuresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pfcpsim_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ModifySessionRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathFailuresResponse.ProtoReflect.Descriptor instead.
+func (*PathFailuresResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PathFailuresResponse) GetFailures() []*PathFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type UPFunctionFeaturesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// features is the payload of the UP Function Features IE received from the remote peer during association setup.
+	// It is empty if the remote peer did not advertise any
+	Features []byte `protobuf:"bytes,1,opt,name=features,proto3" json:"features,omitempty"`
+	// names are the names of the features set, as in 3GPP TS 29.244 (e.g. "FTUP")
+	Names []string `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *UPFunctionFeaturesResponse) Reset() {
+	*x = UPFunctionFeaturesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UPFunctionFeaturesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UPFunctionFeaturesResponse) ProtoMessage() {}
+
+func (x *UPFunctionFeaturesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UPFunctionFeaturesResponse.ProtoReflect.Descriptor instead.
+func (*UPFunctionFeaturesResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UPFunctionFeaturesResponse) GetFeatures() []byte {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *UPFunctionFeaturesResponse) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type StateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// path is the JSON file the active sessions are dumped to or loaded from
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *StateRequest) Reset() {
+	*x = StateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateRequest) ProtoMessage() {}
+
+func (x *StateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateRequest.ProtoReflect.Descriptor instead.
+func (*StateRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StateRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type EmptyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EmptyRequest) Reset() {
+	*x = EmptyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmptyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyRequest) ProtoMessage() {}
+
+func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
+func (*EmptyRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{10}
+}
+
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// cause is the PFCP Cause value received from the remote peer, if any
+	Cause uint32 `protobuf:"varint,3,opt,name=cause,proto3" json:"cause,omitempty"`
+	// failedRules are the rules the remote peer reported as failed while accepting a session modification
+	FailedRules []*FailedRule `protobuf:"bytes,4,rep,name=failedRules,proto3" json:"failedRules,omitempty"`
+	// usageReports are the "usage" reports returned by the remote peer for the URRs queried by ModifySession
+	UsageReports []*SessionReport `protobuf:"bytes,5,rep,name=usageReports,proto3" json:"usageReports,omitempty"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Response) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *Response) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Response) GetCause() uint32 {
+	if x != nil {
+		return x.Cause
+	}
+	return 0
+}
+
+func (x *Response) GetFailedRules() []*FailedRule {
+	if x != nil {
+		return x.FailedRules
+	}
+	return nil
+}
+
+func (x *Response) GetUsageReports() []*SessionReport {
+	if x != nil {
+		return x.UsageReports
+	}
+	return nil
+}
+
+// FailedRule identifies a rule reported by the remote peer with a Failed Rule ID IE
+type FailedRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type is either "PDR", "FAR", "QER", "URR" or "BAR"
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id   uint32 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *FailedRule) Reset() {
+	*x = FailedRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FailedRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedRule) ProtoMessage() {}
+
+func (x *FailedRule) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedRule.ProtoReflect.Descriptor instead.
+func (*FailedRule) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FailedRule) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *FailedRule) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// CreatedSession identifies a session established by CreateSession
+type CreatedSession struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BaseID int32 `protobuf:"varint,1,opt,name=baseID,proto3" json:"baseID,omitempty"`
+	// localSEID and localAddress form the F-SEID allocated by pfcpsim for the session
+	LocalSEID    uint64 `protobuf:"varint,2,opt,name=localSEID,proto3" json:"localSEID,omitempty"`
+	LocalAddress string `protobuf:"bytes,3,opt,name=localAddress,proto3" json:"localAddress,omitempty"`
+	// ueAddress and ueIPv6Address are empty if the UE has no address of the given IP version
+	UeAddress     string `protobuf:"bytes,4,opt,name=ueAddress,proto3" json:"ueAddress,omitempty"`
+	UeIPv6Address string `protobuf:"bytes,5,opt,name=ueIPv6Address,proto3" json:"ueIPv6Address,omitempty"`
+	// uplinkTEID is the TEID allocated by pfcpsim for the session. If allocated by the remote peer, it is the one
+	// reported in the Created PDR of the first uplink PDR, 0 if not reported
+	UplinkTEID uint32 `protobuf:"varint,6,opt,name=uplinkTEID,proto3" json:"uplinkTEID,omitempty"`
+	// failedRules are the rules the remote peer reported as failed while accepting the session establishment
+	FailedRules []*FailedRule `protobuf:"bytes,7,rep,name=failedRules,proto3" json:"failedRules,omitempty"`
+	// establishmentRequest is the hex-encoded Session Establishment Request built for the session in dry runs
+	EstablishmentRequest string `protobuf:"bytes,8,opt,name=establishmentRequest,proto3" json:"establishmentRequest,omitempty"`
+}
+
+func (x *CreatedSession) Reset() {
+	*x = CreatedSession{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreatedSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatedSession) ProtoMessage() {}
+
+func (x *CreatedSession) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatedSession.ProtoReflect.Descriptor instead.
+func (*CreatedSession) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreatedSession) GetBaseID() int32 {
+	if x != nil {
+		return x.BaseID
+	}
+	return 0
+}
+
+func (x *CreatedSession) GetLocalSEID() uint64 {
+	if x != nil {
+		return x.LocalSEID
+	}
+	return 0
+}
+
+func (x *CreatedSession) GetLocalAddress() string {
+	if x != nil {
+		return x.LocalAddress
+	}
+	return ""
+}
+
+func (x *CreatedSession) GetUeAddress() string {
+	if x != nil {
+		return x.UeAddress
+	}
+	return ""
+}
+
+func (x *CreatedSession) GetUeIPv6Address() string {
+	if x != nil {
+		return x.UeIPv6Address
+	}
+	return ""
+}
+
+func (x *CreatedSession) GetUplinkTEID() uint32 {
+	if x != nil {
+		return x.UplinkTEID
+	}
+	return 0
+}
+
+func (x *CreatedSession) GetFailedRules() []*FailedRule {
+	if x != nil {
+		return x.FailedRules
+	}
+	return nil
+}
+
+func (x *CreatedSession) GetEstablishmentRequest() string {
+	if x != nil {
+		return x.EstablishmentRequest
+	}
+	return ""
+}
+
+type CreateSessionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// sessions are ordered by base ID
+	Sessions []*CreatedSession `protobuf:"bytes,3,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *CreateSessionResponse) Reset() {
+	*x = CreateSessionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionResponse) ProtoMessage() {}
+
+func (x *CreateSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateSessionResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateSessionResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *CreateSessionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateSessionResponse) GetSessions() []*CreatedSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type ClearAllSessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// deleted is the number of sessions deleted from the remote peer
+	Deleted int32 `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// failedBaseIDs identify the sessions the remote peer failed to delete.
+	// They are forgotten by pfcpsim anyway
+	FailedBaseIDs []int32 `protobuf:"varint,4,rep,packed,name=failedBaseIDs,proto3" json:"failedBaseIDs,omitempty"`
+}
+
+func (x *ClearAllSessionsResponse) Reset() {
+	*x = ClearAllSessionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearAllSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearAllSessionsResponse) ProtoMessage() {}
+
+func (x *ClearAllSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearAllSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ClearAllSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ClearAllSessionsResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *ClearAllSessionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ClearAllSessionsResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *ClearAllSessionsResponse) GetFailedBaseIDs() []int32 {
+	if x != nil {
+		return x.FailedBaseIDs
+	}
+	return nil
+}
+
+type SessionReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// seid is the local SEID of the reported session
+	Seid uint64 `protobuf:"varint,1,opt,name=seid,proto3" json:"seid,omitempty"`
+	// type is either "usage", "downlink-data", "error-indication", "peer-restart", "n4-path-failure" or "n4-path-recovery".
+	// A "peer-restart" report is sent for each session made stale by a restart of the remote peer,
+	// or once with seid 0 if no session was active.
+	// "n4-path-failure" and "n4-path-recovery" reports are sent in the same way when the remote peer stops
+	// answering heartbeats or the N4 socket fails, making the association inactive, and when it answers again.
+	// With the automatic re-association enabled, a "reassociation-failure" report with seid 0 is sent for every
+	// failed attempt to set up the association again, and a "reassociation" report once it is set up.
+	Type           string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	UrrID          uint32 `protobuf:"varint,3,opt,name=urrID,proto3" json:"urrID,omitempty"`
+	TotalVolume    uint64 `protobuf:"varint,4,opt,name=totalVolume,proto3" json:"totalVolume,omitempty"`
+	UplinkVolume   uint64 `protobuf:"varint,5,opt,name=uplinkVolume,proto3" json:"uplinkVolume,omitempty"`
+	DownlinkVolume uint64 `protobuf:"varint,6,opt,name=downlinkVolume,proto3" json:"downlinkVolume,omitempty"`
+	// duration is the measured duration in seconds
+	Duration uint32 `protobuf:"varint,7,opt,name=duration,proto3" json:"duration,omitempty"`
+	// attempt is the number of the re-association attempt of the "reassociation-failure" and "reassociation" reports
+	Attempt uint32 `protobuf:"varint,8,opt,name=attempt,proto3" json:"attempt,omitempty"`
+}
+
+func (x *SessionReport) Reset() {
+	*x = SessionReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionReport) ProtoMessage() {}
+
+func (x *SessionReport) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionReport.ProtoReflect.Descriptor instead.
+func (*SessionReport) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SessionReport) GetSeid() uint64 {
+	if x != nil {
+		return x.Seid
+	}
+	return 0
+}
+
+func (x *SessionReport) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SessionReport) GetUrrID() uint32 {
+	if x != nil {
+		return x.UrrID
+	}
+	return 0
+}
+
+func (x *SessionReport) GetTotalVolume() uint64 {
+	if x != nil {
+		return x.TotalVolume
+	}
+	return 0
+}
+
+func (x *SessionReport) GetUplinkVolume() uint64 {
+	if x != nil {
+		return x.UplinkVolume
+	}
+	return 0
+}
+
+func (x *SessionReport) GetDownlinkVolume() uint64 {
+	if x != nil {
+		return x.DownlinkVolume
+	}
+	return 0
+}
+
+func (x *SessionReport) GetDuration() uint32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *SessionReport) GetAttempt() uint32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+// PFCPCause is attached to the details of the gRPC errors of the session operations rejected by the remote peer
+type PFCPCause struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// cause is the value of the Cause IE of the response, e.g. 73 for "Rule creation/modification Failure"
+	Cause       uint32 `protobuf:"varint,1,opt,name=cause,proto3" json:"cause,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// failedRule is the rule reported by the Failed Rule ID IE of the response, if any
+	FailedRule *FailedRule `protobuf:"bytes,3,opt,name=failedRule,proto3" json:"failedRule,omitempty"`
+	// offendingIE is the IE type reported by the Offending IE IE of the response, 0 if none
+	OffendingIE uint32 `protobuf:"varint,4,opt,name=offendingIE,proto3" json:"offendingIE,omitempty"`
+}
+
+func (x *PFCPCause) Reset() {
+	*x = PFCPCause{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PFCPCause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PFCPCause) ProtoMessage() {}
+
+func (x *PFCPCause) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PFCPCause.ProtoReflect.Descriptor instead.
+func (*PFCPCause) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PFCPCause) GetCause() uint32 {
+	if x != nil {
+		return x.Cause
+	}
+	return 0
+}
+
+func (x *PFCPCause) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PFCPCause) GetFailedRule() *FailedRule {
+	if x != nil {
+		return x.FailedRule
+	}
+	return nil
+}
+
+func (x *PFCPCause) GetOffendingIE() uint32 {
+	if x != nil {
+		return x.OffendingIE
+	}
+	return 0
+}
+
+type GTPUEchoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// timeout is the time to wait for the Echo Response, in milliseconds. Default is 1000
+	Timeout int32 `protobuf:"varint,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *GTPUEchoRequest) Reset() {
+	*x = GTPUEchoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GTPUEchoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GTPUEchoRequest) ProtoMessage() {}
+
+func (x *GTPUEchoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GTPUEchoRequest.ProtoReflect.Descriptor instead.
+func (*GTPUEchoRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GTPUEchoRequest) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+type GTPUEchoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// reachable is set if the UPF answered within the timeout
+	Reachable bool `protobuf:"varint,3,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	// roundTripTime is the time elapsed between the Echo Request and the Echo Response, in microseconds
+	RoundTripTime int64 `protobuf:"varint,4,opt,name=roundTripTime,proto3" json:"roundTripTime,omitempty"`
+}
+
+func (x *GTPUEchoResponse) Reset() {
+	*x = GTPUEchoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GTPUEchoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GTPUEchoResponse) ProtoMessage() {}
+
+func (x *GTPUEchoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GTPUEchoResponse.ProtoReflect.Descriptor instead.
+func (*GTPUEchoResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GTPUEchoResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *GTPUEchoResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GTPUEchoResponse) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *GTPUEchoResponse) GetRoundTripTime() int64 {
+	if x != nil {
+		return x.RoundTripTime
+	}
+	return 0
+}
+
+type HealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// status summarizes the fields below: "not configured", "configured", "associated" or "n4 path down"
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// configured is set once Configure succeeded
+	Configured bool `protobuf:"varint,2,opt,name=configured,proto3" json:"configured,omitempty"`
+	// associated is set while the association with the remote peer is active
+	Associated bool `protobuf:"varint,3,opt,name=associated,proto3" json:"associated,omitempty"`
+	// n4PathUp is set while the remote peer answers the heartbeats. It is unset if not associated
+	N4PathUp       bool  `protobuf:"varint,4,opt,name=n4PathUp,proto3" json:"n4PathUp,omitempty"`
+	ActiveSessions int32 `protobuf:"varint,5,opt,name=activeSessions,proto3" json:"activeSessions,omitempty"`
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *HealthResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetConfigured() bool {
+	if x != nil {
+		return x.Configured
+	}
+	return false
+}
+
+func (x *HealthResponse) GetAssociated() bool {
+	if x != nil {
+		return x.Associated
+	}
+	return false
+}
+
+func (x *HealthResponse) GetN4PathUp() bool {
+	if x != nil {
+		return x.N4PathUp
+	}
+	return false
+}
+
+func (x *HealthResponse) GetActiveSessions() int32 {
+	if x != nil {
+		return x.ActiveSessions
+	}
+	return 0
+}
+
+type AssociationStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// associated is unset if pfcpsim is not associated with the remote peer. The other fields are then empty
+	Associated bool   `protobuf:"varint,1,opt,name=associated,proto3" json:"associated,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// peerAddress is the address of the remote peer, as configured
+	PeerAddress string `protobuf:"bytes,3,opt,name=peerAddress,proto3" json:"peerAddress,omitempty"`
+	// peerNodeID is the Node ID advertised by the remote peer during association setup
+	PeerNodeID string `protobuf:"bytes,4,opt,name=peerNodeID,proto3" json:"peerNodeID,omitempty"`
+	// recoveryTimeStamp and peerRecoveryTimeStamp are the Recovery Time Stamps of pfcpsim and of the remote peer, in RFC 3339 format
+	RecoveryTimeStamp     string `protobuf:"bytes,5,opt,name=recoveryTimeStamp,proto3" json:"recoveryTimeStamp,omitempty"`
+	PeerRecoveryTimeStamp string `protobuf:"bytes,6,opt,name=peerRecoveryTimeStamp,proto3" json:"peerRecoveryTimeStamp,omitempty"`
+	// associatedAt is the time the association was set up, in RFC 3339 format
+	AssociatedAt string `protobuf:"bytes,7,opt,name=associatedAt,proto3" json:"associatedAt,omitempty"`
+	// associatedFor is the time elapsed since the association was set up, in milliseconds
+	AssociatedFor int64 `protobuf:"varint,8,opt,name=associatedFor,proto3" json:"associatedFor,omitempty"`
+	// upFunctionFeatures and upFunctionFeatureNames are the UP function features advertised by the remote peer.
+	// cpFunctionFeatures is the octet of CP function features advertised by pfcpsim
+	UpFunctionFeatures     []byte   `protobuf:"bytes,9,opt,name=upFunctionFeatures,proto3" json:"upFunctionFeatures,omitempty"`
+	UpFunctionFeatureNames []string `protobuf:"bytes,10,rep,name=upFunctionFeatureNames,proto3" json:"upFunctionFeatureNames,omitempty"`
+	CpFunctionFeatures     uint32   `protobuf:"varint,11,opt,name=cpFunctionFeatures,proto3" json:"cpFunctionFeatures,omitempty"`
+	// n4PathUp is set while the remote peer answers the heartbeats
+	N4PathUp bool `protobuf:"varint,12,opt,name=n4PathUp,proto3" json:"n4PathUp,omitempty"`
+}
+
+func (x *AssociationStatusResponse) Reset() {
+	*x = AssociationStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssociationStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssociationStatusResponse) ProtoMessage() {}
+
+func (x *AssociationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssociationStatusResponse.ProtoReflect.Descriptor instead.
+func (*AssociationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AssociationStatusResponse) GetAssociated() bool {
+	if x != nil {
+		return x.Associated
+	}
+	return false
+}
+
+func (x *AssociationStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AssociationStatusResponse) GetPeerAddress() string {
+	if x != nil {
+		return x.PeerAddress
+	}
+	return ""
+}
+
+func (x *AssociationStatusResponse) GetPeerNodeID() string {
+	if x != nil {
+		return x.PeerNodeID
+	}
+	return ""
+}
+
+func (x *AssociationStatusResponse) GetRecoveryTimeStamp() string {
+	if x != nil {
+		return x.RecoveryTimeStamp
+	}
+	return ""
+}
+
+func (x *AssociationStatusResponse) GetPeerRecoveryTimeStamp() string {
+	if x != nil {
+		return x.PeerRecoveryTimeStamp
+	}
+	return ""
+}
+
+func (x *AssociationStatusResponse) GetAssociatedAt() string {
+	if x != nil {
+		return x.AssociatedAt
+	}
+	return ""
+}
+
+func (x *AssociationStatusResponse) GetAssociatedFor() int64 {
+	if x != nil {
+		return x.AssociatedFor
+	}
+	return 0
+}
+
+func (x *AssociationStatusResponse) GetUpFunctionFeatures() []byte {
+	if x != nil {
+		return x.UpFunctionFeatures
+	}
+	return nil
+}
+
+func (x *AssociationStatusResponse) GetUpFunctionFeatureNames() []string {
+	if x != nil {
+		return x.UpFunctionFeatureNames
+	}
+	return nil
+}
+
+func (x *AssociationStatusResponse) GetCpFunctionFeatures() uint32 {
+	if x != nil {
+		return x.CpFunctionFeatures
+	}
+	return 0
+}
+
+func (x *AssociationStatusResponse) GetN4PathUp() bool {
+	if x != nil {
+		return x.N4PathUp
+	}
+	return false
+}
+
+type LoggingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// level is one of "panic", "fatal", "error", "warning", "info", "debug" or "trace". Unchanged if empty
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// format is either "text" or "json". Unchanged if empty
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+}
+
+func (x *LoggingRequest) Reset() {
+	*x = LoggingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoggingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoggingRequest) ProtoMessage() {}
+
+func (x *LoggingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoggingRequest.ProtoReflect.Descriptor instead.
+func (*LoggingRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LoggingRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *LoggingRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type DisassociateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// keepSessions releases the association without forgetting the active sessions, so that they can
+	// be managed again once associated anew. The active sessions are forgotten if not set
+	KeepSessions bool `protobuf:"varint,1,opt,name=keepSessions,proto3" json:"keepSessions,omitempty"`
+}
+
+func (x *DisassociateRequest) Reset() {
+	*x = DisassociateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisassociateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisassociateRequest) ProtoMessage() {}
+
+func (x *DisassociateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisassociateRequest.ProtoReflect.Descriptor instead.
+func (*DisassociateRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DisassociateRequest) GetKeepSessions() bool {
+	if x != nil {
+		return x.KeepSessions
+	}
+	return false
+}
+
+type InfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// version is the version of pfcpsim, e.g. 0.2.0
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// buildCommit is the commit pfcpsim was built from, "unknown" if not set at build time
+	BuildCommit string `protobuf:"bytes,2,opt,name=buildCommit,proto3" json:"buildCommit,omitempty"`
+	// pfcpFeatures are the PFCP procedures and options supported by pfcpsim, e.g. "session-set-deletion"
+	PfcpFeatures []string `protobuf:"bytes,3,rep,name=pfcpFeatures,proto3" json:"pfcpFeatures,omitempty"`
+}
+
+func (x *InfoResponse) Reset() {
+	*x = InfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoResponse) ProtoMessage() {}
+
+func (x *InfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoResponse.ProtoReflect.Descriptor instead.
+func (*InfoResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *InfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *InfoResponse) GetBuildCommit() string {
+	if x != nil {
+		return x.BuildCommit
+	}
+	return ""
+}
+
+func (x *InfoResponse) GetPfcpFeatures() []string {
+	if x != nil {
+		return x.PfcpFeatures
+	}
+	return nil
+}
+
+// PDRUpdate changes the SDF filter and/or the precedence of a PDR of the sessions
+type PDRUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// pdrID is the ID of the PDR in the session with the baseID of the request.
+	// The IDs of the PDRs of the following sessions are offset as their base IDs
+	PdrID int32 `protobuf:"varint,1,opt,name=pdrID,proto3" json:"pdrID,omitempty"`
+	// appFilter replaces the SDF filter of the PDR, in the same format as appFilters. The current one is kept if empty
+	AppFilter string `protobuf:"bytes,2,opt,name=appFilter,proto3" json:"appFilter,omitempty"`
+	// precedence replaces the precedence of the PDR if not 0. Otherwise, the explicit precedence of appFilter is used, if any
+	Precedence int32 `protobuf:"varint,3,opt,name=precedence,proto3" json:"precedence,omitempty"`
+}
+
+func (x *PDRUpdate) Reset() {
+	*x = PDRUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PDRUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PDRUpdate) ProtoMessage() {}
+
+func (x *PDRUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PDRUpdate.ProtoReflect.Descriptor instead.
+func (*PDRUpdate) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PDRUpdate) GetPdrID() int32 {
+	if x != nil {
+		return x.PdrID
+	}
+	return 0
+}
+
+func (x *PDRUpdate) GetAppFilter() string {
+	if x != nil {
+		return x.AppFilter
+	}
+	return ""
+}
+
+func (x *PDRUpdate) GetPrecedence() int32 {
+	if x != nil {
+		return x.Precedence
+	}
+	return 0
+}
+
+type UpdateAssociationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// cpFunctionFeatures is the 5th octet of the CP Function Features advertised to the remote peer
+	CpFunctionFeatures uint32 `protobuf:"varint,1,opt,name=cpFunctionFeatures,proto3" json:"cpFunctionFeatures,omitempty"`
+	// gracefulReleasePeriodMs, if not 0, is sent in the Graceful Release Period IE, in milliseconds
+	GracefulReleasePeriodMs int32 `protobuf:"varint,2,opt,name=gracefulReleasePeriodMs,proto3" json:"gracefulReleasePeriodMs,omitempty"`
+	// peer is the address of the remote peer to update the association with, one of the additional peers.
+	// If empty, remotePeerAddress is used
+	Peer string `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+}
+
+func (x *UpdateAssociationRequest) Reset() {
+	*x = UpdateAssociationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateAssociationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAssociationRequest) ProtoMessage() {}
+
+func (x *UpdateAssociationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAssociationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAssociationRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateAssociationRequest) GetCpFunctionFeatures() uint32 {
+	if x != nil {
+		return x.CpFunctionFeatures
+	}
+	return 0
+}
+
+func (x *UpdateAssociationRequest) GetGracefulReleasePeriodMs() int32 {
+	if x != nil {
+		return x.GracefulReleasePeriodMs
+	}
+	return 0
+}
+
+func (x *UpdateAssociationRequest) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+type BufferAllSessionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// suggestedBufferingPacketsCount and dlDataNotificationDelayMs are sent in the session BARs,
+	// as in ModifySessionRequest
+	SuggestedBufferingPacketsCount int32 `protobuf:"varint,1,opt,name=suggestedBufferingPacketsCount,proto3" json:"suggestedBufferingPacketsCount,omitempty"`
+	DlDataNotificationDelayMs      int32 `protobuf:"varint,2,opt,name=dlDataNotificationDelayMs,proto3" json:"dlDataNotificationDelayMs,omitempty"`
+}
+
+func (x *BufferAllSessionsRequest) Reset() {
+	*x = BufferAllSessionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BufferAllSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BufferAllSessionsRequest) ProtoMessage() {}
+
+func (x *BufferAllSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BufferAllSessionsRequest.ProtoReflect.Descriptor instead.
+func (*BufferAllSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BufferAllSessionsRequest) GetSuggestedBufferingPacketsCount() int32 {
+	if x != nil {
+		return x.SuggestedBufferingPacketsCount
+	}
+	return 0
+}
+
+func (x *BufferAllSessionsRequest) GetDlDataNotificationDelayMs() int32 {
+	if x != nil {
+		return x.DlDataNotificationDelayMs
+	}
+	return 0
+}
+
+type BufferAllSessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,proto3" json:"statusCode,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// modified is the number of sessions whose downlink traffic is now buffered by the remote peer
+	Modified int32 `protobuf:"varint,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	// failedBaseIDs identify the sessions that could not be modified, e.g. because stale or rejected by the remote peer
+	FailedBaseIDs []int32 `protobuf:"varint,4,rep,name=failedBaseIDs,proto3" json:"failedBaseIDs,omitempty"`
+}
+
+func (x *BufferAllSessionsResponse) Reset() {
+	*x = BufferAllSessionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BufferAllSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BufferAllSessionsResponse) ProtoMessage() {}
+
+func (x *BufferAllSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BufferAllSessionsResponse.ProtoReflect.Descriptor instead.
+func (*BufferAllSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BufferAllSessionsResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *BufferAllSessionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BufferAllSessionsResponse) GetModified() int32 {
+	if x != nil {
+		return x.Modified
+	}
+	return 0
+}
+
+func (x *BufferAllSessionsResponse) GetFailedBaseIDs() []int32 {
+	if x != nil {
+		return x.FailedBaseIDs
+	}
+	return nil
+}
+
+type CreateSessionAndDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// iterations is the number of times a session is established and deleted right after
+	Iterations int32 `protobuf:"varint,1,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	// baseID identifies the sessions as in CreateSessionRequest. Each of the concurrent cycles uses its own baseID,
+	// starting from baseID with the usual step: none of them may identify an active session
+	BaseID        int32  `protobuf:"varint,2,opt,name=baseID,proto3" json:"baseID,omitempty"`
+	NodeBAddress  string `protobuf:"bytes,3,opt,name=nodeBAddress,proto3" json:"nodeBAddress,omitempty"`
+	UeAddressPool string `protobuf:"bytes,4,opt,name=ueAddressPool,proto3" json:"ueAddressPool,omitempty"`
+	// concurrency is the number of cycles run at the same time. Default is 1
+	Concurrency int32 `protobuf:"varint,5,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	// peer is the remote peer the sessions are established with, see CreateSessionRequest
+	Peer string `protobuf:"bytes,6,opt,name=peer,proto3" json:"peer,omitempty"`
+}
+
+func (x *CreateSessionAndDeleteRequest) Reset() {
+	*x = CreateSessionAndDeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSessionAndDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionAndDeleteRequest) ProtoMessage() {}
+
+func (x *CreateSessionAndDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionAndDeleteRequest.ProtoReflect.Descriptor instead.
+func (*CreateSessionAndDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateSessionAndDeleteRequest) GetIterations() int32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *CreateSessionAndDeleteRequest) GetBaseID() int32 {
+	if x != nil {
+		return x.BaseID
+	}
+	return 0
+}
+
+func (x *CreateSessionAndDeleteRequest) GetNodeBAddress() string {
+	if x != nil {
+		return x.NodeBAddress
+	}
+	return ""
+}
+
+func (x *CreateSessionAndDeleteRequest) GetUeAddressPool() string {
+	if x != nil {
+		return x.UeAddressPool
+	}
+	return ""
+}
+
+func (x *CreateSessionAndDeleteRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *CreateSessionAndDeleteRequest) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+type CreateSessionAndDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,proto3" json:"statusCode,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// cycles is the number of sessions both established and deleted
+	Cycles int32 `protobuf:"varint,3,opt,name=cycles,proto3" json:"cycles,omitempty"`
+	// establishmentErrors and deletionErrors count the sessions that could not be established or deleted
+	EstablishmentErrors int32 `protobuf:"varint,4,opt,name=establishmentErrors,proto3" json:"establishmentErrors,omitempty"`
+	DeletionErrors      int32 `protobuf:"varint,5,opt,name=deletionErrors,proto3" json:"deletionErrors,omitempty"`
+	// cyclesPerSecond is the number of cycles completed per second
+	CyclesPerSecond float64 `protobuf:"fixed64,6,opt,name=cyclesPerSecond,proto3" json:"cyclesPerSecond,omitempty"`
+}
+
+func (x *CreateSessionAndDeleteResponse) Reset() {
+	*x = CreateSessionAndDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSessionAndDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionAndDeleteResponse) ProtoMessage() {}
+
+func (x *CreateSessionAndDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionAndDeleteResponse.ProtoReflect.Descriptor instead.
+func (*CreateSessionAndDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateSessionAndDeleteResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *CreateSessionAndDeleteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateSessionAndDeleteResponse) GetCycles() int32 {
+	if x != nil {
+		return x.Cycles
+	}
+	return 0
+}
+
+func (x *CreateSessionAndDeleteResponse) GetEstablishmentErrors() int32 {
+	if x != nil {
+		return x.EstablishmentErrors
+	}
+	return 0
+}
+
+func (x *CreateSessionAndDeleteResponse) GetDeletionErrors() int32 {
+	if x != nil {
+		return x.DeletionErrors
+	}
+	return 0
+}
+
+func (x *CreateSessionAndDeleteResponse) GetCyclesPerSecond() float64 {
+	if x != nil {
+		return x.CyclesPerSecond
+	}
+	return 0
+}
+
+type RecoveryTimeStampRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// timestamp is the new Recovery Time Stamp in RFC 3339 format, e.g. 2022-06-01T12:00:00Z
+	Timestamp string `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// advanceSeconds, if timestamp is empty, advances the current Recovery Time Stamp by this number of seconds.
+	// If both are not set, the Recovery Time Stamp is set to the current time
+	AdvanceSeconds int32 `protobuf:"varint,2,opt,name=advanceSeconds,proto3" json:"advanceSeconds,omitempty"`
+	// peer is the address of the remote peer to re-associate with, one of the additional peers.
+	// If empty, remotePeerAddress is used
+	Peer string `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+}
+
+func (x *RecoveryTimeStampRequest) Reset() {
+	*x = RecoveryTimeStampRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecoveryTimeStampRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryTimeStampRequest) ProtoMessage() {}
+
+func (x *RecoveryTimeStampRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryTimeStampRequest.ProtoReflect.Descriptor instead.
+func (*RecoveryTimeStampRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RecoveryTimeStampRequest) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *RecoveryTimeStampRequest) GetAdvanceSeconds() int32 {
+	if x != nil {
+		return x.AdvanceSeconds
+	}
+	return 0
+}
+
+func (x *RecoveryTimeStampRequest) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+// FailedDeletion identifies a session DeleteSession could not delete
+type FailedDeletion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BaseID int32 `protobuf:"varint,1,opt,name=baseID,proto3" json:"baseID,omitempty"`
+	// error tells why the session could not be deleted
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// cause is the PFCP Cause value received from the remote peer, if it rejected the deletion
+	Cause uint32 `protobuf:"varint,3,opt,name=cause,proto3" json:"cause,omitempty"`
+}
+
+func (x *FailedDeletion) Reset() {
+	*x = FailedDeletion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FailedDeletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedDeletion) ProtoMessage() {}
+
+func (x *FailedDeletion) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedDeletion.ProtoReflect.Descriptor instead.
+func (*FailedDeletion) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *FailedDeletion) GetBaseID() int32 {
+	if x != nil {
+		return x.BaseID
+	}
+	return 0
+}
+
+func (x *FailedDeletion) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FailedDeletion) GetCause() uint32 {
+	if x != nil {
+		return x.Cause
+	}
+	return 0
+}
+
+type DeleteSessionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,proto3" json:"statusCode,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// deletedBaseIDs identify the sessions deleted, in base ID order
+	DeletedBaseIDs []int32 `protobuf:"varint,3,rep,name=deletedBaseIDs,proto3" json:"deletedBaseIDs,omitempty"`
+	// failures identify the sessions that could not be deleted, in base ID order. They are still active
+	Failures []*FailedDeletion `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+	// activeSessions is the number of active sessions left
+	ActiveSessions int32 `protobuf:"varint,5,opt,name=activeSessions,proto3" json:"activeSessions,omitempty"`
+}
+
+func (x *DeleteSessionResponse) Reset() {
+	*x = DeleteSessionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pfcpsim_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSessionResponse) ProtoMessage() {}
+
+func (x *DeleteSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSessionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSessionResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteSessionResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *DeleteSessionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeleteSessionResponse) GetDeletedBaseIDs() []int32 {
+	if x != nil {
+		return x.DeletedBaseIDs
+	}
+	return nil
+}
+
+func (x *DeleteSessionResponse) GetFailures() []*FailedDeletion {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *DeleteSessionResponse) GetActiveSessions() int32 {
+	if x != nil {
+		return x.ActiveSessions
+	}
+	return 0
+}
+
+var File_pfcpsim_proto protoreflect.FileDescriptor
+
+var file_pfcpsim_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x70, 0x66, 0x63, 0x70, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x03, 0x61, 0x70, 0x69, 0x22, 0x85, 0x0a, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x6e,
+	0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x66, 0x69, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x71, 0x66, 0x69, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x6c, 0x54, 0x75, 0x6e,
+	0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x75, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x12, 0x24, 0x0a,
+	0x0d, 0x64, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73,
+	0x74, 0x49, 0x50, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x65, 0x69, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
+	0x46, 0x6c, 0x61, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x65, 0x69, 0x64,
+	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6c, 0x41,
+	0x6d, 0x62, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x6c, 0x41, 0x6d, 0x62,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6c, 0x41, 0x6d, 0x62, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x64, 0x6c, 0x41, 0x6d, 0x62, 0x72, 0x12, 0x32, 0x0a, 0x14, 0x62, 0x69, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x44, 0x46, 0x46, 0x6c, 0x61,
+	0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x62, 0x69, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x44, 0x46, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x26, 0x0a,
+	0x0e, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x18,
+	0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x3a, 0x0a, 0x18, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x6f, 0x62, 0x69,
+	0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x6f, 0x62, 0x69,
+	0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x2c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x26, 0x0a, 0x07, 0x70, 0x64, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x64, 0x6e, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x07, 0x70, 0x64, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x75, 0x65, 0x49,
+	0x50, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x75, 0x65, 0x49, 0x50, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x74, 0x6c, 0x4d, 0x73,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x74, 0x6c, 0x4d, 0x73, 0x12, 0x26, 0x0a,
+	0x0e, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x51, 0x45, 0x52, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x51, 0x45, 0x52, 0x12, 0x3b, 0x0a, 0x0e, 0x74, 0x65, 0x69, 0x64, 0x41, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x69, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0e, 0x74, 0x65, 0x69, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x12, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x53, 0x44, 0x46, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x44, 0x46, 0x46, 0x6c,
+	0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x18, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x11, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x12, 0x3e, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x63, 0x65, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x50, 0x72, 0x65, 0x63, 0x65, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x63, 0x65, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x51, 0x45, 0x52, 0x46, 0x69,
+	0x72, 0x73, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x70, 0x70, 0x51, 0x45,
+	0x52, 0x46, 0x69, 0x72, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6c, 0x47, 0x62, 0x72, 0x18,
+	0x1b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x75, 0x6c, 0x47, 0x62, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x64, 0x6c, 0x47, 0x62, 0x72, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x6c, 0x47,
+	0x62, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x55, 0x6c, 0x4d, 0x62, 0x72, 0x18, 0x1d,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x70, 0x70, 0x55, 0x6c, 0x4d, 0x62, 0x72, 0x12, 0x1a,
+	0x0a, 0x08, 0x61, 0x70, 0x70, 0x44, 0x6c, 0x4d, 0x62, 0x72, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x61, 0x70, 0x70, 0x44, 0x6c, 0x4d, 0x62, 0x72, 0x12, 0x49, 0x0a, 0x12, 0x75, 0x70,
+	0x6c, 0x69, 0x6e, 0x6b, 0x44, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x18, 0x1f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x52, 0x12, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x44, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x14, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x44, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x20, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x14,
+	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x44, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x21,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0x9e, 0x05, 0x0a,
+	0x14, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61, 0x73,
+	0x65, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x1e, 0x0a,
+	0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x22, 0x0a,
+	0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x43, 0x50, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x43, 0x50, 0x46, 0x6c, 0x61,
+	0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x65, 0x6e, 0x64, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x46, 0x6c,
+	0x61, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65, 0x6e, 0x64, 0x4d, 0x61, 0x72,
+	0x6b, 0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x6c, 0x54, 0x75, 0x6e,
+	0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x75, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x12, 0x24, 0x0a,
+	0x0d, 0x64, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73, 0x74, 0x49, 0x50, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x73,
+	0x74, 0x49, 0x50, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6c, 0x41, 0x6d, 0x62, 0x72, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x6c, 0x41, 0x6d, 0x62, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x6c, 0x41, 0x6d, 0x62, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x64, 0x6c, 0x41,
+	0x6d, 0x62, 0x72, 0x12, 0x30, 0x0a, 0x13, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x45, 0x6e, 0x64,
+	0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x13, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x45, 0x6e, 0x64, 0x4d, 0x61, 0x72, 0x6b, 0x65,
+	0x72, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x46, 0x0a, 0x1e, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x1e, 0x73,
+	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e,
+	0x67, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3c, 0x0a,
+	0x19, 0x64, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x19, 0x64, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x2e, 0x0a, 0x0a, 0x70,
+	0x64, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x44, 0x52, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x0a, 0x70, 0x64, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x55, 0x52, 0x52, 0x49, 0x44, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x55, 0x52, 0x52, 0x49, 0x44, 0x73, 0x22, 0xe7, 0x07,
+	0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x75, 0x70, 0x66, 0x4e, 0x33, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x75, 0x70, 0x66, 0x4e, 0x33, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x50, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x12, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e, 0x34, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x4e, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x66, 0x63, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x66, 0x63, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x2e, 0x0a, 0x12, 0x63, 0x70,
+	0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x63, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x17, 0x61, 0x64,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x50, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x17, 0x61, 0x64, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x50, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x4d, 0x69, 0x73, 0x73, 0x65,
+	0x64, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x48, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x1c, 0x72, 0x65, 0x65, 0x73, 0x74, 0x61,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x4f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x64, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1c, 0x72, 0x65,
+	0x65, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x4f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x73,
+	0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x73, 0x69, 0x64, 0x12, 0x1e,
+	0x0a, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x12, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x12, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x32, 0x0a, 0x14, 0x65, 0x73, 0x74, 0x61, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x65, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x6d,
+	0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x6f,
+	0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x28, 0x0a, 0x0f,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62,
+	0x65, 0x61, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x10, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x12, 0x3b, 0x0a, 0x0e, 0x73, 0x65, 0x69, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x53, 0x65, 0x69, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0e, 0x73, 0x65, 0x69, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x36, 0x0a, 0x16, 0x72, 0x65, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x4d, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x16, 0x72, 0x65, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61,
+	0x63, 0x6b, 0x6f, 0x66, 0x66, 0x4d, 0x73, 0x12, 0x34, 0x0a, 0x15, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x28, 0x0a,
+	0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x18, 0x17, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x50,
+	0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x44, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x22, 0x99, 0x01,
+	0x0a, 0x0f, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x46, 0x44,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x2a, 0x0a, 0x10, 0x66, 0x6c, 0x6f, 0x77, 0x44,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x10, 0x66, 0x6c, 0x6f, 0x77, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x50, 0x0a, 0x14, 0x50, 0x46, 0x44,
+	0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x38, 0x0a, 0x0c, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x46, 0x44, 0x73, 0x52, 0x0c, 0x61,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x67, 0x0a, 0x0b, 0x50,
+	0x61, 0x74, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f,
+	0x64, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65,
+	0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50,
+	0x65, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0x44, 0x0a, 0x14, 0x50, 0x61, 0x74, 0x68, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x08,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x4e, 0x0a, 0x1a, 0x55, 0x50,
+	0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x22, 0x0a, 0x0c, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x0e,
+	0x0a, 0x0c, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc6,
+	0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x0b,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x52, 0x75,
+	0x6c, 0x65, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12,
+	0x36, 0x0a, 0x0c, 0x75, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x0c, 0x75, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x30, 0x0a, 0x0a, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x22, 0xb5, 0x02, 0x0a, 0x0e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61,
+	0x73, 0x65, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x45, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x45,
+	0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x65, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x65, 0x49, 0x50, 0x76, 0x36, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x75, 0x65, 0x49,
+	0x50, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x70,
+	0x6c, 0x69, 0x6e, 0x6b, 0x54, 0x45, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x54, 0x45, 0x49, 0x44, 0x12, 0x31, 0x0a, 0x0b, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65,
+	0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x32, 0x0a,
+	0x14, 0x65, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x65, 0x73, 0x74,
+	0x61, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x83, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x95, 0x01, 0x0a, 0x18, 0x43, 0x6c, 0x65, 0x61,
+	0x72, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x42, 0x61, 0x73, 0x65, 0x49, 0x44, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x73, 0x65, 0x49, 0x44, 0x73, 0x22,
+	0xf1, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x73, 0x65, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x72, 0x72,
+	0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x75, 0x72, 0x72, 0x49, 0x44, 0x12,
+	0x20, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x64,
+	0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x09, 0x50, 0x46, 0x43, 0x50, 0x43, 0x61, 0x75, 0x73,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x0a, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0a,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x66,
+	0x66, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x49, 0x45, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x6f, 0x66, 0x66, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x49, 0x45, 0x22, 0x2b, 0x0a, 0x0f,
+	0x47, 0x54, 0x50, 0x55, 0x45, 0x63, 0x68, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x91, 0x01, 0x0a, 0x10, 0x47, 0x54,
+	0x50, 0x55, 0x45, 0x63, 0x68, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x61,
+	0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65,
+	0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x54, 0x72, 0x69, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x72, 0x69, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xac, 0x01,
+	0x0a, 0x0e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x6f,
+	0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x73,
+	0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x34, 0x50, 0x61,
+	0x74, 0x68, 0x55, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x34, 0x50, 0x61,
+	0x74, 0x68, 0x55, 0x70, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xf9, 0x03, 0x0a,
+	0x19, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x73,
+	0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x4e, 0x6f,
+	0x64, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72,
+	0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x2c, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x34, 0x0a, 0x15, 0x70, 0x65, 0x65, 0x72, 0x52, 0x65, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x15, 0x70, 0x65, 0x65, 0x72, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72,
+	0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x73,
+	0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x24,
+	0x0a, 0x0d, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65,
+	0x64, 0x46, 0x6f, 0x72, 0x12, 0x2e, 0x0a, 0x12, 0x75, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x12, 0x75, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x75, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x75, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12,
+	0x63, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x63, 0x70, 0x46, 0x75, 0x6e, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x6e, 0x34, 0x50, 0x61, 0x74, 0x68, 0x55, 0x70, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x6e, 0x34, 0x50, 0x61, 0x74, 0x68, 0x55, 0x70, 0x22, 0x3e, 0x0a, 0x0e, 0x4c, 0x6f, 0x67, 0x67,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x39, 0x0a, 0x13, 0x44, 0x69, 0x73, 0x61,
+	0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x22, 0x0a, 0x0c, 0x6b, 0x65, 0x65, 0x70, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6b, 0x65, 0x65, 0x70, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x6e, 0x0a, 0x0c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a,
+	0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12,
+	0x22, 0x0a, 0x0c, 0x70, 0x66, 0x63, 0x70, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x66, 0x63, 0x70, 0x46, 0x65, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x22, 0x5f, 0x0a, 0x09, 0x50, 0x44, 0x52, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x64, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x70, 0x64, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x70, 0x70, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x63, 0x65, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x63, 0x65, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x22, 0x98, 0x01, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41,
+	0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2e, 0x0a, 0x12, 0x63, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x63,
+	0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x38, 0x0a, 0x17, 0x67, 0x72, 0x61, 0x63, 0x65, 0x66, 0x75, 0x6c, 0x52, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x4d, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x17, 0x67, 0x72, 0x61, 0x63, 0x65, 0x66, 0x75, 0x6c, 0x52, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x4d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x65, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x22,
+	0xa0, 0x01, 0x0a, 0x18, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x1e,
+	0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69,
+	0x6e, 0x67, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x1e, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42,
+	0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x19, 0x64, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x19, 0x64, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x4e,
+	0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79,
+	0x4d, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x19, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x41, 0x6c, 0x6c,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
+	0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d,
+	0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x42, 0x61, 0x73, 0x65, 0x49, 0x44, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0d,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x73, 0x65, 0x49, 0x44, 0x73, 0x22, 0xd7, 0x01,
+	0x0a, 0x1d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41,
+	0x6e, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e,
+	0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x75,
+	0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f,
+	0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x22, 0xf7, 0x01, 0x0a, 0x1e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x64, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x30, 0x0a,
+	0x13, 0x65, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x65, 0x73, 0x74, 0x61,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12,
+	0x26, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x79, 0x63, 0x6c, 0x65,
+	0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x22, 0x74, 0x0a, 0x18, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d,
+	0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x26, 0x0a, 0x0e, 0x61,
+	0x64, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x61, 0x64, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x22, 0x54, 0x0a, 0x0e, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x73,
+	0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49,
+	0x44, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x22, 0xd3, 0x01,
+	0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x42, 0x61, 0x73,
+	0x65, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x42, 0x61, 0x73, 0x65, 0x49, 0x44, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x2a, 0x2f, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x54, 0x48, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50,
+	0x4c, 0x49, 0x4e, 0x4b, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x4f, 0x57, 0x4e, 0x4c, 0x49,
+	0x4e, 0x4b, 0x10, 0x02, 0x2a, 0x29, 0x0a, 0x07, 0x50, 0x64, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x08, 0x0a, 0x04, 0x49, 0x50, 0x56, 0x34, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x56,
+	0x36, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x50, 0x56, 0x34, 0x56, 0x36, 0x10, 0x02, 0x2a,
+	0x40, 0x0a, 0x0e, 0x54, 0x65, 0x69, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x4c, 0x4f, 0x42, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x11,
+	0x0a, 0x0d, 0x55, 0x50, 0x46, 0x5f, 0x41, 0x4c, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x45, 0x44, 0x10,
+	0x02, 0x2a, 0x5f, 0x0a, 0x0f, 0x50, 0x72, 0x65, 0x63, 0x65, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x45, 0x43, 0x45, 0x44, 0x45, 0x4e,
+	0x43, 0x45, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15,
+	0x50, 0x52, 0x45, 0x43, 0x45, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x49, 0x4e, 0x43, 0x52, 0x45,
+	0x41, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x52, 0x45, 0x43, 0x45,
+	0x44, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x44, 0x45, 0x43, 0x52, 0x45, 0x41, 0x53, 0x49, 0x4e, 0x47,
+	0x10, 0x02, 0x2a, 0xb0, 0x01, 0x0a, 0x14, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x13, 0x44,
+	0x45, 0x53, 0x54, 0x49, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55,
+	0x4c, 0x54, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x44, 0x45, 0x53, 0x54, 0x49, 0x4e, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10,
+	0x44, 0x45, 0x53, 0x54, 0x49, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x4f, 0x52, 0x45,
+	0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x45, 0x53, 0x54, 0x49, 0x4e, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x53, 0x47, 0x49, 0x5f, 0x4c, 0x41, 0x4e, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x44,
+	0x45, 0x53, 0x54, 0x49, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x50, 0x5f, 0x46, 0x55,
+	0x4e, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x44, 0x45, 0x53, 0x54,
+	0x49, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x5f, 0x46, 0x55, 0x4e, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x10, 0x05, 0x2a, 0x48, 0x0a, 0x0e, 0x53, 0x65, 0x69, 0x64, 0x41, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x49, 0x44, 0x5f,
+	0x53, 0x45, 0x51, 0x55, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b,
+	0x53, 0x45, 0x49, 0x44, 0x5f, 0x52, 0x41, 0x4e, 0x44, 0x4f, 0x4d, 0x10, 0x01, 0x12, 0x10, 0x0a,
+	0x0c, 0x53, 0x45, 0x49, 0x44, 0x5f, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x49, 0x44, 0x10, 0x02, 0x32,
+	0xe4, 0x0b, 0x0a, 0x07, 0x50, 0x46, 0x43, 0x50, 0x53, 0x69, 0x6d, 0x12, 0x33, 0x0a, 0x09, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x12, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x2f, 0x0a, 0x09, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x12, 0x11, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x39, 0x0a, 0x0c, 0x44, 0x69, 0x73, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74,
+	0x65, 0x12, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x69, 0x73, 0x61, 0x73, 0x73, 0x6f, 0x63,
+	0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x11,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x73,
+	0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x46, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0d, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0d, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66,
+	0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x48, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x16, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x64, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x64, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x46, 0x0a, 0x10, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6c, 0x65,
+	0x61, 0x72, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x11, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x54, 0x0a, 0x11, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x75, 0x66, 0x66, 0x65,
+	0x72, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72,
+	0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x09, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x09, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x11, 0x53, 0x65, 0x6e, 0x64, 0x50,
+	0x46, 0x44, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x50, 0x46, 0x44, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x11, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x55, 0x50, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x50,
+	0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x45, 0x63,
+	0x68, 0x6f, 0x47, 0x54, 0x50, 0x55, 0x12, 0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x54, 0x50,
+	0x55, 0x45, 0x63, 0x68, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x47, 0x54, 0x50, 0x55, 0x45, 0x63, 0x68, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12,
+	0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x04, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x11, 0x41, 0x73, 0x73,
+	0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x11,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e,
+	0x67, 0x12, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x11, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x22, 0x00, 0x30, 0x01, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x61, 0x70, 0x69, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pfcpsim_proto_rawDescOnce sync.Once
+	file_pfcpsim_proto_rawDescData = file_pfcpsim_proto_rawDesc
+)
+
+func file_pfcpsim_proto_rawDescGZIP() []byte {
+	file_pfcpsim_proto_rawDescOnce.Do(func() {
+		file_pfcpsim_proto_rawDescData = protoimpl.X.CompressGZIP(file_pfcpsim_proto_rawDescData)
+	})
+	return file_pfcpsim_proto_rawDescData
+}
+
+var file_pfcpsim_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_pfcpsim_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_pfcpsim_proto_goTypes = []interface{}{
+	(Direction)(0),                         // 0: api.Direction
+	(PdnType)(0),                           // 1: api.PdnType
+	(TeidAllocation)(0),                    // 2: api.TeidAllocation
+	(PrecedenceOrder)(0),                   // 3: api.PrecedenceOrder
+	(DestinationInterface)(0),              // 4: api.DestinationInterface
+	(SeidAllocation)(0),                    // 5: api.SeidAllocation
+	(*CreateSessionRequest)(nil),           // 6: api.CreateSessionRequest
+	(*ModifySessionRequest)(nil),           // 7: api.ModifySessionRequest
+	(*ConfigureRequest)(nil),               // 8: api.ConfigureRequest
+	(*DeleteSessionRequest)(nil),           // 9: api.DeleteSessionRequest
+	(*ApplicationPFDs)(nil),                // 10: api.ApplicationPFDs
+	(*PFDManagementRequest)(nil),           // 11: api.PFDManagementRequest
+	(*PathFailure)(nil),                    // 12: api.PathFailure
+	(*PathFailuresResponse)(nil),           // 13: api.PathFailuresResponse
+	(*UPFunctionFeaturesResponse)(nil),     // 14: api.UPFunctionFeaturesResponse
+	(*StateRequest)(nil),                   // 15: api.StateRequest
+	(*EmptyRequest)(nil),                   // 16: api.EmptyRequest
+	(*Response)(nil),                       // 17: api.Response
+	(*FailedRule)(nil),                     // 18: api.FailedRule
+	(*CreatedSession)(nil),                 // 19: api.CreatedSession
+	(*CreateSessionResponse)(nil),          // 20: api.CreateSessionResponse
+	(*ClearAllSessionsResponse)(nil),       // 21: api.ClearAllSessionsResponse
+	(*SessionReport)(nil),                  // 22: api.SessionReport
+	(*PFCPCause)(nil),                      // 23: api.PFCPCause
+	(*GTPUEchoRequest)(nil),                // 24: api.GTPUEchoRequest
+	(*GTPUEchoResponse)(nil),               // 25: api.GTPUEchoResponse
+	(*HealthResponse)(nil),                 // 26: api.HealthResponse
+	(*AssociationStatusResponse)(nil),      // 27: api.AssociationStatusResponse
+	(*LoggingRequest)(nil),                 // 28: api.LoggingRequest
+	(*DisassociateRequest)(nil),            // 29: api.DisassociateRequest
+	(*InfoResponse)(nil),                   // 30: api.InfoResponse
+	(*PDRUpdate)(nil),                      // 31: api.PDRUpdate
+	(*UpdateAssociationRequest)(nil),       // 32: api.UpdateAssociationRequest
+	(*BufferAllSessionsRequest)(nil),       // 33: api.BufferAllSessionsRequest
+	(*BufferAllSessionsResponse)(nil),      // 34: api.BufferAllSessionsResponse
+	(*CreateSessionAndDeleteRequest)(nil),  // 35: api.CreateSessionAndDeleteRequest
+	(*CreateSessionAndDeleteResponse)(nil), // 36: api.CreateSessionAndDeleteResponse
+	(*RecoveryTimeStampRequest)(nil),       // 37: api.RecoveryTimeStampRequest
+	(*FailedDeletion)(nil),                 // 38: api.FailedDeletion
+	(*DeleteSessionResponse)(nil),          // 39: api.DeleteSessionResponse
+}
+var file_pfcpsim_proto_depIdxs = []int32{
+	0,  // 0: api.CreateSessionRequest.direction:type_name -> api.Direction
+	1,  // 1: api.CreateSessionRequest.pdnType:type_name -> api.PdnType
+	2,  // 2: api.CreateSessionRequest.teidAllocation:type_name -> api.TeidAllocation
+	3,  // 3: api.CreateSessionRequest.precedenceOrder:type_name -> api.PrecedenceOrder
+	4,  // 4: api.CreateSessionRequest.uplinkDstInterface:type_name -> api.DestinationInterface
+	4,  // 5: api.CreateSessionRequest.downlinkDstInterface:type_name -> api.DestinationInterface
+	31, // 6: api.ModifySessionRequest.pdrUpdates:type_name -> api.PDRUpdate
+	5,  // 7: api.ConfigureRequest.seidAllocation:type_name -> api.SeidAllocation
+	10, // 8: api.PFDManagementRequest.applications:type_name -> api.ApplicationPFDs
+	12, // 9: api.PathFailuresResponse.failures:type_name -> api.PathFailure
+	18, // 10: api.Response.failedRules:type_name -> api.FailedRule
+	22, // 11: api.Response.usageReports:type_name -> api.SessionReport
+	18, // 12: api.CreatedSession.failedRules:type_name -> api.FailedRule
+	19, // 13: api.CreateSessionResponse.sessions:type_name -> api.CreatedSession
+	18, // 14: api.PFCPCause.failedRule:type_name -> api.FailedRule
+	38, // 15: api.DeleteSessionResponse.failures:type_name -> api.FailedDeletion
+	8,  // 16: api.PFCPSim.Configure:input_type -> api.ConfigureRequest
+	16, // 17: api.PFCPSim.Associate:input_type -> api.EmptyRequest
+	29, // 18: api.PFCPSim.Disassociate:input_type -> api.DisassociateRequest
+	32, // 19: api.PFCPSim.UpdateAssociation:input_type -> api.UpdateAssociationRequest
+	37, // 20: api.PFCPSim.SetRecoveryTimeStamp:input_type -> api.RecoveryTimeStampRequest
+	6,  // 21: api.PFCPSim.CreateSession:input_type -> api.CreateSessionRequest
+	7,  // 22: api.PFCPSim.ModifySession:input_type -> api.ModifySessionRequest
+	9,  // 23: api.PFCPSim.DeleteSession:input_type -> api.DeleteSessionRequest
+	35, // 24: api.PFCPSim.CreateSessionAndDelete:input_type -> api.CreateSessionAndDeleteRequest
+	16, // 25: api.PFCPSim.ClearAllSessions:input_type -> api.EmptyRequest
+	16, // 26: api.PFCPSim.DeleteSessionSet:input_type -> api.EmptyRequest
+	33, // 27: api.PFCPSim.BufferAllSessions:input_type -> api.BufferAllSessionsRequest
+	15, // 28: api.PFCPSim.DumpState:input_type -> api.StateRequest
+	15, // 29: api.PFCPSim.LoadState:input_type -> api.StateRequest
+	11, // 30: api.PFCPSim.SendPFDManagement:input_type -> api.PFDManagementRequest
+	16, // 31: api.PFCPSim.GetPathFailures:input_type -> api.EmptyRequest
+	16, // 32: api.PFCPSim.GetUPFunctionFeatures:input_type -> api.EmptyRequest
+	24, // 33: api.PFCPSim.EchoGTPU:input_type -> api.GTPUEchoRequest
+	16, // 34: api.PFCPSim.Health:input_type -> api.EmptyRequest
+	16, // 35: api.PFCPSim.Info:input_type -> api.EmptyRequest
+	16, // 36: api.PFCPSim.AssociationStatus:input_type -> api.EmptyRequest
+	28, // 37: api.PFCPSim.SetLogging:input_type -> api.LoggingRequest
+	16, // 38: api.PFCPSim.SubscribeReports:input_type -> api.EmptyRequest
+	17, // 39: api.PFCPSim.Configure:output_type -> api.Response
+	17, // 40: api.PFCPSim.Associate:output_type -> api.Response
+	17, // 41: api.PFCPSim.Disassociate:output_type -> api.Response
+	17, // 42: api.PFCPSim.UpdateAssociation:output_type -> api.Response
+	17, // 43: api.PFCPSim.SetRecoveryTimeStamp:output_type -> api.Response
+	20, // 44: api.PFCPSim.CreateSession:output_type -> api.CreateSessionResponse
+	17, // 45: api.PFCPSim.ModifySession:output_type -> api.Response
+	39, // 46: api.PFCPSim.DeleteSession:output_type -> api.DeleteSessionResponse
+	36, // 47: api.PFCPSim.CreateSessionAndDelete:output_type -> api.CreateSessionAndDeleteResponse
+	21, // 48: api.PFCPSim.ClearAllSessions:output_type -> api.ClearAllSessionsResponse
+	21, // 49: api.PFCPSim.DeleteSessionSet:output_type -> api.ClearAllSessionsResponse
+	34, // 50: api.PFCPSim.BufferAllSessions:output_type -> api.BufferAllSessionsResponse
+	17, // 51: api.PFCPSim.DumpState:output_type -> api.Response
+	17, // 52: api.PFCPSim.LoadState:output_type -> api.Response
+	17, // 53: api.PFCPSim.SendPFDManagement:output_type -> api.Response
+	13, // 54: api.PFCPSim.GetPathFailures:output_type -> api.PathFailuresResponse
+	14, // 55: api.PFCPSim.GetUPFunctionFeatures:output_type -> api.UPFunctionFeaturesResponse
+	25, // 56: api.PFCPSim.EchoGTPU:output_type -> api.GTPUEchoResponse
+	26, // 57: api.PFCPSim.Health:output_type -> api.HealthResponse
+	30, // 58: api.PFCPSim.Info:output_type -> api.InfoResponse
+	27, // 59: api.PFCPSim.AssociationStatus:output_type -> api.AssociationStatusResponse
+	17, // 60: api.PFCPSim.SetLogging:output_type -> api.Response
+	22, // 61: api.PFCPSim.SubscribeReports:output_type -> api.SessionReport
+	39, // [39:62] is the sub-list for method output_type
+	16, // [16:39] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_pfcpsim_proto_init() }
+func file_pfcpsim_proto_init() {
+	if File_pfcpsim_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pfcpsim_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModifySessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplicationPFDs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PFDManagementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
 			default:
 				return nil
 			}
 		}
-		file_pfcpsim_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConfigureRequest); i {
+		file_pfcpsim_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathFailure); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -683,8 +4119,8 @@ func file_pfcpsim_proto_init() {
 				return nil
 			}
 		}
-		file_pfcpsim_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteSessionRequest); i {
+		file_pfcpsim_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathFailuresResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -695,7 +4131,31 @@ func file_pfcpsim_proto_init() {
 				return nil
 			}
 		}
-		file_pfcpsim_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_pfcpsim_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UPFunctionFeaturesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EmptyRequest); i {
 			case 0:
 				return &v.state
@@ -707,7 +4167,7 @@ func file_pfcpsim_proto_init() {
 				return nil
 			}
 		}
-		file_pfcpsim_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_pfcpsim_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Response); i {
 			case 0:
 				return &v.state
@@ -719,19 +4179,284 @@ func file_pfcpsim_proto_init() {
 				return nil
 			}
 		}
+		file_pfcpsim_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FailedRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreatedSession); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSessionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearAllSessionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PFCPCause); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GTPUEchoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GTPUEchoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssociationStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoggingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisassociateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PDRUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateAssociationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BufferAllSessionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BufferAllSessionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSessionAndDeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSessionAndDeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecoveryTimeStampRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FailedDeletion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pfcpsim_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSessionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pfcpsim_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      6,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_pfcpsim_proto_goTypes,
 		DependencyIndexes: file_pfcpsim_proto_depIdxs,
+		EnumInfos:         file_pfcpsim_proto_enumTypes,
 		MessageInfos:      file_pfcpsim_proto_msgTypes,
 	}.Build()
 	File_pfcpsim_proto = out.File
@@ -26,10 +26,54 @@ type PFCPSimClient interface {
 	// Associate connects PFCPClient to remote peer and starts an association
 	Associate(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error)
 	// Disassociate perform teardown of association and disconnects from remote peer.
-	Disassociate(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error)
-	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Response, error)
+	Disassociate(ctx context.Context, in *DisassociateRequest, opts ...grpc.CallOption) (*Response, error)
+	// UpdateAssociation sends an Association Update Request to a remote peer, e.g. to renegotiate the CP function features.
+	// It requires an active association with the remote peer.
+	UpdateAssociation(ctx context.Context, in *UpdateAssociationRequest, opts ...grpc.CallOption) (*Response, error)
+	// SetRecoveryTimeStamp changes the Recovery Time Stamp advertised to a remote peer and sends a new
+	// Association Setup Request, so that the peer sees an apparent restart of pfcpsim.
+	// The peer then considers the sessions established so far lost.
+	SetRecoveryTimeStamp(ctx context.Context, in *RecoveryTimeStampRequest, opts ...grpc.CallOption) (*Response, error)
+	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*CreateSessionResponse, error)
 	ModifySession(ctx context.Context, in *ModifySessionRequest, opts ...grpc.CallOption) (*Response, error)
-	DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*Response, error)
+	// DeleteSession deletes the sessions one after the other, carrying on past the ones that can't be deleted.
+	// It fails only if none of the sessions could be deleted
+	DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*DeleteSessionResponse, error)
+	// CreateSessionAndDelete repeatedly establishes a session and deletes it right after, without keeping it,
+	// to measure how fast the remote peer handles the churn of sessions.
+	CreateSessionAndDelete(ctx context.Context, in *CreateSessionAndDeleteRequest, opts ...grpc.CallOption) (*CreateSessionAndDeleteResponse, error)
+	// ClearAllSessions deletes all the active sessions, regardless of their base IDs.
+	ClearAllSessions(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ClearAllSessionsResponse, error)
+	// DeleteSessionSet deletes all the active sessions like ClearAllSessions, but with a single
+	// Session Set Deletion Request per remote peer. The sessions of the peers not supporting it,
+	// and the ones established outside of the configured PDN connection set, are deleted one by one.
+	DeleteSessionSet(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ClearAllSessionsResponse, error)
+	// BufferAllSessions makes the remote peers buffer the downlink traffic of all the active sessions, regardless of their
+	// base IDs, and notify it with Downlink Data Reports, to be observed with SubscribeReports.
+	BufferAllSessions(ctx context.Context, in *BufferAllSessionsRequest, opts ...grpc.CallOption) (*BufferAllSessionsResponse, error)
+	// DumpState writes the active sessions to a file, to be loaded by a later pfcpsim instance.
+	DumpState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*Response, error)
+	// LoadState reads the sessions dumped by DumpState, without establishing them again on the remote peer.
+	LoadState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*Response, error)
+	// SendPFDManagement provisions the PFDs of the given applications on the remote peer.
+	SendPFDManagement(ctx context.Context, in *PFDManagementRequest, opts ...grpc.CallOption) (*Response, error)
+	// GetPathFailures returns the user plane path failures reported by the remote peer through Node Report Requests.
+	GetPathFailures(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*PathFailuresResponse, error)
+	// GetUPFunctionFeatures returns the UP function features advertised by the remote peer.
+	GetUPFunctionFeatures(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*UPFunctionFeaturesResponse, error)
+	// EchoGTPU sends a GTP-U Echo Request to the N3 address of the UPF to verify the data plane path.
+	// It does not require an association with the remote peer.
+	EchoGTPU(ctx context.Context, in *GTPUEchoRequest, opts ...grpc.CallOption) (*GTPUEchoResponse, error)
+	// Health returns the status of pfcpsim, e.g. to wait for it to be associated. It can be called at any time.
+	Health(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// Info returns the version of pfcpsim and the PFCP features it supports, to check its compatibility.
+	Info(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	// AssociationStatus returns the details of the association with the remote peer.
+	AssociationStatus(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*AssociationStatusResponse, error)
+	// SetLogging changes the level and the format of the logs of pfcpsim at runtime.
+	SetLogging(ctx context.Context, in *LoggingRequest, opts ...grpc.CallOption) (*Response, error)
+	// SubscribeReports streams the usage reports and downlink data notifications received from the remote peer.
+	SubscribeReports(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (PFCPSim_SubscribeReportsClient, error)
 }
 
 type pFCPSimClient struct {
@@ -58,7 +102,7 @@ func (c *pFCPSimClient) Associate(ctx context.Context, in *EmptyRequest, opts ..
 	return out, nil
 }
 
-func (c *pFCPSimClient) Disassociate(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error) {
+func (c *pFCPSimClient) Disassociate(ctx context.Context, in *DisassociateRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := c.cc.Invoke(ctx, "/api.PFCPSim/Disassociate", in, out, opts...)
 	if err != nil {
@@ -67,8 +111,26 @@ func (c *pFCPSimClient) Disassociate(ctx context.Context, in *EmptyRequest, opts
 	return out, nil
 }
 
-func (c *pFCPSimClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Response, error) {
+func (c *pFCPSimClient) UpdateAssociation(ctx context.Context, in *UpdateAssociationRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/UpdateAssociation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) SetRecoveryTimeStamp(ctx context.Context, in *RecoveryTimeStampRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/SetRecoveryTimeStamp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*CreateSessionResponse, error) {
+	out := new(CreateSessionResponse)
 	err := c.cc.Invoke(ctx, "/api.PFCPSim/CreateSession", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -85,8 +147,8 @@ func (c *pFCPSimClient) ModifySession(ctx context.Context, in *ModifySessionRequ
 	return out, nil
 }
 
-func (c *pFCPSimClient) DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*Response, error) {
-	out := new(Response)
+func (c *pFCPSimClient) DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*DeleteSessionResponse, error) {
+	out := new(DeleteSessionResponse)
 	err := c.cc.Invoke(ctx, "/api.PFCPSim/DeleteSession", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -94,6 +156,164 @@ func (c *pFCPSimClient) DeleteSession(ctx context.Context, in *DeleteSessionRequ
 	return out, nil
 }
 
+func (c *pFCPSimClient) CreateSessionAndDelete(ctx context.Context, in *CreateSessionAndDeleteRequest, opts ...grpc.CallOption) (*CreateSessionAndDeleteResponse, error) {
+	out := new(CreateSessionAndDeleteResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/CreateSessionAndDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) ClearAllSessions(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ClearAllSessionsResponse, error) {
+	out := new(ClearAllSessionsResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/ClearAllSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) DeleteSessionSet(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ClearAllSessionsResponse, error) {
+	out := new(ClearAllSessionsResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/DeleteSessionSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) BufferAllSessions(ctx context.Context, in *BufferAllSessionsRequest, opts ...grpc.CallOption) (*BufferAllSessionsResponse, error) {
+	out := new(BufferAllSessionsResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/BufferAllSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) DumpState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/DumpState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) LoadState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/LoadState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) SendPFDManagement(ctx context.Context, in *PFDManagementRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/SendPFDManagement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) GetPathFailures(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*PathFailuresResponse, error) {
+	out := new(PathFailuresResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/GetPathFailures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) GetUPFunctionFeatures(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*UPFunctionFeaturesResponse, error) {
+	out := new(UPFunctionFeaturesResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/GetUPFunctionFeatures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) EchoGTPU(ctx context.Context, in *GTPUEchoRequest, opts ...grpc.CallOption) (*GTPUEchoResponse, error) {
+	out := new(GTPUEchoResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/EchoGTPU", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) Health(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) Info(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/Info", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) AssociationStatus(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*AssociationStatusResponse, error) {
+	out := new(AssociationStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/AssociationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) SetLogging(ctx context.Context, in *LoggingRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/SetLogging", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) SubscribeReports(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (PFCPSim_SubscribeReportsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PFCPSim_ServiceDesc.Streams[0], "/api.PFCPSim/SubscribeReports", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pFCPSimSubscribeReportsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PFCPSim_SubscribeReportsClient interface {
+	Recv() (*SessionReport, error)
+	grpc.ClientStream
+}
+
+type pFCPSimSubscribeReportsClient struct {
+	grpc.ClientStream
+}
+
+func (x *pFCPSimSubscribeReportsClient) Recv() (*SessionReport, error) {
+	m := new(SessionReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PFCPSimServer is the server API for PFCPSim service.
 // All implementations must embed UnimplementedPFCPSimServer
 // for forward compatibility
@@ -102,10 +322,54 @@ type PFCPSimServer interface {
 	// Associate connects PFCPClient to remote peer and starts an association
 	Associate(context.Context, *EmptyRequest) (*Response, error)
 	// Disassociate perform teardown of association and disconnects from remote peer.
-	Disassociate(context.Context, *EmptyRequest) (*Response, error)
-	CreateSession(context.Context, *CreateSessionRequest) (*Response, error)
+	Disassociate(context.Context, *DisassociateRequest) (*Response, error)
+	// UpdateAssociation sends an Association Update Request to a remote peer, e.g. to renegotiate the CP function features.
+	// It requires an active association with the remote peer.
+	UpdateAssociation(context.Context, *UpdateAssociationRequest) (*Response, error)
+	// SetRecoveryTimeStamp changes the Recovery Time Stamp advertised to a remote peer and sends a new
+	// Association Setup Request, so that the peer sees an apparent restart of pfcpsim.
+	// The peer then considers the sessions established so far lost.
+	SetRecoveryTimeStamp(context.Context, *RecoveryTimeStampRequest) (*Response, error)
+	CreateSession(context.Context, *CreateSessionRequest) (*CreateSessionResponse, error)
 	ModifySession(context.Context, *ModifySessionRequest) (*Response, error)
-	DeleteSession(context.Context, *DeleteSessionRequest) (*Response, error)
+	// DeleteSession deletes the sessions one after the other, carrying on past the ones that can't be deleted.
+	// It fails only if none of the sessions could be deleted
+	DeleteSession(context.Context, *DeleteSessionRequest) (*DeleteSessionResponse, error)
+	// CreateSessionAndDelete repeatedly establishes a session and deletes it right after, without keeping it,
+	// to measure how fast the remote peer handles the churn of sessions.
+	CreateSessionAndDelete(context.Context, *CreateSessionAndDeleteRequest) (*CreateSessionAndDeleteResponse, error)
+	// ClearAllSessions deletes all the active sessions, regardless of their base IDs.
+	ClearAllSessions(context.Context, *EmptyRequest) (*ClearAllSessionsResponse, error)
+	// DeleteSessionSet deletes all the active sessions like ClearAllSessions, but with a single
+	// Session Set Deletion Request per remote peer. The sessions of the peers not supporting it,
+	// and the ones established outside of the configured PDN connection set, are deleted one by one.
+	DeleteSessionSet(context.Context, *EmptyRequest) (*ClearAllSessionsResponse, error)
+	// BufferAllSessions makes the remote peers buffer the downlink traffic of all the active sessions, regardless of their
+	// base IDs, and notify it with Downlink Data Reports, to be observed with SubscribeReports.
+	BufferAllSessions(context.Context, *BufferAllSessionsRequest) (*BufferAllSessionsResponse, error)
+	// DumpState writes the active sessions to a file, to be loaded by a later pfcpsim instance.
+	DumpState(context.Context, *StateRequest) (*Response, error)
+	// LoadState reads the sessions dumped by DumpState, without establishing them again on the remote peer.
+	LoadState(context.Context, *StateRequest) (*Response, error)
+	// SendPFDManagement provisions the PFDs of the given applications on the remote peer.
+	SendPFDManagement(context.Context, *PFDManagementRequest) (*Response, error)
+	// GetPathFailures returns the user plane path failures reported by the remote peer through Node Report Requests.
+	GetPathFailures(context.Context, *EmptyRequest) (*PathFailuresResponse, error)
+	// GetUPFunctionFeatures returns the UP function features advertised by the remote peer.
+	GetUPFunctionFeatures(context.Context, *EmptyRequest) (*UPFunctionFeaturesResponse, error)
+	// EchoGTPU sends a GTP-U Echo Request to the N3 address of the UPF to verify the data plane path.
+	// It does not require an association with the remote peer.
+	EchoGTPU(context.Context, *GTPUEchoRequest) (*GTPUEchoResponse, error)
+	// Health returns the status of pfcpsim, e.g. to wait for it to be associated. It can be called at any time.
+	Health(context.Context, *EmptyRequest) (*HealthResponse, error)
+	// Info returns the version of pfcpsim and the PFCP features it supports, to check its compatibility.
+	Info(context.Context, *EmptyRequest) (*InfoResponse, error)
+	// AssociationStatus returns the details of the association with the remote peer.
+	AssociationStatus(context.Context, *EmptyRequest) (*AssociationStatusResponse, error)
+	// SetLogging changes the level and the format of the logs of pfcpsim at runtime.
+	SetLogging(context.Context, *LoggingRequest) (*Response, error)
+	// SubscribeReports streams the usage reports and downlink data notifications received from the remote peer.
+	SubscribeReports(*EmptyRequest, PFCPSim_SubscribeReportsServer) error
 	mustEmbedUnimplementedPFCPSimServer()
 }
 
@@ -119,18 +383,69 @@ func (UnimplementedPFCPSimServer) Configure(context.Context, *ConfigureRequest)
 func (UnimplementedPFCPSimServer) Associate(context.Context, *EmptyRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Associate not implemented")
 }
-func (UnimplementedPFCPSimServer) Disassociate(context.Context, *EmptyRequest) (*Response, error) {
+func (UnimplementedPFCPSimServer) Disassociate(context.Context, *DisassociateRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Disassociate not implemented")
 }
-func (UnimplementedPFCPSimServer) CreateSession(context.Context, *CreateSessionRequest) (*Response, error) {
+func (UnimplementedPFCPSimServer) UpdateAssociation(context.Context, *UpdateAssociationRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAssociation not implemented")
+}
+func (UnimplementedPFCPSimServer) SetRecoveryTimeStamp(context.Context, *RecoveryTimeStampRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRecoveryTimeStamp not implemented")
+}
+func (UnimplementedPFCPSimServer) CreateSession(context.Context, *CreateSessionRequest) (*CreateSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSession not implemented")
 }
 func (UnimplementedPFCPSimServer) ModifySession(context.Context, *ModifySessionRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ModifySession not implemented")
 }
-func (UnimplementedPFCPSimServer) DeleteSession(context.Context, *DeleteSessionRequest) (*Response, error) {
+func (UnimplementedPFCPSimServer) DeleteSession(context.Context, *DeleteSessionRequest) (*DeleteSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteSession not implemented")
 }
+func (UnimplementedPFCPSimServer) CreateSessionAndDelete(context.Context, *CreateSessionAndDeleteRequest) (*CreateSessionAndDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSessionAndDelete not implemented")
+}
+func (UnimplementedPFCPSimServer) ClearAllSessions(context.Context, *EmptyRequest) (*ClearAllSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearAllSessions not implemented")
+}
+func (UnimplementedPFCPSimServer) DeleteSessionSet(context.Context, *EmptyRequest) (*ClearAllSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSessionSet not implemented")
+}
+func (UnimplementedPFCPSimServer) BufferAllSessions(context.Context, *BufferAllSessionsRequest) (*BufferAllSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BufferAllSessions not implemented")
+}
+func (UnimplementedPFCPSimServer) DumpState(context.Context, *StateRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpState not implemented")
+}
+func (UnimplementedPFCPSimServer) LoadState(context.Context, *StateRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadState not implemented")
+}
+func (UnimplementedPFCPSimServer) SendPFDManagement(context.Context, *PFDManagementRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendPFDManagement not implemented")
+}
+func (UnimplementedPFCPSimServer) GetPathFailures(context.Context, *EmptyRequest) (*PathFailuresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPathFailures not implemented")
+}
+func (UnimplementedPFCPSimServer) GetUPFunctionFeatures(context.Context, *EmptyRequest) (*UPFunctionFeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUPFunctionFeatures not implemented")
+}
+func (UnimplementedPFCPSimServer) EchoGTPU(context.Context, *GTPUEchoRequest) (*GTPUEchoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EchoGTPU not implemented")
+}
+func (UnimplementedPFCPSimServer) Health(context.Context, *EmptyRequest) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (UnimplementedPFCPSimServer) Info(context.Context, *EmptyRequest) (*InfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (UnimplementedPFCPSimServer) AssociationStatus(context.Context, *EmptyRequest) (*AssociationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssociationStatus not implemented")
+}
+func (UnimplementedPFCPSimServer) SetLogging(context.Context, *LoggingRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogging not implemented")
+}
+func (UnimplementedPFCPSimServer) SubscribeReports(*EmptyRequest, PFCPSim_SubscribeReportsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeReports not implemented")
+}
 func (UnimplementedPFCPSimServer) mustEmbedUnimplementedPFCPSimServer() {}
 
 // UnsafePFCPSimServer may be embedded to opt out of forward compatibility for this service.
@@ -181,7 +496,7 @@ func _PFCPSim_Associate_Handler(srv interface{}, ctx context.Context, dec func(i
 }
 
 func _PFCPSim_Disassociate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+	in := new(DisassociateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -193,7 +508,43 @@ func _PFCPSim_Disassociate_Handler(srv interface{}, ctx context.Context, dec fun
 		FullMethod: "/api.PFCPSim/Disassociate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PFCPSimServer).Disassociate(ctx, req.(*EmptyRequest))
+		return srv.(PFCPSimServer).Disassociate(ctx, req.(*DisassociateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_UpdateAssociation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAssociationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).UpdateAssociation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/UpdateAssociation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).UpdateAssociation(ctx, req.(*UpdateAssociationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_SetRecoveryTimeStamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoveryTimeStampRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).SetRecoveryTimeStamp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/SetRecoveryTimeStamp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).SetRecoveryTimeStamp(ctx, req.(*RecoveryTimeStampRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -252,6 +603,279 @@ func _PFCPSim_DeleteSession_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PFCPSim_CreateSessionAndDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSessionAndDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).CreateSessionAndDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/CreateSessionAndDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).CreateSessionAndDelete(ctx, req.(*CreateSessionAndDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_ClearAllSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).ClearAllSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/ClearAllSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).ClearAllSessions(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_DeleteSessionSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).DeleteSessionSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/DeleteSessionSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).DeleteSessionSet(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_BufferAllSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BufferAllSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).BufferAllSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/BufferAllSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).BufferAllSessions(ctx, req.(*BufferAllSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_DumpState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).DumpState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/DumpState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).DumpState(ctx, req.(*StateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_LoadState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).LoadState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/LoadState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).LoadState(ctx, req.(*StateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_SendPFDManagement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PFDManagementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).SendPFDManagement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/SendPFDManagement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).SendPFDManagement(ctx, req.(*PFDManagementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_GetPathFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).GetPathFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/GetPathFailures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).GetPathFailures(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_GetUPFunctionFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).GetUPFunctionFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/GetUPFunctionFeatures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).GetUPFunctionFeatures(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_EchoGTPU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GTPUEchoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).EchoGTPU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/EchoGTPU",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).EchoGTPU(ctx, req.(*GTPUEchoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).Health(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).Info(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_AssociationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).AssociationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/AssociationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).AssociationStatus(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_SetLogging_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoggingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).SetLogging(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/SetLogging",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).SetLogging(ctx, req.(*LoggingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_SubscribeReports_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmptyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PFCPSimServer).SubscribeReports(m, &pFCPSimSubscribeReportsServer{stream})
+}
+
+type PFCPSim_SubscribeReportsServer interface {
+	Send(*SessionReport) error
+	grpc.ServerStream
+}
+
+type pFCPSimSubscribeReportsServer struct {
+	grpc.ServerStream
+}
+
+func (x *pFCPSimSubscribeReportsServer) Send(m *SessionReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // PFCPSim_ServiceDesc is the grpc.ServiceDesc for PFCPSim service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -271,6 +895,14 @@ var PFCPSim_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Disassociate",
 			Handler:    _PFCPSim_Disassociate_Handler,
 		},
+		{
+			MethodName: "UpdateAssociation",
+			Handler:    _PFCPSim_UpdateAssociation_Handler,
+		},
+		{
+			MethodName: "SetRecoveryTimeStamp",
+			Handler:    _PFCPSim_SetRecoveryTimeStamp_Handler,
+		},
 		{
 			MethodName: "CreateSession",
 			Handler:    _PFCPSim_CreateSession_Handler,
@@ -283,7 +915,69 @@ var PFCPSim_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSession",
 			Handler:    _PFCPSim_DeleteSession_Handler,
 		},
+		{
+			MethodName: "CreateSessionAndDelete",
+			Handler:    _PFCPSim_CreateSessionAndDelete_Handler,
+		},
+		{
+			MethodName: "ClearAllSessions",
+			Handler:    _PFCPSim_ClearAllSessions_Handler,
+		},
+		{
+			MethodName: "DeleteSessionSet",
+			Handler:    _PFCPSim_DeleteSessionSet_Handler,
+		},
+		{
+			MethodName: "BufferAllSessions",
+			Handler:    _PFCPSim_BufferAllSessions_Handler,
+		},
+		{
+			MethodName: "DumpState",
+			Handler:    _PFCPSim_DumpState_Handler,
+		},
+		{
+			MethodName: "LoadState",
+			Handler:    _PFCPSim_LoadState_Handler,
+		},
+		{
+			MethodName: "SendPFDManagement",
+			Handler:    _PFCPSim_SendPFDManagement_Handler,
+		},
+		{
+			MethodName: "GetPathFailures",
+			Handler:    _PFCPSim_GetPathFailures_Handler,
+		},
+		{
+			MethodName: "GetUPFunctionFeatures",
+			Handler:    _PFCPSim_GetUPFunctionFeatures_Handler,
+		},
+		{
+			MethodName: "EchoGTPU",
+			Handler:    _PFCPSim_EchoGTPU_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _PFCPSim_Health_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _PFCPSim_Info_Handler,
+		},
+		{
+			MethodName: "AssociationStatus",
+			Handler:    _PFCPSim_AssociationStatus_Handler,
+		},
+		{
+			MethodName: "SetLogging",
+			Handler:    _PFCPSim_SetLogging_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeReports",
+			Handler:       _PFCPSim_SubscribeReports_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/pfcpsim.proto",
 }
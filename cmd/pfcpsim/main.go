@@ -6,23 +6,60 @@ package main
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
-	pb "github.com/ardzoht/pfcpsim/api"
 	"github.com/ardzoht/pfcpsim/internal/pfcpsim"
+	pfcpsimlib "github.com/ardzoht/pfcpsim/pkg/pfcpsim"
 	"github.com/pborman/getopt/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
 
 const (
-	defaultgRPCServerPort = "54321"
+	defaultgRPCServerPort    = "54321"
+	defaultMetricsServerPort = "9091"
+	defaultShutdownTimeout   = 10 * time.Second
 )
 
-func startServer(apiDoneChannel chan bool, iFace string, port string, group *sync.WaitGroup) {
+// startMetricsServer serves the Prometheus metrics over HTTP on the given port, under /metrics.
+func startMetricsServer(port string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	log.Infof("Metrics server listening on port %v", port)
+
+	if err := http.ListenAndServe(fmt.Sprintf("0.0.0.0:%v", port), mux); err != nil {
+		log.Fatalf("Metrics HTTP server failed to listen: %v", err)
+	}
+}
+
+// stopServer stops accepting new gRPC calls and waits for the pending ones to complete.
+// Pending calls are canceled if they are not complete within timeout.
+func stopServer(grpcServer *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		log.Warnf("Pending gRPC calls not complete within %v, canceling them", timeout)
+		grpcServer.Stop()
+	}
+}
+
+func startServer(apiDoneChannel chan bool, iFace string, port string, idleTimeout time.Duration,
+	shutdownTimeout time.Duration, releaseAssociation bool, group *sync.WaitGroup) {
 	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%v", port))
 	if err != nil {
 		log.Fatalf("API gRPC Server failed to listen: %v", err)
@@ -30,7 +67,7 @@ func startServer(apiDoneChannel chan bool, iFace string, port string, group *syn
 
 	grpcServer := grpc.NewServer()
 
-	pb.RegisterPFCPSimServer(grpcServer, pfcpsim.NewPFCPSimService(iFace))
+	pfcpsim.RegisterServices(grpcServer, iFace, idleTimeout)
 
 	go func() {
 		if err := grpcServer.Serve(lis); err != nil {
@@ -38,14 +75,14 @@ func startServer(apiDoneChannel chan bool, iFace string, port string, group *syn
 		}
 	}()
 
-	log.Infof("Server listening on port %v", port)
+	log.Infof("Server %v (%v) listening on port %v", pfcpsim.Version, pfcpsim.BuildCommit, port)
 
-	x := <-apiDoneChannel
-	if x {
-		// if the API channel is closed, stop the gRPC pfcpsim
-		grpcServer.Stop()
-		log.Warnf("Stopping API gRPC pfcpsim")
-	}
+	// if the API channel is closed, stop the gRPC pfcpsim
+	<-apiDoneChannel
+	log.Warnf("Stopping API gRPC pfcpsim")
+
+	stopServer(grpcServer, shutdownTimeout)
+	pfcpsim.Shutdown(releaseAssociation)
 
 	group.Done()
 }
@@ -54,6 +91,19 @@ func main() {
 	port := getopt.StringLong("port", 'p', defaultgRPCServerPort, "the gRPC Server port to listen")
 	iFaceName := getopt.StringLong("interface", 'i', "", "Defines the local address. If left blank,"+
 		" the IP will be taken from the first non-loopback interface")
+	idleTimeout := getopt.DurationLong("idle-timeout", 0, 0, "Delete sessions that did not receive any usage report"+
+		" within the given time (e.g. 5m). Disabled if 0")
+
+	metricsPort := getopt.StringLong("metrics-port", 'm', defaultMetricsServerPort, "the HTTP port to serve"+
+		" Prometheus metrics on. Disabled if empty")
+
+	shutdownTimeout := getopt.DurationLong("shutdown-timeout", 0, defaultShutdownTimeout, "On shutdown, the maximum time"+
+		" to wait for pending gRPC calls to complete")
+	keepAssociation := getopt.BoolLong("keep-association", 0, "Do not release the association with the remote peer"+
+		" on shutdown")
+
+	configPath := getopt.StringLong("config", 'c', "", "A YAML or JSON file to configure the server with at startup,"+
+		" as the Configure RPC does")
 
 	optHelp := getopt.BoolLong("help", 0, "Help")
 
@@ -63,22 +113,36 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *configPath != "" {
+		if err := pfcpsim.LoadConfig(*configPath); err != nil {
+			log.Fatalf("Failed to load the configuration: %v", err)
+		}
+	}
+
 	// control channels, they are only closed when the goroutine needs to be terminated
 	doneChannel := make(chan bool)
 
 	sigs := make(chan os.Signal, 1)
-	// stop API servers on SIGTERM
-	signal.Notify(sigs, syscall.SIGTERM)
+	// stop API servers on SIGINT and SIGTERM
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 
 	go func() {
 		<-sigs
 		close(doneChannel)
 	}()
 
+	if *metricsPort != "" {
+		if err := pfcpsimlib.RegisterMetrics(prometheus.DefaultRegisterer); err != nil {
+			log.Fatalf("Failed to register the metrics: %v", err)
+		}
+
+		go startMetricsServer(*metricsPort)
+	}
+
 	wg := sync.WaitGroup{}
 	wg.Add(1)
 
-	go startServer(doneChannel, *iFaceName, *port, &wg)
+	go startServer(doneChannel, *iFaceName, *port, *idleTimeout, *shutdownTimeout, !*keepAssociation, &wg)
 	log.Debugf("Started API gRPC Service")
 
 	wg.Wait()
@@ -5,12 +5,15 @@ go 1.16
 require (
 	github.com/c-robinson/iplib v1.0.3
 	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/google/gopacket v1.1.19
 	github.com/jessevdk/go-flags v1.5.0
 	github.com/omec-project/pfcpsim v0.0.0-20220328122841-64474e93876e
 	github.com/pborman/getopt/v2 v2.1.0
+	github.com/prometheus/client_golang v1.12.2
 	github.com/sirupsen/logrus v1.9.0
 	github.com/stretchr/testify v1.8.0
 	github.com/wmnsk/go-pfcp v0.0.15
 	google.golang.org/grpc v1.48.0
 	google.golang.org/protobuf v1.28.1
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+// Package fakeupf provides a minimal PFCP peer to be used in tests.
+// By default, it accepts every request it receives and answers with a well-formed response.
+// Custom behaviours can be installed per message type using HandleFunc.
+package fakeupf
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// Handler returns the response to be sent back for the given request.
+// Returning nil drops the request without answering.
+type Handler func(req message.Message) message.Message
+
+type FakeUPF struct {
+	conn *net.UDPConn
+
+	lock       sync.Mutex
+	handlers   map[uint8]Handler
+	received   []message.Message
+	peerAddr   *net.UDPAddr
+	lastSEID   uint64
+	lastTEID   uint32
+	recoveryTS time.Time
+}
+
+// New starts a FakeUPF listening on a random port of the loopback interface.
+func New() (*FakeUPF, error) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		return nil, err
+	}
+
+	u := &FakeUPF{
+		conn:       conn,
+		handlers:   make(map[uint8]Handler),
+		recoveryTS: time.Now(),
+	}
+
+	go u.serve()
+
+	return u, nil
+}
+
+// Addr returns the address the FakeUPF is listening on, in the host:port form.
+func (u *FakeUPF) Addr() string {
+	return u.conn.LocalAddr().String()
+}
+
+func (u *FakeUPF) Close() {
+	u.conn.Close()
+}
+
+// HandleFunc overrides the default behaviour for the given request message type.
+func (u *FakeUPF) HandleFunc(msgType uint8, h Handler) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.handlers[msgType] = h
+}
+
+// Received returns the messages of the given type received so far.
+func (u *FakeUPF) Received(msgType uint8) []message.Message {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	var msgs []message.Message
+
+	for _, msg := range u.received {
+		if msg.MessageType() == msgType {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return msgs
+}
+
+// PeerAddr returns the address of the last peer seen, in the host:port form.
+// An empty string is returned if no message was received yet.
+func (u *FakeUPF) PeerAddr() string {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if u.peerAddr == nil {
+		return ""
+	}
+
+	return u.peerAddr.String()
+}
+
+// Send sends an unsolicited message (e.g. a Session Report Request) to the last peer seen.
+func (u *FakeUPF) Send(msg message.Message) error {
+	u.lock.Lock()
+	peer := u.peerAddr
+	u.lock.Unlock()
+
+	if peer == nil {
+		return net.ErrClosed
+	}
+
+	b := make([]byte, msg.MarshalLen())
+	if err := msg.MarshalTo(b); err != nil {
+		return err
+	}
+
+	_, err := u.conn.WriteToUDP(b, peer)
+
+	return err
+}
+
+func (u *FakeUPF) serve() {
+	buf := make([]byte, 1500)
+
+	for {
+		n, addr, err := u.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		// Parsed IEs reference the underlying buffer, hence it can't be reused for the next read.
+		req, err := message.Parse(append([]byte(nil), buf[:n]...))
+		if err != nil {
+			continue
+		}
+
+		u.lock.Lock()
+		u.received = append(u.received, req)
+		u.peerAddr = addr
+		handler, ok := u.handlers[req.MessageType()]
+		u.lock.Unlock()
+
+		if !ok {
+			handler = u.defaultResponse
+		}
+
+		resp := handler(req)
+		if resp == nil {
+			continue
+		}
+
+		b := make([]byte, resp.MarshalLen())
+		if err := resp.MarshalTo(b); err != nil {
+			continue
+		}
+
+		_, _ = u.conn.WriteToUDP(b, addr)
+	}
+}
+
+// allocateFTEIDs returns a Created PDR IE for each PDR asking to allocate its F-TEID, with the CH flag.
+func (u *FakeUPF) allocateFTEIDs(pdrs []*ie.IE) []*ie.IE {
+	var created []*ie.IE
+
+	for _, pdr := range pdrs {
+		fteid := pdiFTEID(pdr)
+		if fteid == nil || !fteid.HasCh() {
+			continue
+		}
+
+		id, err := pdr.PDRID()
+		if err != nil {
+			continue
+		}
+
+		var allocated *ie.IE
+		if fteid.HasIPv6() {
+			allocated = ie.NewFTEID(0x02, u.nextTEID(), nil, net.IPv6loopback, 0)
+		} else {
+			allocated = ie.NewFTEID(0x01, u.nextTEID(), net.IPv4(127, 0, 0, 1), nil, 0)
+		}
+
+		created = append(created, ie.NewCreatedPDR(ie.NewPDRID(id), allocated))
+	}
+
+	return created
+}
+
+// pdiFTEID returns the F-TEID of the PDI of pdr, or nil if it has none.
+func pdiFTEID(pdr *ie.IE) *ie.FTEIDFields {
+	pdi, err := pdr.PDI()
+	if err != nil {
+		return nil
+	}
+
+	for _, child := range pdi {
+		if child.Type == ie.FTEID {
+			if fteid, err := child.FTEID(); err == nil {
+				return fteid
+			}
+		}
+	}
+
+	return nil
+}
+
+func (u *FakeUPF) nextTEID() uint32 {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.lastTEID++
+
+	return u.lastTEID
+}
+
+func (u *FakeUPF) nextSEID() uint64 {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.lastSEID++
+
+	return u.lastSEID
+}
+
+// SetRecoveryTimeStamp changes the Recovery Time Stamp advertised by the FakeUPF.
+// Setting a later value simulates a restart of the UPF.
+func (u *FakeUPF) SetRecoveryTimeStamp(ts time.Time) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.recoveryTS = ts
+}
+
+// RecoveryTimeStamp returns the Recovery Time Stamp advertised by the FakeUPF.
+func (u *FakeUPF) RecoveryTimeStamp() time.Time {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	return u.recoveryTS
+}
+
+// NodeID returns the Node ID IE used by the FakeUPF in its responses.
+func (u *FakeUPF) NodeID() *ie.IE {
+	return ie.NewNodeID("127.0.0.1", "", "")
+}
+
+func (u *FakeUPF) defaultResponse(req message.Message) message.Message {
+	accepted := ie.NewCause(ie.CauseRequestAccepted)
+
+	switch req := req.(type) {
+	case *message.HeartbeatRequest:
+		return message.NewHeartbeatResponse(req.Sequence(), ie.NewRecoveryTimeStamp(u.RecoveryTimeStamp()))
+	case *message.AssociationSetupRequest:
+		return message.NewAssociationSetupResponse(req.Sequence(),
+			u.NodeID(),
+			accepted,
+			ie.NewRecoveryTimeStamp(u.RecoveryTimeStamp()),
+		)
+	case *message.AssociationReleaseRequest:
+		return message.NewAssociationReleaseResponse(req.Sequence(), u.NodeID(), accepted)
+	case *message.AssociationUpdateRequest:
+		return message.NewAssociationUpdateResponse(req.Sequence(), u.NodeID(), accepted)
+	case *message.PFDManagementRequest:
+		return message.NewPFDManagementResponse(req.Sequence(), accepted, nil)
+	case *message.SessionEstablishmentRequest:
+		var cpSEID uint64
+
+		if req.CPFSEID != nil {
+			if fseid, err := req.CPFSEID.FSEID(); err == nil {
+				cpSEID = fseid.SEID
+			}
+		}
+
+		resp := message.NewSessionEstablishmentResponse(0, 0, cpSEID, req.Sequence(), 0,
+			u.NodeID(),
+			accepted,
+			ie.NewFSEID(u.nextSEID(), net.IPv4(127, 0, 0, 1), nil),
+		)
+		resp.CreatedPDR = u.allocateFTEIDs(req.CreatePDR)
+
+		return resp
+	case *message.SessionModificationRequest:
+		return message.NewSessionModificationResponse(0, 0, 0, req.Sequence(), 0, accepted)
+	case *message.SessionDeletionRequest:
+		return message.NewSessionDeletionResponse(0, 0, 0, req.Sequence(), 0, accepted)
+	case *message.SessionSetDeletionRequest:
+		return message.NewSessionSetDeletionResponse(req.Sequence(), u.NodeID(), accepted, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package fakeupf
+
+import (
+	"net"
+	"sync/atomic"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	gtpuEchoRequest  = 1
+	gtpuEchoResponse = 2
+
+	// recoveryIEType is the type of the Recovery IE carried by Echo Responses
+	recoveryIEType = 14
+)
+
+// GTPUEchoResponder is a minimal GTP-U peer answering Echo Requests, to be used in tests.
+type GTPUEchoResponder struct {
+	conn *net.UDPConn
+
+	received int32
+	silent   int32
+}
+
+// NewGTPUEchoResponder starts a GTPUEchoResponder listening on a random port of the loopback interface.
+func NewGTPUEchoResponder() (*GTPUEchoResponder, error) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		return nil, err
+	}
+
+	r := &GTPUEchoResponder{conn: conn}
+
+	go r.serve()
+
+	return r, nil
+}
+
+// Addr returns the address the GTPUEchoResponder is listening on, in the host:port form.
+func (r *GTPUEchoResponder) Addr() string {
+	return r.conn.LocalAddr().String()
+}
+
+// Port returns the port the GTPUEchoResponder is listening on.
+func (r *GTPUEchoResponder) Port() int {
+	return r.conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func (r *GTPUEchoResponder) Close() {
+	r.conn.Close()
+}
+
+// SetSilent makes the GTPUEchoResponder stop answering, if silent is set, or answer again.
+func (r *GTPUEchoResponder) SetSilent(silent bool) {
+	var v int32
+	if silent {
+		v = 1
+	}
+
+	atomic.StoreInt32(&r.silent, v)
+}
+
+// Received returns the number of Echo Requests received so far.
+func (r *GTPUEchoResponder) Received() int {
+	return int(atomic.LoadInt32(&r.received))
+}
+
+func (r *GTPUEchoResponder) serve() {
+	buf := make([]byte, 1500)
+
+	for {
+		n, peer, err := r.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		var req layers.GTPv1U
+		if err := req.DecodeFromBytes(buf[:n], gopacket.NilDecodeFeedback); err != nil || req.MessageType != gtpuEchoRequest {
+			continue
+		}
+
+		atomic.AddInt32(&r.received, 1)
+
+		if atomic.LoadInt32(&r.silent) == 1 {
+			continue
+		}
+
+		resp := gopacket.NewSerializeBuffer()
+
+		err = (&layers.GTPv1U{
+			Version:            1,
+			SequenceNumberFlag: true,
+			MessageType:        gtpuEchoResponse,
+			// the optional header fields and the Recovery IE
+			MessageLength:  6,
+			SequenceNumber: req.SequenceNumber,
+		}).SerializeTo(resp, gopacket.SerializeOptions{})
+		if err != nil {
+			continue
+		}
+
+		// the Recovery IE follows the optional header fields, which SerializeTo appends
+		recovery, err := resp.AppendBytes(2)
+		if err != nil {
+			continue
+		}
+
+		recovery[0], recovery[1] = recoveryIEType, 0
+
+		r.conn.WriteToUDP(resp.Bytes(), peer)
+	}
+}
@@ -5,6 +5,8 @@ package commands
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	pb "github.com/ardzoht/pfcpsim/api"
 	"github.com/jessevdk/go-flags"
@@ -12,16 +14,86 @@ import (
 )
 
 type associate struct{}
-type disassociate struct{}
+type disassociate struct {
+	KeepSessions bool `long:"keep-sessions" description:"If set, the active sessions are kept to be managed again once associated anew"`
+}
+
+type updateAssociation struct {
+	CPFeatures            uint8         `long:"cp-features" default:"0" description:"The 5th octet of the CP Function Features advertised to the remote peer (e.g. 1 for LOAD)"`
+	GracefulReleasePeriod time.Duration `long:"graceful-release-period" default:"0s" description:"The Graceful Release Period sent to the remote peer (e.g. 30s). Not sent if 0"`
+	Peer                  string        `long:"peer" description:"The address of the remote peer to update the association with, among the configured ones. Default is the remote peer"`
+}
+
+type recoveryTimeStamp struct {
+	Timestamp string        `short:"t" long:"timestamp" default:"" description:"The new Recovery Time Stamp in RFC 3339 format (e.g. 2022-06-01T12:00:00Z)"`
+	Advance   time.Duration `long:"advance" default:"0s" description:"Advance the current Recovery Time Stamp by this duration instead, truncated to the second (e.g. 1m). If neither is set, the current time is used"`
+	Peer      string        `long:"peer" description:"The address of the remote peer to re-associate with, among the configured ones. Default is the remote peer"`
+}
+
 type configureRemoteAddresses struct {
-	RemotePeerAddress  string `short:"r" long:"remote-peer-addr" default:"" description:"The remote PFCP agent address."`
-	N3InterfaceAddress string `short:"n" long:"n3-addr" default:"" description:"The IPv4 address of the UPF's N3 interface"`
+	RemotePeerAddress  string   `short:"r" long:"remote-peer-addr" default:"" description:"The remote PFCP agent address."`
+	N3InterfaceAddress string   `short:"n" long:"n3-addr" default:"" description:"The IPv4 address of the UPF's N3 interface"`
+	AssociationRetries int32    `long:"association-retries" default:"0" description:"The number of times a failed association setup is retried, with exponential backoff"`
+	LocalN4Address     string   `long:"local-n4-addr" default:"" description:"The source address of PFCP messages. Default is the address of the pfcpsim interface"`
+	CapturePath        string   `long:"capture" default:"" description:"The pcap file where PFCP messages are written. Capture is disabled if not set"`
+	PFCPPort           int32    `long:"pfcp-port" default:"0" description:"The PFCP port of the remote peer, if not specified in the remote peer address. Default is 8805"`
+	AdditionalPeers    []string `long:"additional-peer-addr" description:"A remote PFCP agent address to associate with besides the remote peer. Repeat it to add several peers"`
+	MaxMissedHBs       int32    `long:"max-missed-heartbeats" default:"1" description:"The number of consecutive unanswered heartbeats after which the N4 path is considered failed"`
+	ReestablishOnEI    bool     `long:"reestablish-on-error-indication" description:"If set, the sessions reported with an Error Indication Report are established again with the same rules"`
+	CPFeatures         uint8    `long:"cp-features" default:"0" description:"The 5th octet of the CP Function Features advertised during association setup (e.g. 1 for LOAD). Not advertised if 0"`
+	CSID               uint16   `long:"csid" default:"0" description:"The PDN connection set of the sessions, advertised with the FQ-CSID IE to enable 'session clear --set'. Disabled if 0"`
+	NodeIDType         string   `long:"node-id-type" choice:"ipv4" choice:"ipv6" choice:"fqdn" description:"The type of the Node ID advertised to the PFCP servers. Inferred from --node-id if not set"`
+	NodeID             string   `long:"node-id" default:"" description:"The Node ID advertised to the PFCP servers. Default is the local N4 address"`
+	AssociationTimeout int32    `long:"association-timeout" default:"0" description:"The time to wait for the Association Setup and Release Responses, in milliseconds. Default is the response timeout"`
+	EstablishTimeout   int32    `long:"establishment-timeout" default:"0" description:"The time to wait for the Session Establishment Responses, in milliseconds. Default is the response timeout"`
+	ModifyTimeout      int32    `long:"modification-timeout" default:"0" description:"The time to wait for the Session Modification Responses, in milliseconds. Default is the response timeout"`
+	DeleteTimeout      int32    `long:"deletion-timeout" default:"0" description:"The time to wait for the Session Deletion Responses, in milliseconds. Default is the response timeout"`
+	HeartbeatTimeout   int32    `long:"heartbeat-timeout" default:"0" description:"The time to wait for the Heartbeat Responses, in milliseconds. Default is the response timeout"`
+	SEIDAllocation     string   `long:"seid-allocation" default:"sequential" choice:"sequential" choice:"random" choice:"base-id" description:"How the local SEIDs of the sessions are allocated: increasing from 1, random or equal to the base ID of each session"`
+	ReassocBackoff     int32    `long:"reassociation-backoff" default:"0" description:"The time to wait before setting up the association again once the N4 path fails, in milliseconds, doubling after every failed attempt. Disabled if 0"`
+	MessagePriority    int32    `long:"message-priority" default:"-1" description:"The priority, from 0 (the highest) to 15, set in the header of the Session Establishment, Modification and Deletion Requests. Disabled if negative"`
+}
+
+type pfdManagement struct {
+	ApplicationID    string   `short:"a" long:"app-id" required:"true" description:"The Application ID the PFDs belong to"`
+	FlowDescriptions []string `short:"f" long:"flow-description" description:"A PFD flow description. e.g. 'permit out ip from 10.0.0.1 to assigned'"`
+	URLs             []string `short:"u" long:"url" description:"A PFD URL"`
+	DomainNames      []string `short:"d" long:"domain-name" description:"A PFD domain name"`
+}
+
+type pathFailures struct{}
+
+type upFeatures struct{}
+
+type health struct{}
+
+type info struct{}
+
+type associationStatus struct{}
+
+type gtpuEcho struct {
+	Timeout int32 `short:"t" long:"timeout" default:"1000" description:"The time to wait for the GTP-U Echo Response, in milliseconds"`
+}
+
+type logging struct {
+	Level  string `short:"l" long:"level" choice:"panic" choice:"fatal" choice:"error" choice:"warning" choice:"info" choice:"debug" choice:"trace" description:"The log level of pfcpsim. Unchanged if not set"`
+	Format string `short:"f" long:"format" choice:"text" choice:"json" description:"The format of the logs of pfcpsim. Unchanged if not set"`
 }
 
 type serviceOptions struct {
 	Associate    associate                `command:"associate"`
 	Disassociate disassociate             `command:"disassociate"`
+	Update       updateAssociation        `command:"update-association"`
+	Recovery     recoveryTimeStamp        `command:"recovery-timestamp"`
 	Configure    configureRemoteAddresses `command:"configure"`
+	PFD          pfdManagement            `command:"pfd"`
+	PathFailures pathFailures             `command:"path-failures"`
+	UPFeatures   upFeatures               `command:"up-features"`
+	Health       health                   `command:"health"`
+	Info         info                     `command:"info"`
+	Association  associationStatus        `command:"association"`
+	GTPUEcho     gtpuEcho                 `command:"gtpu-echo"`
+	Logging      logging                  `command:"logging"`
 }
 
 func RegisterServiceCommands(parser *flags.Parser) {
@@ -33,8 +105,28 @@ func (c *configureRemoteAddresses) Execute(args []string) error {
 	defer disconnect()
 
 	res, err := client.Configure(context.Background(), &pb.ConfigureRequest{
-		UpfN3Address:      c.N3InterfaceAddress,
-		RemotePeerAddress: c.RemotePeerAddress,
+		UpfN3Address:                 c.N3InterfaceAddress,
+		RemotePeerAddress:            c.RemotePeerAddress,
+		AssociationRetries:           c.AssociationRetries,
+		LocalN4Address:               c.LocalN4Address,
+		PfcpPort:                     c.PFCPPort,
+		CapturePath:                  c.CapturePath,
+		CpFunctionFeatures:           uint32(c.CPFeatures),
+		AdditionalPeerAddresses:      c.AdditionalPeers,
+		MaxMissedHeartbeats:          c.MaxMissedHBs,
+		ReestablishOnErrorIndication: c.ReestablishOnEI,
+		Csid:                         uint32(c.CSID),
+		NodeIDType:                   c.NodeIDType,
+		NodeID:                       c.NodeID,
+		AssociationTimeout:           c.AssociationTimeout,
+		EstablishmentTimeout:         c.EstablishTimeout,
+		ModificationTimeout:          c.ModifyTimeout,
+		DeletionTimeout:              c.DeleteTimeout,
+		HeartbeatTimeout:             c.HeartbeatTimeout,
+		SeidAllocation:               pb.SeidAllocation(pb.SeidAllocation_value["SEID_"+strings.ReplaceAll(strings.ToUpper(c.SEIDAllocation), "-", "_")]),
+		ReassociationBackoffMs:       c.ReassocBackoff,
+		EnableMessagePriority:        c.MessagePriority >= 0,
+		MessagePriority:              uint32(c.MessagePriority),
 	})
 
 	if err != nil {
@@ -64,7 +156,9 @@ func (c *disassociate) Execute(args []string) error {
 	client := connect()
 	defer disconnect()
 
-	res, err := client.Disassociate(context.Background(), &pb.EmptyRequest{})
+	res, err := client.Disassociate(context.Background(), &pb.DisassociateRequest{
+		KeepSessions: c.KeepSessions,
+	})
 	if err != nil {
 		log.Fatalf("Error while disassociating: %v", err)
 	}
@@ -73,3 +167,184 @@ func (c *disassociate) Execute(args []string) error {
 
 	return nil
 }
+
+func (c *updateAssociation) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.UpdateAssociation(context.Background(), &pb.UpdateAssociationRequest{
+		CpFunctionFeatures:      uint32(c.CPFeatures),
+		GracefulReleasePeriodMs: int32(c.GracefulReleasePeriod.Milliseconds()),
+		Peer:                    c.Peer,
+	})
+	if err != nil {
+		log.Fatalf("Error while updating association: %v", err)
+	}
+
+	log.Infof(res.Message)
+
+	return nil
+}
+
+func (c *recoveryTimeStamp) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.SetRecoveryTimeStamp(context.Background(), &pb.RecoveryTimeStampRequest{
+		Timestamp:      c.Timestamp,
+		AdvanceSeconds: int32(c.Advance.Seconds()),
+		Peer:           c.Peer,
+	})
+	if err != nil {
+		log.Fatalf("Error while setting Recovery Time Stamp: %v", err)
+	}
+
+	log.Infof(res.Message)
+
+	return nil
+}
+
+func (c *pfdManagement) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.SendPFDManagement(context.Background(), &pb.PFDManagementRequest{
+		Applications: []*pb.ApplicationPFDs{
+			{
+				ApplicationID:    c.ApplicationID,
+				FlowDescriptions: c.FlowDescriptions,
+				Urls:             c.URLs,
+				DomainNames:      c.DomainNames,
+			},
+		},
+	})
+	if err != nil {
+		log.Fatalf("Error while sending PFD management: %v", err)
+	}
+
+	log.Infof(res.Message)
+
+	return nil
+}
+
+func (c *pathFailures) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.GetPathFailures(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		log.Fatalf("Error while retrieving path failures: %v", err)
+	}
+
+	if len(res.Failures) == 0 {
+		log.Info("No user plane path failures reported")
+	}
+
+	for _, failure := range res.Failures {
+		log.Infof("%v: user plane path failure reported by %v towards %v", failure.ReportedAt, failure.NodeID, failure.RemotePeers)
+	}
+
+	return nil
+}
+
+func (c *upFeatures) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.GetUPFunctionFeatures(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		log.Fatalf("Error while retrieving UP function features: %v", err)
+	}
+
+	if len(res.Features) == 0 {
+		log.Info("No UP function features advertised by the remote peer")
+		return nil
+	}
+
+	log.Infof("UP function features: %x %v", res.Features, res.Names)
+
+	return nil
+}
+
+func (c *health) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.Health(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		log.Fatalf("Error while retrieving the status of pfcpsim: %v", err)
+	}
+
+	log.Infof("Status: %v, configured: %v, associated: %v, N4 path up: %v, active sessions: %v",
+		res.Status, res.Configured, res.Associated, res.N4PathUp, res.ActiveSessions)
+
+	return nil
+}
+
+func (c *info) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.Info(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		log.Fatalf("Error while retrieving the version of pfcpsim: %v", err)
+	}
+
+	log.Infof("Version: %v, build commit: %v, PFCP features: %v", res.Version, res.BuildCommit, strings.Join(res.PfcpFeatures, ", "))
+
+	return nil
+}
+
+func (c *associationStatus) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.AssociationStatus(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		log.Fatalf("Error while retrieving the association status: %v", err)
+	}
+
+	log.Info(res.Message)
+
+	if !res.Associated {
+		return nil
+	}
+
+	log.Infof("Recovery time stamps: local %v, remote peer %v", res.RecoveryTimeStamp, res.PeerRecoveryTimeStamp)
+	log.Infof("Associated at %v, N4 path up: %v", res.AssociatedAt, res.N4PathUp)
+	log.Infof("UP function features: %x %v, CP function features: %x",
+		res.UpFunctionFeatures, res.UpFunctionFeatureNames, res.CpFunctionFeatures)
+
+	return nil
+}
+
+func (c *gtpuEcho) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.EchoGTPU(context.Background(), &pb.GTPUEchoRequest{Timeout: c.Timeout})
+	if err != nil {
+		log.Fatalf("Error while sending GTP-U Echo Request: %v", err)
+	}
+
+	log.Infof(res.Message)
+
+	return nil
+}
+
+func (c *logging) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.SetLogging(context.Background(), &pb.LoggingRequest{
+		Level:  c.Level,
+		Format: c.Format,
+	})
+	if err != nil {
+		log.Fatalf("Error while setting logging: %v", err)
+	}
+
+	log.Infof(res.Message)
+
+	return nil
+}
@@ -5,6 +5,9 @@ package commands
 
 import (
 	"context"
+	"strconv"
+	"strings"
+	"time"
 
 	pb "github.com/ardzoht/pfcpsim/api"
 	"github.com/jessevdk/go-flags"
@@ -14,9 +17,9 @@ import (
 type commonArgs struct {
 	Count                int      `short:"c" long:"count" default:"1" description:"The number of sessions to create"`
 	BaseID               int      `short:"i" long:"baseID"  default:"1" description:"The base ID to use"`
-	UePool               string   `short:"u" long:"ue-pool" default:"17.0.0.0/24" description:"The UE pool address"`
+	UePool               []string `short:"u" long:"ue-pool" default:"17.0.0.0/24" description:"The UE pool address. Repeat it to assign UE addresses from several pools"`
 	GnBAddress           string   `short:"g" long:"gnb-addr" description:"The UE pool address"`
-	AppFilterString      []string `short:"a" long:"app-filter" default:"ip:any:any:allow:100" description:"Specify an application filter. Format: '{ip | udp | tcp}:{IPv4 Prefix | any}:{<lower-L4-port>-<upper-L4-port> | any}:{allow | deny}:{rule-precedence}' . e.g. 'udp:10.0.0.0/8:80-88:allow:100'"`
+	AppFilterString      []string `short:"a" long:"app-filter" default:"ip:any:any:allow:100" description:"Specify an application filter. Format: '{ip | udp | tcp | any | <IP-protocol-number>}:{IPv4 Prefix | [IPv6 Prefix] | any}:{<L4-port> | <lower-L4-port>-<upper-L4-port> | any}:{allow | deny}[:{rule-precedence}[:{qfi}[:{ul-mbr}:{dl-mbr}]]]' . The rule precedence is set by --precedence-order if omitted, the QFI of the request is used if qfi is omitted, the MBRs (kbps) of the request if ul-mbr and dl-mbr are omitted. e.g. 'udp:10.0.0.0/8:80-88:allow:100'"`
 	QFI                  uint8    `short:"q" long:"qfi" description:"The QFI value for QERs. Max value 64."`
 	UlTunnelDstIP        string   `short:"l" long:"uplink-tunnel-dst-ip" description:"Uplink tunnel destination IPv4 address"`
 	DlTunnelDstIP        string   `short:"d" long:"downlink-tunnel-dst-ip" description:"Downlink tunnel destination IPv4 address"`
@@ -39,15 +42,40 @@ func (a *commonArgs) validate() {
 type sessionCreate struct {
 	Args struct {
 		commonArgs
+		UePoolRoundRobin bool          `short:"r" long:"ue-pool-round-robin" description:"If set, UE addresses are assigned from the UE pools in round-robin order, instead of exhausting one pool after the other"`
+		Concurrency      int32         `long:"concurrency" default:"1" description:"The maximum number of sessions established in parallel"`
+		PrecedenceOrder  string        `long:"precedence-order" default:"default" choice:"default" choice:"increasing" choice:"decreasing" description:"How precedences are assigned to the application filters not specifying one: all 100, or increasing/decreasing from 100 in the order of the filters"`
+		AppQERFirst      bool          `long:"app-qer-first" description:"If set, PDRs list the application QER before the session QER"`
+		Rate             float64       `long:"rate" description:"The maximum number of sessions established per second. If not set, sessions are established as fast as possible"`
+		Direction        string        `long:"direction" default:"both" choice:"both" choice:"uplink" choice:"downlink" description:"The direction of the rules created for each application filter"`
+		PDNType          string        `long:"pdn-type" default:"ipv4" choice:"ipv4" choice:"ipv6" choice:"ipv4v6" description:"The IP versions of the UE addresses"`
+		UeIPv6Pool       string        `long:"ue-ipv6-pool" description:"The UE IPv6 pool address, used with the ipv6 and ipv4v6 PDN types"`
+		TTL              time.Duration `long:"ttl" description:"The lifetime of the sessions (e.g. 30s), after which they are deleted. If not set, sessions last until deleted"`
+		UlGbr            int32         `long:"ul-gbr" description:"The UL GBR of the session QER in kbps. Requires --ul-ambr or --dl-ambr, which are then the MBRs"`
+		DlGbr            int32         `long:"dl-gbr" description:"The DL GBR of the session QER in kbps. Requires --ul-ambr or --dl-ambr, which are then the MBRs"`
+		AppUlMbr         int32         `long:"app-ul-mbr" description:"The UL MBR of the application QERs in kbps, for the application filters not specifying one. No MBR is set if not set"`
+		AppDlMbr         int32         `long:"app-dl-mbr" description:"The DL MBR of the application QERs in kbps, for the application filters not specifying one. No MBR is set if not set"`
+		SkipSessionQER   bool          `long:"skip-session-qer" description:"If set, no session QER is created and PDRs reference only the application QERs. Session AMBRs are ignored"`
+		DirectionalSDF   bool          `long:"directional-sdf" description:"If set, downlink PDRs get the SDF filter of the uplink ones with source and destination swapped. Can't be combined with --bid"`
+		Peer             string        `long:"peer" description:"The address of the remote peer the sessions are established with, among the configured ones. Default is the remote peer"`
+		TEIDAllocation   string        `long:"teid-allocation" default:"per-session" choice:"per-session" choice:"global" choice:"upf-allocated" description:"How uplink TEIDs are allocated: the base ID of each session, the first TEID not used by any session, or by the UPF"`
+		UlDstInterface   string        `long:"ul-dst-interface" default:"default" choice:"default" choice:"access" choice:"core" choice:"sgi-lan" choice:"cp-function" choice:"li-function" description:"The destination interface of the uplink FARs. Default is core"`
+		DlDstInterface   string        `long:"dl-dst-interface" default:"default" choice:"default" choice:"access" choice:"core" choice:"sgi-lan" choice:"cp-function" choice:"li-function" description:"The destination interface of the downlink FARs. Default is access"`
+		DryRun           bool          `long:"dry-run" description:"If set, the Session Establishment Requests are built and printed in hex, but neither sent nor recorded"`
 	}
 }
 
 type sessionModify struct {
 	Args struct {
 		commonArgs
-		BufferFlag    bool `short:"b" long:"buffer" description:"If set, downlink FARs will have the buffer flag set to true"`
-		NotifyCPFlag  bool `short:"n" long:"notifycp" description:"If set, downlink FARs will have the notify CP flag set to true"`
-		EndMarkerFlag bool `short:"m" long:"endmarker" description:"If set, downlink FARs will have the end marker set to true"`
+		BufferFlag          bool          `short:"b" long:"buffer" description:"If set, downlink FARs will have the buffer flag set to true"`
+		NotifyCPFlag        bool          `short:"n" long:"notifycp" description:"If set, downlink FARs will have the notify CP flag set to true"`
+		EndMarkerFlag       bool          `short:"m" long:"endmarker" description:"If set, downlink FARs will have the end marker set to true, unless buffering"`
+		UplinkEndMarkerFlag bool          `long:"uplink-endmarker" description:"If set, uplink FARs are updated as well, with the end marker set to true"`
+		BufferedPackets     uint8         `long:"buffered-packets" description:"The number of packets the UPF is suggested to buffer, used with the buffer flag"`
+		DlNotifyDelay       time.Duration `long:"dl-notify-delay" description:"The delay of downlink data notifications (e.g. 100ms), used with the buffer flag"`
+		UpdatePDRs          []string      `long:"update-pdr" description:"Update a PDR of the sessions. Format: '<pdr-id>:<precedence>[:<app-filter>]'. The PDR ID is the one in the session with the base ID, the precedence is kept if 0, the SDF filter if the app filter is omitted. e.g. '1:50:tcp:10.0.0.0/8:443:allow'"`
+		QueryURRs           []uint32      `long:"query-urr" description:"Query the usage measured by the URR with this ID in each session, instead of modifying the sessions. Can be repeated"`
 	}
 }
 
@@ -57,10 +85,80 @@ type sessionDelete struct {
 	}
 }
 
+type sessionClear struct {
+	Set bool `long:"set" description:"If set, the sessions are deleted with a single Session Set Deletion Request per remote peer. Requires 'service configure --csid'"`
+}
+
+type sessionCycle struct {
+	Iterations  int32  `short:"n" long:"iterations" default:"100" description:"The number of times a session is established and deleted right after"`
+	BaseID      int32  `short:"i" long:"baseID" default:"1" description:"The base ID to use. Concurrent cycles use the following ones, with the usual step"`
+	UePool      string `short:"u" long:"ue-pool" default:"17.0.0.0/24" description:"The UE pool address"`
+	GnBAddress  string `short:"g" long:"gnb-addr" description:"The (e/g)NodeB address"`
+	Concurrency int32  `long:"concurrency" default:"1" description:"The number of cycles run in parallel"`
+	Peer        string `long:"peer" description:"The address of the remote peer the sessions are established with, among the configured ones. Default is the remote peer"`
+}
+
+type sessionBufferAll struct {
+	BufferedPackets uint8         `long:"buffered-packets" description:"The number of packets the UPF is suggested to buffer per session"`
+	DlNotifyDelay   time.Duration `long:"dl-notify-delay" description:"The delay of downlink data notifications (e.g. 100ms)"`
+}
+
+type sessionDump struct {
+	Path string `short:"f" long:"file" required:"true" description:"The JSON file the active sessions are written to"`
+}
+
+type sessionLoad struct {
+	Path string `short:"f" long:"file" required:"true" description:"The JSON file written by 'session dump'"`
+}
+
+type sessionReports struct{}
+
 type SessionOptions struct {
-	Create sessionCreate `command:"create"`
-	Modify sessionModify `command:"modify"`
-	Delete sessionDelete `command:"delete"`
+	Create    sessionCreate    `command:"create"`
+	Modify    sessionModify    `command:"modify"`
+	Delete    sessionDelete    `command:"delete"`
+	Cycle     sessionCycle     `command:"cycle"`
+	Clear     sessionClear     `command:"clear"`
+	BufferAll sessionBufferAll `command:"buffer-all"`
+	Dump      sessionDump      `command:"dump"`
+	Load      sessionLoad      `command:"load"`
+	Reports   sessionReports   `command:"reports"`
+}
+
+// dstInterface returns the destination interface matching the value of --ul-dst-interface or --dl-dst-interface.
+func dstInterface(iface string) pb.DestinationInterface {
+	return pb.DestinationInterface(pb.DestinationInterface_value["DESTINATION_"+strings.ReplaceAll(strings.ToUpper(iface), "-", "_")])
+}
+
+// pdrUpdates parses the PDR updates passed with --update-pdr.
+func pdrUpdates(args []string) []*pb.PDRUpdate {
+	updates := make([]*pb.PDRUpdate, 0, len(args))
+
+	for _, arg := range args {
+		fields := strings.SplitN(arg, ":", 3)
+		if len(fields) < 2 {
+			log.Fatalf("Invalid PDR update %q. Please make sure to use the format '<pdr-id>:<precedence>[:<app-filter>]'", arg)
+		}
+
+		pdrID, err := strconv.ParseUint(fields[0], 10, 16)
+		if err != nil {
+			log.Fatalf("Invalid PDR ID in PDR update %q: %v", arg, err)
+		}
+
+		precedence, err := strconv.ParseUint(fields[1], 10, 31)
+		if err != nil {
+			log.Fatalf("Invalid precedence in PDR update %q: %v", arg, err)
+		}
+
+		update := &pb.PDRUpdate{PdrID: int32(pdrID), Precedence: int32(precedence)}
+		if len(fields) == 3 {
+			update.AppFilter = fields[2]
+		}
+
+		updates = append(updates, update)
+	}
+
+	return updates
 }
 
 func RegisterSessionCommands(parser *flags.Parser) {
@@ -79,18 +177,38 @@ func (s *sessionCreate) Execute(args []string) error {
 
 	log.Infof("s.Args.UlAmbr:%v, s.Args.DlAmbr:%v", int32(s.Args.UlAmbr), int32(s.Args.DlAmbr))
 	res, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
-		Count:                int32(s.Args.Count),
-		BaseID:               int32(s.Args.BaseID),
-		NodeBAddress:         s.Args.GnBAddress,
-		UeAddressPool:        s.Args.UePool,
-		AppFilters:           s.Args.AppFilterString,
-		Qfi:                  int32(s.Args.QFI),
-		UlTunnelDstIP:        s.Args.UlTunnelDstIP,
-		DlTunnelDstIP:        s.Args.DlTunnelDstIP,
-		TeidAllocFlag:        s.Args.TeidAllocFlag,
-		UlAmbr:               s.Args.UlAmbr,
-		DlAmbr:               s.Args.DlAmbr,
-		BidirectionalSDFFlag: s.Args.BidirectionalSDFFlag,
+		Count:                    int32(s.Args.Count),
+		BaseID:                   int32(s.Args.BaseID),
+		NodeBAddress:             s.Args.GnBAddress,
+		UeAddressPools:           s.Args.UePool,
+		UeAddressPoolsRoundRobin: s.Args.UePoolRoundRobin,
+		Concurrency:              s.Args.Concurrency,
+		SessionsPerSecond:        s.Args.Rate,
+		AppQERFirst:              s.Args.AppQERFirst,
+		PrecedenceOrder:          pb.PrecedenceOrder(pb.PrecedenceOrder_value["PRECEDENCE_"+strings.ToUpper(s.Args.PrecedenceOrder)]),
+		Direction:                pb.Direction(pb.Direction_value[strings.ToUpper(s.Args.Direction)]),
+		PdnType:                  pb.PdnType(pb.PdnType_value[strings.ToUpper(s.Args.PDNType)]),
+		TeidAllocation:           pb.TeidAllocation(pb.TeidAllocation_value[strings.ReplaceAll(strings.ToUpper(s.Args.TEIDAllocation), "-", "_")]),
+		UeIPv6AddressPool:        s.Args.UeIPv6Pool,
+		TtlMs:                    uint32(s.Args.TTL.Milliseconds()),
+		SkipSessionQER:           s.Args.SkipSessionQER,
+		AppFilters:               s.Args.AppFilterString,
+		Qfi:                      int32(s.Args.QFI),
+		UlTunnelDstIP:            s.Args.UlTunnelDstIP,
+		DlTunnelDstIP:            s.Args.DlTunnelDstIP,
+		TeidAllocFlag:            s.Args.TeidAllocFlag,
+		UlAmbr:                   s.Args.UlAmbr,
+		DlAmbr:                   s.Args.DlAmbr,
+		UlGbr:                    s.Args.UlGbr,
+		DlGbr:                    s.Args.DlGbr,
+		AppUlMbr:                 s.Args.AppUlMbr,
+		AppDlMbr:                 s.Args.AppDlMbr,
+		BidirectionalSDFFlag:     s.Args.BidirectionalSDFFlag,
+		DirectionalSDFFlag:       s.Args.DirectionalSDF,
+		Peer:                     s.Args.Peer,
+		UplinkDstInterface:       dstInterface(s.Args.UlDstInterface),
+		DownlinkDstInterface:     dstInterface(s.Args.DlDstInterface),
+		DryRun:                   s.Args.DryRun,
 	})
 
 	if err != nil {
@@ -99,6 +217,18 @@ func (s *sessionCreate) Execute(args []string) error {
 
 	log.Infof(res.Message)
 
+	for _, created := range res.Sessions {
+		log.Infof("Session %v: UE address %v %v", created.BaseID, created.UeAddress, created.UeIPv6Address)
+
+		if created.EstablishmentRequest != "" {
+			log.Infof("Session %v: Session Establishment Request %v", created.BaseID, created.EstablishmentRequest)
+		}
+
+		for _, rule := range created.FailedRules {
+			log.Warnf("Session %v: %v %v reported as failed by the remote peer", created.BaseID, rule.Type, rule.Id)
+		}
+	}
+
 	return nil
 }
 
@@ -109,16 +239,21 @@ func (s *sessionModify) Execute(args []string) error {
 	s.Args.validate()
 
 	res, err := client.ModifySession(context.Background(), &pb.ModifySessionRequest{
-		Count:         int32(s.Args.Count),
-		BaseID:        int32(s.Args.BaseID),
-		NodeBAddress:  s.Args.GnBAddress,
-		UeAddressPool: s.Args.UePool,
-		BufferFlag:    s.Args.BufferFlag,
-		NotifyCPFlag:  s.Args.NotifyCPFlag,
-		AppFilters:    s.Args.AppFilterString,
-		EndMarkerFlag: s.Args.EndMarkerFlag,
-		UlAmbr:        s.Args.UlAmbr,
-		DlAmbr:        s.Args.DlAmbr,
+		Count:                          int32(s.Args.Count),
+		BaseID:                         int32(s.Args.BaseID),
+		NodeBAddress:                   s.Args.GnBAddress,
+		UeAddressPool:                  s.Args.UePool[0],
+		BufferFlag:                     s.Args.BufferFlag,
+		NotifyCPFlag:                   s.Args.NotifyCPFlag,
+		AppFilters:                     s.Args.AppFilterString,
+		EndMarkerFlag:                  s.Args.EndMarkerFlag,
+		UlAmbr:                         s.Args.UlAmbr,
+		DlAmbr:                         s.Args.DlAmbr,
+		UplinkEndMarkerFlag:            s.Args.UplinkEndMarkerFlag,
+		SuggestedBufferingPacketsCount: int32(s.Args.BufferedPackets),
+		DlDataNotificationDelayMs:      int32(s.Args.DlNotifyDelay.Milliseconds()),
+		PdrUpdates:                     pdrUpdates(s.Args.UpdatePDRs),
+		QueryURRIDs:                    s.Args.QueryURRs,
 	})
 
 	if err != nil {
@@ -127,6 +262,15 @@ func (s *sessionModify) Execute(args []string) error {
 
 	log.Infof(res.Message)
 
+	for _, rule := range res.FailedRules {
+		log.Warnf("%v %v reported as failed by the remote peer", rule.Type, rule.Id)
+	}
+
+	for _, report := range res.UsageReports {
+		log.Infof("Usage report: SEID %v, URR ID %v, volume (total/UL/DL) %v/%v/%v bytes, duration %vs",
+			report.Seid, report.UrrID, report.TotalVolume, report.UplinkVolume, report.DownlinkVolume, report.Duration)
+	}
+
 	return nil
 }
 
@@ -144,8 +288,125 @@ func (s *sessionDelete) Execute(args []string) error {
 		log.Fatalf("Error while deleting sessions: %v", err)
 	}
 
+	log.Infof("%v sessions deleted; activeSessions: %v", len(res.DeletedBaseIDs), res.ActiveSessions)
+
+	for _, failure := range res.Failures {
+		log.Warnf("Session %v: could not be deleted: %v", failure.BaseID, failure.Error)
+	}
+
+	if len(res.Failures) > 0 {
+		log.Fatalf("%v of %v sessions could not be deleted", len(res.Failures), s.Args.Count)
+	}
+
+	return nil
+}
+
+func (s *sessionClear) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	clearSessions := client.ClearAllSessions
+	if s.Set {
+		clearSessions = client.DeleteSessionSet
+	}
+
+	res, err := clearSessions(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		log.Fatalf("Error while clearing sessions: %v", err)
+	}
+
+	log.Infof(res.Message)
+
+	return nil
+}
+
+func (s *sessionCycle) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.CreateSessionAndDelete(context.Background(), &pb.CreateSessionAndDeleteRequest{
+		Iterations:    s.Iterations,
+		BaseID:        s.BaseID,
+		NodeBAddress:  s.GnBAddress,
+		UeAddressPool: s.UePool,
+		Concurrency:   s.Concurrency,
+		Peer:          s.Peer,
+	})
+	if err != nil {
+		log.Fatalf("Error while cycling sessions: %v", err)
+	}
+
+	log.Infof(res.Message)
+
+	return nil
+}
+
+func (s *sessionBufferAll) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.BufferAllSessions(context.Background(), &pb.BufferAllSessionsRequest{
+		SuggestedBufferingPacketsCount: int32(s.BufferedPackets),
+		DlDataNotificationDelayMs:      int32(s.DlNotifyDelay.Milliseconds()),
+	})
+	if err != nil {
+		log.Fatalf("Error while buffering sessions: %v", err)
+	}
+
 	log.Infof(res.Message)
 
 	return nil
 }
 
+func (s *sessionDump) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.DumpState(context.Background(), &pb.StateRequest{Path: s.Path})
+	if err != nil {
+		log.Fatalf("Error while dumping sessions: %v", err)
+	}
+
+	log.Infof(res.Message)
+
+	return nil
+}
+
+func (s *sessionLoad) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.LoadState(context.Background(), &pb.StateRequest{Path: s.Path})
+	if err != nil {
+		log.Fatalf("Error while loading sessions: %v", err)
+	}
+
+	log.Infof(res.Message)
+
+	return nil
+}
+
+func (s *sessionReports) Execute(args []string) error {
+	client := connect()
+	defer disconnect()
+
+	stream, err := client.SubscribeReports(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		log.Fatalf("Error while subscribing to session reports: %v", err)
+	}
+
+	for {
+		report, err := stream.Recv()
+		if err != nil {
+			log.Fatalf("Error while receiving session reports: %v", err)
+		}
+
+		if report.Attempt != 0 {
+			log.Infof("Session report: type %v, attempt %v", report.Type, report.Attempt)
+			continue
+		}
+
+		log.Infof("Session report: SEID %v, type %v, URR ID %v, volume (total/UL/DL) %v/%v/%v bytes, duration %vs",
+			report.Seid, report.Type, report.UrrID, report.TotalVolume, report.UplinkVolume, report.DownlinkVolume, report.Duration)
+	}
+}
@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/ardzoht/pfcpsim/api"
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// serverConfig is the content of the configuration file loaded at startup.
+// Being JSON a subset of YAML, the file can be written in either format.
+type serverConfig struct {
+	RemotePeerAddress            string   `yaml:"remotePeerAddress"`
+	AdditionalPeerAddresses      []string `yaml:"additionalPeerAddresses"`
+	UpfN3Address                 string   `yaml:"upfN3Address"`
+	LocalN4Address               string   `yaml:"localN4Address"`
+	PfcpPort                     int32    `yaml:"pfcpPort"`
+	AssociationRetries           int32    `yaml:"associationRetries"`
+	CapturePath                  string   `yaml:"capturePath"`
+	CpFunctionFeatures           uint32   `yaml:"cpFunctionFeatures"`
+	MaxMissedHeartbeats          int32    `yaml:"maxMissedHeartbeats"`
+	ReestablishOnErrorIndication bool     `yaml:"reestablishOnErrorIndication"`
+	Csid                         uint32   `yaml:"csid"`
+	NodeIDType                   string   `yaml:"nodeIDType"`
+	NodeID                       string   `yaml:"nodeID"`
+	AssociationTimeout           int32    `yaml:"associationTimeout"`
+	EstablishmentTimeout         int32    `yaml:"establishmentTimeout"`
+	ModificationTimeout          int32    `yaml:"modificationTimeout"`
+	DeletionTimeout              int32    `yaml:"deletionTimeout"`
+	HeartbeatTimeout             int32    `yaml:"heartbeatTimeout"`
+	SeidAllocation               string   `yaml:"seidAllocation"`
+	ReassociationBackoffMs       int32    `yaml:"reassociationBackoffMs"`
+	// MessagePriority is the priority of the session related requests. Disabled if not set
+	MessagePriority *uint32 `yaml:"messagePriority"`
+
+	// QER holds the QER parameters of the CreateSession requests not specifying them
+	QER struct {
+		Qfi    int32 `yaml:"qfi"`
+		UlAmbr int32 `yaml:"ulAmbr"`
+		DlAmbr int32 `yaml:"dlAmbr"`
+	} `yaml:"qer"`
+}
+
+// LoadConfig configures the server from the YAML or JSON file at path, as the Configure RPC does.
+// The RPC can still override the configuration afterwards. Returns error if the file can't be read,
+// contains unknown fields or invalid values.
+func LoadConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read configuration file: %w", err)
+	}
+
+	var config serverConfig
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&config); err != nil {
+		return fmt.Errorf("could not parse configuration file %v: %w", path, err)
+	}
+
+	if config.QER.Qfi < 0 || config.QER.Qfi > 63 {
+		return pfcpsim.NewInvalidFormatError(fmt.Sprintf("QFI %v. Please make sure it is a number between 0 and 63", config.QER.Qfi))
+	}
+
+	if config.QER.UlAmbr < 0 || config.QER.DlAmbr < 0 {
+		return pfcpsim.NewInvalidFormatError(fmt.Sprintf("AMBRs uplink %v, downlink %v. Please make sure they are not negative",
+			config.QER.UlAmbr, config.QER.DlAmbr))
+	}
+
+	var seidAllocation int32
+
+	if config.SeidAllocation != "" {
+		var ok bool
+
+		seidAllocation, ok = pb.SeidAllocation_value["SEID_"+strings.ReplaceAll(strings.ToUpper(config.SeidAllocation), "-", "_")]
+		if !ok {
+			return pfcpsim.NewInvalidFormatError(fmt.Sprintf("SEID allocation %v. Please make sure it is sequential, random or base-id",
+				config.SeidAllocation))
+		}
+	}
+
+	var messagePriority uint32
+	if config.MessagePriority != nil {
+		messagePriority = *config.MessagePriority
+	}
+
+	configurationMsg, err := configure(&pb.ConfigureRequest{
+		RemotePeerAddress:            config.RemotePeerAddress,
+		AdditionalPeerAddresses:      config.AdditionalPeerAddresses,
+		UpfN3Address:                 config.UpfN3Address,
+		LocalN4Address:               config.LocalN4Address,
+		PfcpPort:                     config.PfcpPort,
+		AssociationRetries:           config.AssociationRetries,
+		CapturePath:                  config.CapturePath,
+		CpFunctionFeatures:           config.CpFunctionFeatures,
+		MaxMissedHeartbeats:          config.MaxMissedHeartbeats,
+		ReestablishOnErrorIndication: config.ReestablishOnErrorIndication,
+		Csid:                         config.Csid,
+		NodeIDType:                   config.NodeIDType,
+		NodeID:                       config.NodeID,
+		AssociationTimeout:           config.AssociationTimeout,
+		EstablishmentTimeout:         config.EstablishmentTimeout,
+		ModificationTimeout:          config.ModificationTimeout,
+		DeletionTimeout:              config.DeletionTimeout,
+		HeartbeatTimeout:             config.HeartbeatTimeout,
+		SeidAllocation:               pb.SeidAllocation(seidAllocation),
+		ReassociationBackoffMs:       config.ReassociationBackoffMs,
+		EnableMessagePriority:        config.MessagePriority != nil,
+		MessagePriority:              messagePriority,
+	})
+	if err != nil {
+		return err
+	}
+
+	defaultQFI = config.QER.Qfi
+	defaultUlAmbr = config.QER.UlAmbr
+	defaultDlAmbr = config.QER.DlAmbr
+
+	log.Infof("Configuration loaded from %v. %v", path, configurationMsg)
+
+	return nil
+}
+
+// configure validates request and applies it to the server configuration.
+// Returns a description of the resulting configuration.
+func configure(request *pb.ConfigureRequest) (string, error) {
+	if net.ParseIP(request.UpfN3Address) == nil {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("UPF N3 address %v", request.UpfN3Address))
+	}
+
+	if request.AssociationRetries < 0 {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("association retries %v. Please make sure they are not negative",
+			request.AssociationRetries))
+	}
+
+	if request.LocalN4Address != "" && net.ParseIP(request.LocalN4Address) == nil {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("local N4 address %v", request.LocalN4Address))
+	}
+
+	if request.PfcpPort < 0 || request.PfcpPort > 65535 {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("PFCP port %v. Please make sure it is a number between 0 and 65535",
+			request.PfcpPort))
+	}
+
+	if request.MaxMissedHeartbeats < 0 {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("max missed heartbeats %v. Please make sure they are not negative",
+			request.MaxMissedHeartbeats))
+	}
+
+	if request.CpFunctionFeatures > math.MaxUint8 {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("CP function features %v. Please make sure they fit in one octet",
+			request.CpFunctionFeatures))
+	}
+
+	if request.Csid > math.MaxUint16 {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("CSID %v. Please make sure it is a number between 0 and 65535", request.Csid))
+	}
+
+	timeouts := map[pfcpsim.Operation]int32{
+		pfcpsim.OperationAssociation:   request.AssociationTimeout,
+		pfcpsim.OperationEstablishment: request.EstablishmentTimeout,
+		pfcpsim.OperationModification:  request.ModificationTimeout,
+		pfcpsim.OperationDeletion:      request.DeletionTimeout,
+		pfcpsim.OperationHeartbeat:     request.HeartbeatTimeout,
+	}
+
+	for _, timeout := range timeouts {
+		if timeout < 0 {
+			return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("operation timeout %v. Please make sure it is not negative", timeout))
+		}
+	}
+
+	if request.ReassociationBackoffMs < 0 {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("re-association backoff %v. Please make sure it is not negative",
+			request.ReassociationBackoffMs))
+	}
+
+	if request.EnableMessagePriority && request.MessagePriority > pfcpsim.MaxMessagePriority {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("message priority %v. Please make sure it is a number between 0 and %v",
+			request.MessagePriority, pfcpsim.MaxMessagePriority))
+	}
+
+	if _, ok := pb.SeidAllocation_name[int32(request.SeidAllocation)]; !ok {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("SEID allocation %v", request.SeidAllocation))
+	}
+
+	var localNodeIDType uint8
+
+	if request.NodeID != "" || request.NodeIDType != "" {
+		var err error
+
+		if localNodeIDType, err = parseNodeID(request.NodeIDType, request.NodeID); err != nil {
+			return "", err
+		}
+	}
+
+	for _, address := range append([]string{request.RemotePeerAddress}, request.AdditionalPeerAddresses...) {
+		if err := validateRemotePeerAddress(address); err != nil {
+			return "", pfcpsim.NewInvalidFormatError("remote peer address", err)
+		}
+	}
+
+	remotePeerAddress = request.RemotePeerAddress
+	additionalPeerAddresses = request.AdditionalPeerAddresses
+	upfN3Address = request.UpfN3Address
+	associationRetries = int(request.AssociationRetries)
+	localN4Address = request.LocalN4Address
+	pfcpPort = int(request.PfcpPort)
+	capturePath = request.CapturePath
+	cpFunctionFeatures = uint8(request.CpFunctionFeatures)
+	csid = uint16(request.Csid)
+	nodeIDType = localNodeIDType
+	nodeID = request.NodeID
+	seidAllocation = request.SeidAllocation
+
+	messagePriority = -1
+	if request.EnableMessagePriority {
+		messagePriority = int(request.MessagePriority)
+	}
+
+	reestablishOnErrorIndication = request.ReestablishOnErrorIndication
+
+	operationTimeouts = make(map[pfcpsim.Operation]time.Duration)
+
+	for op, timeout := range timeouts {
+		if timeout != 0 {
+			operationTimeouts[op] = time.Duration(timeout) * time.Millisecond
+		}
+	}
+
+	reassociationBackoff = time.Duration(request.ReassociationBackoffMs) * time.Millisecond
+
+	maxMissedHeartbeats = pfcpsim.DefaultMaxMissedHeartbeats
+	if request.MaxMissedHeartbeats != 0 {
+		maxMissedHeartbeats = int(request.MaxMissedHeartbeats)
+	}
+
+	configurationMsg := fmt.Sprintf("Server is configured. Remote peer address: %v, N3 interface address: %v, association retries: %v",
+		remotePeerAddress, upfN3Address, associationRetries)
+	if localN4Address != "" {
+		configurationMsg += fmt.Sprintf(", local N4 address: %v", localN4Address)
+	}
+
+	if pfcpPort != 0 {
+		configurationMsg += fmt.Sprintf(", PFCP port: %v", pfcpPort)
+	}
+
+	if capturePath != "" {
+		configurationMsg += fmt.Sprintf(", capture file: %v", capturePath)
+	}
+
+	if nodeID != "" {
+		configurationMsg += fmt.Sprintf(", Node ID: %v", nodeID)
+	}
+
+	if csid != 0 {
+		configurationMsg += fmt.Sprintf(", CSID: %v", csid)
+	}
+
+	if messagePriority >= 0 {
+		configurationMsg += fmt.Sprintf(", message priority: %v", messagePriority)
+	}
+
+	if len(additionalPeerAddresses) > 0 {
+		configurationMsg += fmt.Sprintf(", additional remote peers: %v", additionalPeerAddresses)
+	}
+
+	return configurationMsg, nil
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/ardzoht/pfcpsim/api"
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	"github.com/stretchr/testify/require"
+	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// writeConfig writes content to a configuration file and returns its path.
+// The configuration loaded from it is reset at the end of the test.
+func writeConfig(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	t.Cleanup(func() {
+		remotePeerAddress = ""
+		additionalPeerAddresses = nil
+		upfN3Address = ""
+		localN4Address = ""
+		pfcpPort = 0
+		associationRetries = 0
+		capturePath = ""
+		cpFunctionFeatures = 0
+		maxMissedHeartbeats = pfcpsim.DefaultMaxMissedHeartbeats
+		reestablishOnErrorIndication = false
+		csid = 0
+		nodeIDType, nodeID = 0, ""
+		seidAllocation = pb.SeidAllocation_SEID_SEQUENTIAL
+		messagePriority = -1
+		defaultQFI, defaultUlAmbr, defaultDlAmbr = 0, 0, 0
+	})
+
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeConfig(t, "pfcpsim.yaml", `
+remotePeerAddress: 10.0.0.1
+additionalPeerAddresses:
+  - 10.0.0.3:8806
+upfN3Address: 198.18.0.1
+localN4Address: 10.0.0.2
+pfcpPort: 8805
+associationRetries: 3
+maxMissedHeartbeats: 4
+reestablishOnErrorIndication: true
+csid: 7
+nodeID: smf.5gc.local
+seidAllocation: base-id
+messagePriority: 0
+qer:
+  qfi: 9
+  ulAmbr: 50000
+  dlAmbr: 100000
+`)
+
+	require.NoError(t, LoadConfig(path))
+
+	require.True(t, isConfigured())
+	require.Equal(t, "10.0.0.1", remotePeerAddress)
+	require.Equal(t, []string{"10.0.0.3:8806"}, additionalPeerAddresses)
+	require.Equal(t, "198.18.0.1", upfN3Address)
+	require.Equal(t, "10.0.0.2", localN4Address)
+	require.Equal(t, 8805, pfcpPort)
+	require.Equal(t, 3, associationRetries)
+	require.Equal(t, 4, maxMissedHeartbeats)
+	require.True(t, reestablishOnErrorIndication)
+	require.Equal(t, uint16(7), csid)
+	require.Equal(t, "smf.5gc.local", nodeID)
+	require.Equal(t, ie.NodeIDFQDN, nodeIDType)
+	require.Equal(t, pb.SeidAllocation_SEID_BASE_ID, seidAllocation)
+	require.Equal(t, 0, messagePriority)
+	require.Equal(t, int32(9), defaultQFI)
+	require.Equal(t, int32(50000), defaultUlAmbr)
+	require.Equal(t, int32(100000), defaultDlAmbr)
+
+	// the Configure RPC overrides the file, but not the QER parameters
+	_, err := NewPFCPSimService("", 0).Configure(context.Background(), &pb.ConfigureRequest{
+		RemotePeerAddress: "10.0.0.5",
+		UpfN3Address:      "198.18.0.1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.5", remotePeerAddress)
+	require.Empty(t, localN4Address)
+	require.Equal(t, -1, messagePriority)
+	require.Equal(t, int32(9), defaultQFI)
+}
+
+func TestLoadConfigJSON(t *testing.T) {
+	path := writeConfig(t, "pfcpsim.json", `{"remotePeerAddress": "upf.local", "upfN3Address": "198.18.0.1", "qer": {"qfi": 5}}`)
+
+	require.NoError(t, LoadConfig(path))
+	require.Equal(t, "upf.local", remotePeerAddress)
+	require.Equal(t, int32(5), defaultQFI)
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "malformed", content: "remotePeerAddress: [10.0.0.1"},
+		{name: "unknown field", content: "remotePeerAddress: 10.0.0.1\nupfN3Address: 198.18.0.1\nn3Address: 198.18.0.1"},
+		{name: "invalid N3 address", content: "remotePeerAddress: 10.0.0.1\nupfN3Address: invalid"},
+		{name: "invalid remote peer", content: "remotePeerAddress: upf_1\nupfN3Address: 198.18.0.1"},
+		{name: "QFI out of range", content: "remotePeerAddress: 10.0.0.1\nupfN3Address: 198.18.0.1\nqer:\n  qfi: 64"},
+		{name: "CSID out of range", content: "remotePeerAddress: 10.0.0.1\nupfN3Address: 198.18.0.1\ncsid: 65536"},
+		{name: "unknown SEID allocation", content: "remotePeerAddress: 10.0.0.1\nupfN3Address: 198.18.0.1\nseidAllocation: odd"},
+		{name: "negative AMBR", content: "remotePeerAddress: 10.0.0.1\nupfN3Address: 198.18.0.1\nqer:\n  ulAmbr: -1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, LoadConfig(writeConfig(t, "pfcpsim.yaml", tt.content)))
+			require.False(t, isConfigured())
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		require.Error(t, LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")))
+	})
+}
+
+func TestCreateSessionDefaultQER(t *testing.T) {
+	require.NoError(t, LoadConfig(writeConfig(t, "pfcpsim.yaml", `
+remotePeerAddress: 10.0.0.1
+upfN3Address: 198.18.0.1
+qer:
+  qfi: 9
+  ulAmbr: 50000
+  dlAmbr: 100000
+`)))
+
+	upf := setupAssociation(t)
+
+	_, err := NewPFCPSimService("", 0).CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, received, 1)
+
+	qers := received[0].(*message.SessionEstablishmentRequest).CreateQER
+	require.NotEmpty(t, qers)
+
+	// the session QER comes first
+	ulMBR, err := qers[0].MBRUL()
+	require.NoError(t, err)
+	require.Equal(t, uint64(50000), ulMBR)
+
+	dlMBR, err := qers[0].MBRDL()
+	require.NoError(t, err)
+	require.Equal(t, uint64(100000), dlMBR)
+
+	for _, qer := range qers[1:] {
+		qfi, err := qer.QFI()
+		require.NoError(t, err)
+		require.Equal(t, uint8(9), qfi)
+	}
+}
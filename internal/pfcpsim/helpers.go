@@ -4,51 +4,1108 @@
 package pfcpsim
 
 import (
+	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
-	"github.com/omec-project/pfcpsim/pkg/pfcpsim"
+	pb "github.com/ardzoht/pfcpsim/api"
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim/session"
+	"github.com/c-robinson/iplib"
 	log "github.com/sirupsen/logrus"
 	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-const sdfFilterFormatWPort = "permit out %v from %v to assigned %v-%v"
+const sdfFilterFormatWPort = "permit out %v from %v to assigned %v"
 const sdfFilterFormatWOPort = "permit out %v from %v to assigned"
 
+// defaultAppFilterPrecedence is the precedence of the PDRs of application filters not specifying one
+const defaultAppFilterPrecedence = 100
+
+// passThroughAppFilter is the application filter of the sessions created without any, matching all the traffic
+const passThroughAppFilter = "ip:any:any:allow:100"
+
+// errUEAddressesExhausted is returned if the UE address pools don't provide enough addresses
+var errUEAddressesExhausted = errors.New("not enough UE addresses")
+
+// errInvalidSessionGBR is returned if the session GBRs can't be enforced by the session QER
+var errInvalidSessionGBR = errors.New("invalid session GBRs")
+
+// errPDRNotFound is returned if a session was not created with the PDR to update
+var errPDRNotFound = errors.New("PDR not found")
+
+// errFTEIDUnknown is returned if the remote peer did not report the F-TEID it chose for a PDR
+var errFTEIDUnknown = errors.New("F-TEID chosen by the remote peer not known")
+
+const (
+	reportTypeUsage           = "usage"
+	reportTypeDownlinkData    = "downlink-data"
+	reportTypePeerRestart     = "peer-restart"
+	reportTypePathFailure     = "n4-path-failure"
+	reportTypePathRecovery    = "n4-path-recovery"
+	reportTypeErrorIndication = "error-indication"
+
+	reportTypeReassociation        = "reassociation"
+	reportTypeReassociationFailure = "reassociation-failure"
+
+	reportSubscriberBufferSize = 64
+)
+
 func connectPFCPSim() error {
 	if sim == nil {
-		localAddr, err := getLocalAddress(interfaceName)
+		localAddr, err := localAddress(remotePeerAddress)
+		if err != nil {
+			return err
+		}
+
+		sim = newSim(localAddr)
+	}
+
+	sim.SetMaxMissedHeartbeats(maxMissedHeartbeats)
+
+	if localN4Address != "" {
+		if err := sim.SetLocalN4Address(localN4Address); err != nil {
+			return err
+		}
+	}
+
+	err := sim.ConnectN4(peerEndpoint(remotePeerAddress))
+	if err != nil {
+		return err
+	}
+
+	if capturePath != "" {
+		if err := sim.StartCapture(capturePath); err != nil {
+			sim.DisconnectN4()
+			return err
+		}
+	}
+
+	setRemotePeerConnected(true)
+
+	return nil
+}
+
+// validateRemotePeerAddress verifies that address is either a host or a host:port pair,
+// where host is an IP address or a hostname. PFCPStandardPort is used if the port is omitted.
+func validateRemotePeerAddress(address string) error {
+	host := strings.Trim(address, "[]")
+
+	if h, port, err := net.SplitHostPort(address); err == nil {
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil || p == 0 {
+			return pfcpsim.NewInvalidFormatError(fmt.Sprintf(
+				"remote peer port %v. Please make sure it is a number between 1 and 65535", port))
+		}
+
+		host = h
+	}
+
+	if net.ParseIP(host) == nil && !pfcpsim.IsValidHostname(host) {
+		return pfcpsim.NewInvalidFormatError(fmt.Sprintf("remote peer host %v", host))
+	}
+
+	return nil
+}
+
+// nodeIDTypes maps the Node ID types accepted by the Configure RPC to the ones of the Node ID IE.
+var nodeIDTypes = map[string]uint8{
+	"ipv4": ie.NodeIDIPv4Address,
+	"ipv6": ie.NodeIDIPv6Address,
+	"fqdn": ie.NodeIDFQDN,
+}
+
+// parseNodeID returns the Node ID IE type of value, named typeName. If typeName is empty,
+// the type is inferred from value. Returns error if value does not match the type.
+func parseNodeID(typeName string, value string) (uint8, error) {
+	if value == "" {
+		return 0, pfcpsim.NewInvalidFormatError(fmt.Sprintf("Node ID of type %v. Please make sure it is not empty", typeName))
+	}
+
+	nodeIDType, ok := nodeIDTypes[strings.ToLower(typeName)]
+
+	switch {
+	case typeName == "" && net.ParseIP(value) == nil:
+		nodeIDType = ie.NodeIDFQDN
+	case typeName == "" && net.ParseIP(value).To4() == nil:
+		nodeIDType = ie.NodeIDIPv6Address
+	case typeName == "":
+		nodeIDType = ie.NodeIDIPv4Address
+	case !ok:
+		return 0, pfcpsim.NewInvalidFormatError(fmt.Sprintf("Node ID type %v. Please make sure to use 'ipv4', 'ipv6' or 'fqdn'", typeName))
+	}
+
+	return nodeIDType, pfcpsim.ValidateNodeID(nodeIDType, value)
+}
+
+// applyNodeID makes client advertise nodeID, if set, or the Node ID derived from its local address.
+func applyNodeID(client *pfcpsim.PFCPClient) error {
+	if nodeID == "" {
+		client.ResetNodeID()
+		return nil
+	}
+
+	return client.SetNodeID(nodeIDType, nodeID)
+}
+
+// applyOperationTimeouts makes client wait for the responses as long as configured for each operation.
+// The operations without a configured timeout use the default response timeout.
+func applyOperationTimeouts(client *pfcpsim.PFCPClient) {
+	for _, op := range []pfcpsim.Operation{
+		pfcpsim.OperationAssociation,
+		pfcpsim.OperationEstablishment,
+		pfcpsim.OperationModification,
+		pfcpsim.OperationDeletion,
+		pfcpsim.OperationHeartbeat,
+	} {
+		client.SetOperationTimeout(op, operationTimeouts[op])
+	}
+}
+
+// applySEIDAllocation makes client allocate the local SEIDs as configured. With SEID_BASE_ID, establishSession
+// chooses them instead: client allocates them sequentially only for re-established sessions.
+func applySEIDAllocation(client *pfcpsim.PFCPClient) {
+	if seidAllocation == pb.SeidAllocation_SEID_RANDOM {
+		client.SetSEIDAllocation(pfcpsim.SEIDAllocationRandom)
+		return
+	}
+
+	client.SetSEIDAllocation(pfcpsim.SEIDAllocationSequential)
+}
+
+// applyMessagePriority makes client send the session related requests with the configured message priority, if any.
+func applyMessagePriority(client *pfcpsim.PFCPClient) error {
+	if messagePriority < 0 {
+		client.DisableMessagePriority()
+		return nil
+	}
+
+	return client.SetMessagePriority(uint8(messagePriority))
+}
+
+// establishSession establishes through client the session identified by baseID. Its local SEID is baseID
+// with SEID_BASE_ID, allocated by client otherwise.
+func establishSession(ctx context.Context, client *pfcpsim.PFCPClient, baseID int, pdnType uint8, pdrs, fars, qers []*ie.IE) (*pfcpsim.PFCPSession, error) {
+	if seidAllocation == pb.SeidAllocation_SEID_BASE_ID {
+		return client.EstablishSessionWithSEID(ctx, uint64(baseID), pdnType, pdrs, fars, qers)
+	}
+
+	return client.EstablishSessionWithPDNType(ctx, pdnType, pdrs, fars, qers)
+}
+
+// newDryRunSession builds through client the Session Establishment Request of the session identified by baseID,
+// without sending it. Its F-SEID carries baseID, as the local SEID is allocated only when the request is sent.
+func newDryRunSession(client *pfcpsim.PFCPClient, baseID int, pdnType uint8, pdrs, fars, qers []*ie.IE) (*pb.CreatedSession, error) {
+	estReq := client.BuildSessionEstablishmentRequest(uint64(baseID), pdnType, pdrs, fars, qers)
+
+	payload, err := estReq.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreatedSession{
+		BaseID:               int32(baseID),
+		LocalSEID:            uint64(baseID),
+		LocalAddress:         client.LocalAddr(),
+		EstablishmentRequest: hex.EncodeToString(payload),
+	}, nil
+}
+
+// localAddress returns the source address of the N4 messages sent to peerAddress:
+// localN4Address if set, the address of interfaceName otherwise.
+func localAddress(peerAddress string) (string, error) {
+	if localN4Address != "" {
+		return localN4Address, nil
+	}
+
+	ip, err := getLocalAddress(interfaceName, isIPv6Peer(peerAddress))
+	if err != nil {
+		return "", err
+	}
+
+	return ip.String(), nil
+}
+
+// gtpuLocalAddress returns the source address of GTP-U Echo Requests towards upfN3Address.
+// If no address is found, an empty string is returned to let the OS choose it.
+func gtpuLocalAddress() string {
+	addr, err := localAddress(upfN3Address)
+	if err != nil {
+		log.Debugf("Could not find the local address towards %v: %v", upfN3Address, err)
+		return ""
+	}
+
+	return addr
+}
+
+// peerEndpoint returns the address of the remote peer, including pfcpPort if
+// address does not specify a port.
+func peerEndpoint(address string) string {
+	if pfcpPort == 0 {
+		return address
+	}
+
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+
+	return net.JoinHostPort(strings.Trim(address, "[]"), strconv.Itoa(pfcpPort))
+}
+
+// setLogging sets the level and the format of the standard logger, used by the whole server.
+// Empty values leave the current setting unchanged. Returns error if level or format is unknown.
+func setLogging(level, format string) error {
+	var formatter log.Formatter
+
+	switch format {
+	case "":
+	case "text":
+		formatter = &log.TextFormatter{}
+	case "json":
+		formatter = &log.JSONFormatter{}
+	default:
+		return pfcpsim.NewInvalidFormatError(fmt.Sprintf("log format %q. Please use 'text' or 'json'", format))
+	}
+
+	if level != "" {
+		parsed, err := log.ParseLevel(level)
+		if err != nil {
+			return pfcpsim.NewInvalidFormatError("log level", err)
+		}
+
+		log.SetLevel(parsed)
+	}
+
+	if formatter != nil {
+		log.SetFormatter(formatter)
+	}
+
+	return nil
+}
+
+// logFormat returns the format of the standard logger, either "text" or "json".
+func logFormat() string {
+	if _, ok := log.StandardLogger().Formatter.(*log.JSONFormatter); ok {
+		return "json"
+	}
+
+	return "text"
+}
+
+// newSim returns a new PFCPClient and starts forwarding its session reports to the subscribers.
+func newSim(localAddr string) *pfcpsim.PFCPClient {
+	client := pfcpsim.NewPFCPClient(localAddr)
+
+	go dispatchSessionReports(client, client.SessionReports())
+	go dispatchPeerRestarts(client.PeerRestarts())
+	go dispatchN4PathEvents(client.N4PathEvents())
+	go dispatchReassociationAttempts(client.ReassociationAttempts())
+
+	return client
+}
+
+func dispatchSessionReports(client *pfcpsim.PFCPClient, reports <-chan *message.SessionReportRequest) {
+	for req := range reports {
+		for _, report := range newSessionReports(req) {
+			publishReport(report)
+		}
+
+		if isErrorIndication(req) {
+			handleErrorIndication(client, req.SEID())
+		}
+	}
+}
+
+func dispatchPeerRestarts(restarts <-chan pfcpsim.PeerRestart) {
+	for restart := range restarts {
+		log.Warnf("Remote peer restarted at %v: association lost, %v sessions are now stale",
+			restart.RecoveryTimeStamp.Format(time.RFC3339), len(restart.StaleSessions))
+
+		for _, report := range newPeerRestartReports(restart) {
+			publishReport(report)
+		}
+	}
+}
+
+// newPeerRestartReports converts a peer restart into the reports streamed to the subscribers.
+// A report is generated for each stale session, or a single one with SEID 0 if there is none.
+func newPeerRestartReports(restart pfcpsim.PeerRestart) []*pb.SessionReport {
+	if len(restart.StaleSessions) == 0 {
+		return []*pb.SessionReport{{Type: reportTypePeerRestart}}
+	}
+
+	reports := make([]*pb.SessionReport, 0, len(restart.StaleSessions))
+
+	for _, sess := range restart.StaleSessions {
+		reports = append(reports, &pb.SessionReport{
+			Seid: sess.LocalSEID(),
+			Type: reportTypePeerRestart,
+		})
+	}
+
+	return reports
+}
+
+func dispatchN4PathEvents(events <-chan pfcpsim.N4PathEvent) {
+	for event := range events {
+		switch {
+		case event.Up:
+			log.Infof("N4 path recovered: association active again")
+		case event.Err != nil:
+			log.Warnf("N4 path failed with socket error %v: association inactive", event.Err)
+		default:
+			log.Warnf("N4 path failed after %v missed heartbeats: association inactive", event.MissedHeartbeats)
+		}
+
+		for _, report := range newN4PathReports(event) {
+			publishReport(report)
+		}
+	}
+}
+
+func dispatchReassociationAttempts(attempts <-chan pfcpsim.ReassociationAttempt) {
+	for attempt := range attempts {
+		report := &pb.SessionReport{
+			Type:    reportTypeReassociation,
+			Attempt: uint32(attempt.Attempt),
+		}
+
+		if attempt.Err != nil {
+			log.Warnf("Re-association attempt %v failed: %v. Retrying in %v", attempt.Attempt, attempt.Err, attempt.Backoff)
+
+			report.Type = reportTypeReassociationFailure
+		} else {
+			log.Infof("Association set up again after %v attempts", attempt.Attempt)
+		}
+
+		publishReport(report)
+	}
+}
+
+// newN4PathReports converts an N4 path event into the reports streamed to the subscribers.
+// A report is generated for each affected session, or a single one with SEID 0 if there is none.
+func newN4PathReports(event pfcpsim.N4PathEvent) []*pb.SessionReport {
+	reportType := reportTypePathFailure
+	if event.Up {
+		reportType = reportTypePathRecovery
+	}
+
+	if len(event.Sessions) == 0 {
+		return []*pb.SessionReport{{Type: reportType}}
+	}
+
+	reports := make([]*pb.SessionReport, 0, len(event.Sessions))
+
+	for _, sess := range event.Sessions {
+		reports = append(reports, &pb.SessionReport{
+			Seid: sess.LocalSEID(),
+			Type: reportType,
+		})
+	}
+
+	return reports
+}
+
+// deleteRemoteSession deletes the session on the remote peer identified by peer, see peerClient.
+// Stale sessions are skipped, since the peer lost them when it restarted.
+func deleteRemoteSession(ctx context.Context, peer string, sess *pfcpsim.PFCPSession) error {
+	if sess.IsStale() {
+		return nil
+	}
+
+	client, err := peerClient(peer)
+	if err != nil {
+		return err
+	}
+
+	return client.DeleteSessionWithContext(ctx, sess)
+}
+
+// isErrorIndication reports whether req carries an Error Indication Report.
+func isErrorIndication(req *message.SessionReportRequest) bool {
+	return req.ReportType != nil && req.ReportType.HasERIR()
+}
+
+// newSessionReports converts a Session Report Request into the reports streamed to the subscribers.
+// A report is generated for each Usage Report IE, for the Downlink Data Report IE and for an Error Indication Report.
+func newSessionReports(req *message.SessionReportRequest) []*pb.SessionReport {
+	var reports []*pb.SessionReport
+
+	if isErrorIndication(req) {
+		reports = append(reports, &pb.SessionReport{
+			Seid: req.SEID(),
+			Type: reportTypeErrorIndication,
+		})
+	}
+
+	if req.DownlinkDataReport != nil {
+		reports = append(reports, &pb.SessionReport{
+			Seid: req.SEID(),
+			Type: reportTypeDownlinkData,
+		})
+	}
+
+	for _, usageReport := range req.UsageReport {
+		report := &pb.SessionReport{
+			Seid: req.SEID(),
+			Type: reportTypeUsage,
+		}
+
+		if urrID, err := usageReport.URRID(); err == nil {
+			report.UrrID = urrID
+		}
+
+		if volume, err := usageReport.VolumeMeasurement(); err == nil {
+			report.TotalVolume = volume.TotalVolume
+			report.UplinkVolume = volume.UplinkVolume
+			report.DownlinkVolume = volume.DownlinkVolume
+		}
+
+		if duration, err := usageReport.DurationMeasurement(); err == nil {
+			report.Duration = uint32(duration.Seconds())
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// newUsageReports returns a "usage" report for each of the Usage Reports of the session with seid.
+func newUsageReports(seid uint64, usageReports []session.UsageReport) []*pb.SessionReport {
+	reports := make([]*pb.SessionReport, 0, len(usageReports))
+
+	for _, usageReport := range usageReports {
+		reports = append(reports, &pb.SessionReport{
+			Seid:           seid,
+			Type:           reportTypeUsage,
+			UrrID:          usageReport.URRID,
+			TotalVolume:    usageReport.TotalVolume,
+			UplinkVolume:   usageReport.UplinkVolume,
+			DownlinkVolume: usageReport.DownlinkVolume,
+			Duration:       uint32(usageReport.Duration.Seconds()),
+		})
+	}
+
+	return reports
+}
+
+func isConfigured() bool {
+	if upfN3Address != "" && remotePeerAddress != "" {
+		return true
+	}
+
+	return false
+}
+
+func isRemotePeerConnected() bool {
+	return atomic.LoadInt32(&remotePeerConnected) == 1
+}
+
+func setRemotePeerConnected(connected bool) {
+	var value int32
+	if connected {
+		value = 1
+	}
+
+	atomic.StoreInt32(&remotePeerConnected, value)
+}
+
+// isAssociated returns true if the association with the remote peer is set up. Unlike sim.IsAssociationAlive,
+// it is true also while the N4 path is failed, as the association is inactive only until the remote peer
+// answers a heartbeat again.
+func isAssociated() bool {
+	if !isRemotePeerConnected() || sim == nil {
+		return false
+	}
+
+	return sim.IsAssociationAlive() || !sim.IsN4PathUp()
+}
+
+// sweepIdleSessions periodically deletes the sessions that did not receive any usage report
+// within idleTimeout. It is meant to run in its own goroutine for the whole server lifetime.
+func sweepIdleSessions(idleTimeout time.Duration) {
+	ticker := time.NewTicker(idleTimeout / 2)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !isRemotePeerConnected() {
+			continue
+		}
+
+		deleteIdleSessions(idleTimeout)
+	}
+}
+
+// deleteIdleSessions deletes the sessions idle for longer than idleTimeout.
+// Returns the number of deleted sessions.
+func deleteIdleSessions(idleTimeout time.Duration) int {
+	deleted := 0
+
+	for index, sess := range getIdleSessions(idleTimeout) {
+		cancelSessionExpiry(index)
+
+		if err := deleteRemoteSession(context.Background(), sessionPeer(index), sess); err != nil {
+			log.Errorf("Could not delete idle session with index %v: %v", index, err)
+			continue
+		}
+
+		activeSessions.Delete(index)
+
+		deleted++
+	}
+
+	if deleted > 0 {
+		log.Infof("%v idle sessions deleted; activeSessions: %v", deleted, activeSessions.Len())
+	}
+
+	return deleted
+}
+
+// ueAddressPool hands out the addresses of a network, starting from the one following first.
+type ueAddressPool struct {
+	network *net.IPNet
+	last    net.IP
+}
+
+func newUEAddressPool(cidr string) (*ueAddressPool, error) {
+	first, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ueAddressPool{
+		network: network,
+		last:    first,
+	}, nil
+}
+
+// next returns the next address of the pool, or nil if the pool is exhausted.
+func (p *ueAddressPool) next() net.IP {
+	ip := iplib.NextIP(p.last)
+	if ip == nil || !p.network.Contains(ip) {
+		return nil
+	}
+
+	p.last = ip
+
+	return ip
+}
+
+// allocateUEAddresses returns count UE addresses taken from pools. If roundRobin is true, addresses are taken
+// from each pool in turn, otherwise every pool is exhausted before moving to the next one.
+// Returns error if a pool can't be parsed or if the pools don't provide enough addresses.
+func allocateUEAddresses(pools []string, count int, roundRobin bool) ([]net.IP, error) {
+	if len(pools) == 0 {
+		return nil, pfcpsim.NewInvalidFormatError("UE address pool. Please make sure it is not empty")
+	}
+
+	available := make([]*ueAddressPool, 0, len(pools))
+
+	for _, cidr := range pools {
+		pool, err := newUEAddressPool(cidr)
 		if err != nil {
+			return nil, pfcpsim.NewInvalidFormatError(fmt.Sprintf("UE address pool %v", cidr), err)
+		}
+
+		available = append(available, pool)
+	}
+
+	addresses := make([]net.IP, 0, count)
+
+	for current := 0; len(addresses) < count; {
+		if len(available) == 0 {
+			return nil, fmt.Errorf("%w: pools %v provide only %v addresses, %v needed",
+				errUEAddressesExhausted, pools, len(addresses), count)
+		}
+
+		ip := available[current].next()
+		if ip == nil {
+			// pool exhausted: drop it and keep on with the following one
+			available = append(available[:current], available[current+1:]...)
+		} else {
+			addresses = append(addresses, ip)
+
+			if roundRobin {
+				current++
+			}
+		}
+
+		if current >= len(available) {
+			current = 0
+		}
+	}
+
+	return addresses, nil
+}
+
+// allocateIPv6UEAddresses returns count UE addresses taken from the IPv6 pool.
+// Returns error if the pool is not an IPv6 prefix or doesn't provide enough addresses.
+func allocateIPv6UEAddresses(pool string, count int) ([]net.IP, error) {
+	if ip, _, err := net.ParseCIDR(pool); err == nil && ip.To4() != nil {
+		return nil, pfcpsim.NewInvalidFormatError(fmt.Sprintf("UE address pool %v. Please make sure it is an IPv6 prefix", pool))
+	}
+
+	return allocateUEAddresses([]string{pool}, count, false)
+}
+
+// addressAt returns the k-th address of addresses, or an empty string if addresses is empty.
+func addressAt(addresses []net.IP, k int) string {
+	if len(addresses) == 0 {
+		return ""
+	}
+
+	return addresses[k].String()
+}
+
+// getIdleSessions returns the active sessions whose last activity is older than timeout.
+func getIdleSessions(timeout time.Duration) map[int]*pfcpsim.PFCPSession {
+	idle := make(map[int]*pfcpsim.PFCPSession)
+
+	activeSessions.Range(func(index int, sess *pfcpsim.PFCPSession) bool {
+		if time.Since(sess.LastActivity()) > timeout {
+			idle[index] = sess
+		}
+
+		return true
+	})
+
+	return idle
+}
+
+// clearAllSessions deletes all the active sessions from the remote peer and empties activeSessions.
+// Returns the number of sessions deleted from the remote peer and the sorted base IDs of the ones that failed.
+func clearAllSessions() (deleted int, failed []int) {
+	activeSessions.Range(func(index int, sess *pfcpsim.PFCPSession) bool {
+		cancelSessionExpiry(index)
+
+		if err := deleteRemoteSession(context.Background(), sessionPeer(index), sess); err != nil {
+			log.Errorf("Could not delete session with baseID %v: %v", index, err)
+
+			failed = append(failed, index)
+		} else {
+			deleted++
+		}
+
+		activeSessions.Delete(index)
+
+		return true
+	})
+
+	sort.Ints(failed)
+
+	return deleted, failed
+}
+
+// deleteSessionSets deletes all the active sessions from the remote peers and empties activeSessions.
+// If csid is set, the sessions of each peer are deleted with a single Session Set Deletion Request.
+// The sessions left, because the peer rejected or ignored the request or because they were established
+// in another PDN connection set, are deleted one by one as clearAllSessions does.
+// Returns the number of sessions deleted from the remote peers and the sorted base IDs of the ones that failed.
+func deleteSessionSets(ctx context.Context) (deleted int, failed []int) {
+	if csid != 0 {
+		peers := make(map[string]struct{})
+
+		activeSessions.Range(func(index int, sess *pfcpsim.PFCPSession) bool {
+			peers[sessionPeer(index)] = struct{}{}
+
+			return true
+		})
+
+		for peer := range peers {
+			deleted += deleteRemoteSessionSet(ctx, peer)
+		}
+	}
+
+	clearedDeleted, failed := clearAllSessions()
+
+	return deleted + clearedDeleted, failed
+}
+
+// deleteRemoteSessionSet deletes the sessions of the configured PDN connection set from the remote peer,
+// and from activeSessions. Returns the number of sessions deleted, 0 if the peer did not accept the request.
+func deleteRemoteSessionSet(ctx context.Context, peer string) int {
+	client, err := peerClient(peer)
+	if err != nil {
+		log.Warnf("Could not delete session set: %v", err)
+		return 0
+	}
+
+	removed, err := client.DeleteSessionSet(ctx, csid)
+	if err != nil {
+		log.Warnf("Remote peer did not delete session set %v, deleting its sessions one by one: %v", csid, err)
+		return 0
+	}
+
+	isRemoved := make(map[*pfcpsim.PFCPSession]bool, len(removed))
+	for _, sess := range removed {
+		isRemoved[sess] = true
+	}
+
+	activeSessions.Range(func(index int, sess *pfcpsim.PFCPSession) bool {
+		if isRemoved[sess] {
+			cancelSessionExpiry(index)
+			activeSessions.Delete(index)
+		}
+
+		return true
+	})
+
+	return len(removed)
+}
+
+// bufferAllSessions modifies all the active sessions so that the remote peers buffer their downlink traffic
+// and notify it, as ModifySession does with the buffer and notify flags set. The sessions without downlink FARs
+// are left untouched. Returns the number of sessions modified and the sorted base IDs of the ones that failed,
+// because stale or rejected by the remote peer.
+func bufferAllSessions(ctx context.Context, packets, delayMs int32) (modified int, failed []int) {
+	activeSessions.Range(func(index int, sess *pfcpsim.PFCPSession) bool {
+		record, _ := activeSessions.Record(index)
+
+		var fars []*ie.IE
+
+		for _, rules := range record.AppRules {
+			if rules.DownlinkFARID == 0 {
+				continue
+			}
+
+			fars = append(fars, newDownlinkFAR(record, rules.DownlinkFARID, session.ActionNotify|session.ActionBuffer, 0, "", false))
+		}
+
+		if len(fars) == 0 {
+			return true
+		}
+
+		if err := modifyRemoteSession(ctx, record.Peer, sess, fars, newSessionBAR(sess, packets, delayMs)); err != nil {
+			log.Errorf("Could not buffer the downlink traffic of session with baseID %v: %v", index, err)
+
+			failed = append(failed, index)
+		} else {
+			modified++
+		}
+
+		return true
+	})
+
+	sort.Ints(failed)
+
+	return modified, failed
+}
+
+// modifyRemoteSession updates the FARs and the BAR of the session on the remote peer identified by peer,
+// see peerClient. Stale sessions can't be modified, since the peer lost them when it restarted.
+func modifyRemoteSession(ctx context.Context, peer string, sess *pfcpsim.PFCPSession, fars []*ie.IE, bar *ie.IE) error {
+	if sess.IsStale() {
+		return errSessionStale
+	}
+
+	client, err := peerClient(peer)
+	if err != nil {
+		return err
+	}
+
+	return client.ModifySessionWithContext(ctx, sess, nil, fars, nil, bar)
+}
+
+// newSessionBAR returns the BAR making the remote peer buffer the downlink traffic of sess. The BAR is created,
+// or updated if sess already has one. packets and delayMs are left to the remote peer if 0.
+func newSessionBAR(sess *pfcpsim.PFCPSession, packets, delayMs int32) *ie.IE {
+	barMethod := session.Create
+	if sess.HasBAR() {
+		barMethod = session.Update
+	}
+
+	barBuilder := session.NewBARBuilder().
+		WithID(sessionBARID).
+		WithMethod(barMethod)
+
+	if packets != 0 {
+		barBuilder.WithSuggestedBufferingPacketsCount(uint8(packets))
+	}
+
+	if delayMs != 0 {
+		barBuilder.WithDownlinkDataNotificationDelay(time.Duration(delayMs) * time.Millisecond)
+	}
+
+	return barBuilder.Build()
+}
+
+// newDownlinkFAR returns the update of the downlink FAR identified by farID of the session described by record.
+func newDownlinkFAR(record sessionRecord, farID uint32, actions uint8, teid uint32, nodeBAddress string, endMarker bool) *ie.IE {
+	return session.NewFARBuilder().
+		WithID(farID).
+		WithMethod(session.Update).
+		WithAction(actions).
+		WithDstInterface(pfcpDstInterface(record.DownlinkDstInterface, ie.DstInterfaceAccess)).
+		WithTEID(teid).
+		WithDownlinkIP(nodeBAddress).
+		WithEndMarker(endMarker).
+		WithBARID(sessionBARID).
+		BuildFAR()
+}
+
+// rollbackSessions deletes the active sessions identified by baseIDs, both from the remote peer and locally.
+// It is best-effort: sessions that can't be deleted from the remote peer are logged and dropped anyway.
+func rollbackSessions(baseIDs []int) {
+	for _, i := range baseIDs {
+		sess, ok := activeSessions.Get(i)
+		if !ok {
+			continue
+		}
+
+		if err := deleteRemoteSession(context.Background(), sessionPeer(i), sess); err != nil {
+			log.Errorf("Could not roll back session with baseID %v: %v", i, err)
+		}
+
+		activeSessions.Delete(i)
+	}
+
+	if len(baseIDs) > 0 {
+		log.Warnf("%v sessions rolled back; activeSessions: %v", len(baseIDs), activeSessions.Len())
+	}
+}
+
+// checkSessionsExist verifies that an active session exists for each of the count base IDs starting from baseID.
+// Returns error identifying the first missing base ID otherwise.
+func checkSessionsExist(baseID int, count int) error {
+	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
+		if _, ok := activeSessions.Get(i); !ok {
+			return pfcpsim.NewNotEnoughSessionsError(fmt.Errorf("%w with baseID %v", errSessionNotActive, i))
+		}
+	}
+
+	return nil
+}
+
+// checkSessionsNotExist verifies that no active session exists for the count base IDs starting from baseID.
+// Returns error listing all the conflicting base IDs otherwise.
+func checkSessionsNotExist(baseID int, count int) error {
+	var conflicts []int
+
+	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
+		if _, ok := activeSessions.Get(i); ok {
+			conflicts = append(conflicts, i)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%w with baseIDs %v", errSessionsActive, conflicts)
+	}
+
+	return nil
+}
+
+// contextAwareError returns a gRPC error with message msg. Its code matches the error of ctx if ctx is done,
+// e.g. because the client canceled the request, and is code otherwise.
+func contextAwareError(ctx context.Context, code codes.Code, msg string) error {
+	if err := ctx.Err(); err != nil {
+		code = status.FromContextError(err).Code()
+	}
+
+	return status.Error(code, msg)
+}
+
+func newFailedRule(rule pfcpsim.FailedRule) *pb.FailedRule {
+	return &pb.FailedRule{
+		Type: rule.TypeName(),
+		Id:   rule.ID,
+	}
+}
+
+// newFailedRules converts the failed rules reported by the remote peer to their protobuf messages.
+func newFailedRules(rules []pfcpsim.FailedRule) []*pb.FailedRule {
+	var failedRules []*pb.FailedRule
+
+	for _, rule := range rules {
+		failedRules = append(failedRules, newFailedRule(rule))
+	}
+
+	return failedRules
+}
+
+// rejectionError returns a gRPC error like contextAwareError. If the remote peer rejected the request,
+// the PFCP Cause of the first of errs carrying one is attached to the error as a pb.PFCPCause detail,
+// along with the failed rule and the offending IE reported with it.
+func rejectionError(ctx context.Context, code codes.Code, msg string, errs ...error) error {
+	err := contextAwareError(ctx, code, msg)
+
+	for _, e := range errs {
+		cause, ok := pfcpsim.RejectionCause(e)
+		if !ok {
+			continue
+		}
+
+		detail := &pb.PFCPCause{
+			Cause:       uint32(cause),
+			Description: pfcpsim.CauseDescription(cause),
+		}
+
+		if rule, ok := pfcpsim.RejectedRule(e); ok {
+			detail.FailedRule = newFailedRule(rule)
+		}
+
+		if offendingIE, ok := pfcpsim.OffendingIE(e); ok {
+			detail.OffendingIE = uint32(offendingIE)
+		}
+
+		withCause, detailErr := status.Convert(err).WithDetails(detail)
+		if detailErr != nil {
 			return err
 		}
 
-		sim = pfcpsim.NewPFCPClient(localAddr.String())
+		return withCause.Err()
 	}
 
-	err := sim.ConnectN4(remotePeerAddress)
-	if err != nil {
-		return err
+	return err
+}
+
+// orderedSessionErrors returns the errors of errs, ordered by position.
+func orderedSessionErrors(errs map[int]error) []error {
+	ordered := make([]error, 0, len(errs))
+	for _, k := range sortedPositions(errs) {
+		ordered = append(ordered, errs[k])
 	}
 
-	remotePeerConnected = true
+	return ordered
+}
+
+// runConcurrently calls f for each k in [0, n), running at most concurrency calls at the same time.
+// Returns the errors returned by f, indexed by k.
+func runConcurrently(n int, concurrency int, f func(k int) error) map[int]error {
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+	)
 
-	return nil
+	errs := make(map[int]error)
+	jobs := make(chan int)
+
+	for w := 0; w < concurrency && w < n; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for k := range jobs {
+				if err := f(k); err != nil {
+					lock.Lock()
+					errs[k] = err
+					lock.Unlock()
+				}
+			}
+		}()
+	}
+
+	for k := 0; k < n; k++ {
+		jobs <- k
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return errs
 }
 
-func isConfigured() bool {
-	if upfN3Address != "" && remotePeerAddress != "" {
-		return true
+// rateLimiter paces operations so that they start at most rate times per second.
+// It is safe for concurrent use; a nil rateLimiter does not limit anything.
+type rateLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	// next is the earliest start time of the next operation
+	next time.Time
+}
+
+// newRateLimiter returns a rateLimiter allowing rate operations per second, or nil if rate is not positive.
+func newRateLimiter(rate float64) *rateLimiter {
+	if rate <= 0 {
+		return nil
 	}
 
-	return false
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / rate)}
 }
 
-func isRemotePeerConnected() bool {
-	return remotePeerConnected
+// Wait blocks until the next operation can start, or until ctx is done.
+// The first operation starts immediately.
+func (r *rateLimiter) Wait(ctx context.Context) error {
+	if r == nil {
+		return ctx.Err()
+	}
+
+	r.lock.Lock()
+	now := time.Now()
+
+	start := r.next
+	if start.Before(now) {
+		start = now
+	}
+
+	r.next = start.Add(r.interval)
+	r.lock.Unlock()
+
+	timer := time.NewTimer(start.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// summarizeSessionErrors returns a description of errs, which are indexed by the position of the session
+// starting from baseID. Errors are listed in base ID order.
+func summarizeSessionErrors(baseID int, errs map[int]error) string {
+	summary := make([]string, 0, len(errs))
+	for _, k := range sortedPositions(errs) {
+		summary = append(summary, fmt.Sprintf("baseID %v: %v", baseID+k*SessionStep, errs[k]))
+	}
+
+	return strings.Join(summary, "; ")
+}
+
+// newFailedDeletions returns the failures reported by DeleteSession, in base ID order, for the errors of the
+// sessions not deleted. errs are indexed by the position of the sessions in the request starting from baseID.
+func newFailedDeletions(baseID int, errs map[int]error) []*pb.FailedDeletion {
+	failures := make([]*pb.FailedDeletion, 0, len(errs))
+
+	for _, k := range sortedPositions(errs) {
+		failure := &pb.FailedDeletion{
+			BaseID: int32(baseID + k*SessionStep),
+			Error:  errs[k].Error(),
+		}
+
+		if cause, ok := pfcpsim.RejectionCause(errs[k]); ok {
+			failure.Cause = uint32(cause)
+		}
+
+		failures = append(failures, failure)
+	}
+
+	return failures
+}
+
+// sortedPositions returns the positions errs are indexed by, in increasing order.
+func sortedPositions(errs map[int]error) []int {
+	positions := make([]int, 0, len(errs))
+	for k := range errs {
+		positions = append(positions, k)
+	}
+
+	sort.Ints(positions)
+
+	return positions
 }
 
 // isNumOfAppFiltersCorrect returns error if the number of the passed filter exceed the max number of supported application filters.
@@ -61,10 +1118,58 @@ func isNumOfAppFiltersCorrect(filters []string) error {
 	return nil
 }
 
+// newApplicationPFDs builds the Application ID's PFDs IE from its gRPC representation.
+// Returns error if the application ID or any of the PFDs is empty.
+func newApplicationPFDs(app *pb.ApplicationPFDs) (*ie.IE, error) {
+	if app.ApplicationID == "" {
+		return nil, pfcpsim.NewInvalidFormatError("Application ID. Please make sure it is not empty")
+	}
+
+	builder := session.NewPFDBuilder().WithApplicationID(app.ApplicationID)
+
+	for _, fd := range app.FlowDescriptions {
+		if fd == "" {
+			return nil, pfcpsim.NewInvalidFormatError("Flow description. Please make sure it is not empty")
+		}
+
+		builder.AddPFD(fd, "", "")
+	}
+
+	for _, url := range app.Urls {
+		if url == "" {
+			return nil, pfcpsim.NewInvalidFormatError("URL. Please make sure it is not empty")
+		}
+
+		builder.AddPFD("", url, "")
+	}
+
+	for _, domainName := range app.DomainNames {
+		if domainName == "" {
+			return nil, pfcpsim.NewInvalidFormatError("Domain name. Please make sure it is not empty")
+		}
+
+		builder.AddPFD("", "", domainName)
+	}
+
+	return builder.Build(), nil
+}
+
+// isIPv6Peer returns true if peerAddress, optionally followed by a port, is an IPv6 address.
+func isIPv6Peer(peerAddress string) bool {
+	if host, _, err := net.SplitHostPort(peerAddress); err == nil {
+		peerAddress = host
+	}
+
+	ip := net.ParseIP(peerAddress)
+
+	return ip != nil && ip.To4() == nil
+}
+
 // getLocalAddress returns the first IP address of the interfaceName, if specified,
-// otherwise returns the IP address of the first non-loopback interface
+// otherwise returns the IP address of the first non-loopback interface.
+// An IPv6 global unicast address is returned if ipv6 is true, an IPv4 address otherwise.
 // Returns error if fail occurs at any stage.
-func getLocalAddress(interfaceName string) (net.IP, error) {
+func getLocalAddress(interfaceName string, ipv6 bool) (net.IP, error) {
 	addrs, err := net.InterfaceAddrs()
 	if err != nil {
 		return nil, err
@@ -83,7 +1188,11 @@ func getLocalAddress(interfaceName string) (net.IP, error) {
 	for _, address := range addrs {
 		// Check address type to be non-loopback
 		if ipnet, ok := address.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
-			if ipnet.IP.To4() != nil {
+			if !ipv6 && ipnet.IP.To4() != nil {
+				return ipnet.IP, nil
+			}
+
+			if ipv6 && ipnet.IP.To4() == nil && ipnet.IP.IsGlobalUnicast() {
 				return ipnet.IP, nil
 			}
 		}
@@ -92,21 +1201,50 @@ func getLocalAddress(interfaceName string) (net.IP, error) {
 	return nil, pfcpsim.NewNoValidInterfaceError()
 }
 
-// parseAppFilter parses an application filter. Returns a tuple formed by a formatted SDF filter
-// and a uint8 representing the Application QER gate status and a precedence. Returns error if fail occurs while validating the filter string.
-func parseAppFilter(filter string) (string, uint8, uint32, error) {
+// parseAppFilter parses an application filter. Returns a tuple formed by a formatted SDF filter,
+// a uint8 representing the Application QER gate status, a precedence and the QFI of the Application QERs.
+// Returns error if fail occurs while validating the filter string.
+// The precedence token is optional: defaultAppFilterPrecedence is used if it is omitted.
+// The QFI token is optional too and can only follow the precedence: 0 is returned if it is omitted.
+// The MBR tokens can only follow the QFI, see parseAppFilterMBRs.
+func parseAppFilter(filter string) (string, uint8, uint32, uint8, error) {
 	if filter == "" {
 		// parsing a wildcard app filter
-		return "", ie.GateStatusOpen, 100, nil
+		return "", ie.GateStatusOpen, defaultAppFilterPrecedence, 0, nil
 	}
 
-	result := strings.Split(filter, ":")
-	if len(result) != 5 {
-		return "", 0, 0, pfcpsim.NewInvalidFormatError("Parser was not able to generate the correct number of arguments." +
+	result := splitAppFilter(filter)
+	if len(result) < 4 || len(result) == 7 || len(result) > 8 {
+		return "", 0, 0, 0, pfcpsim.NewInvalidFormatError("Parser was not able to generate the correct number of arguments." +
 			" Please make sure to use the right format")
 	}
 
-	proto, ipNetAddr, portRange, action, precedence := result[0], result[1], result[2], result[3], result[4]
+	proto, ipNetAddr, portRange, action := result[0], result[1], result[2], result[3]
+
+	precedence := strconv.Itoa(defaultAppFilterPrecedence)
+	if len(result) >= 5 {
+		precedence = result[4]
+	}
+
+	var qfi uint8
+
+	if len(result) >= 6 {
+		// QFI is a 6-bit value
+		q, err := strconv.ParseUint(result[5], 10, 6)
+		if err != nil {
+			return "", 0, 0, 0, pfcpsim.NewInvalidFormatError("QFI. Please make sure it is a number between 1 and 63", err)
+		}
+
+		if q == 0 {
+			return "", 0, 0, 0, pfcpsim.NewInvalidFormatError("QFI. Please make sure it is a number between 1 and 63")
+		}
+
+		qfi = uint8(q)
+	}
+
+	if _, _, _, err := parseAppFilterMBRs(filter); err != nil {
+		return "", 0, 0, 0, err
+	}
 
 	var gateStatus uint8
 	switch action {
@@ -115,16 +1253,17 @@ func parseAppFilter(filter string) (string, uint8, uint32, error) {
 	case "deny":
 		gateStatus = ie.GateStatusClosed
 	default:
-		return "", 0, 0, pfcpsim.NewInvalidFormatError("Action. Please make sure to use 'allow' or 'deny'")
+		return "", 0, 0, 0, pfcpsim.NewInvalidFormatError("Action. Please make sure to use 'allow' or 'deny'")
 	}
 
-	if !(proto == "ip" || proto == "udp" || proto == "tcp") {
-		return "", 0, 0, pfcpsim.NewInvalidFormatError("Unsupported or unknown protocol.")
+	proto, err := parseAppFilterProtocol(proto)
+	if err != nil {
+		return "", 0, 0, 0, err
 	}
 
 	precedenceConverted, err := strconv.Atoi(precedence)
 	if err != nil {
-		return "", 0, 0, pfcpsim.NewInvalidFormatError("Precedence. Please make sure it is a number", err)
+		return "", 0, 0, 0, pfcpsim.NewInvalidFormatError("Precedence. Please make sure it is a number", err)
 	}
 
 	precedenceUint := uint32(precedenceConverted)
@@ -132,31 +1271,462 @@ func parseAppFilter(filter string) (string, uint8, uint32, error) {
 	if ipNetAddr != "any" {
 		_, _, err := net.ParseCIDR(ipNetAddr)
 		if err != nil {
-			return "", 0, 0, pfcpsim.NewInvalidFormatError("IP and subnet mask.", err)
+			return "", 0, 0, 0, pfcpsim.NewInvalidFormatError("IP and subnet mask.", err)
 		}
 	}
 
+	sdfFilter := fmt.Sprintf(sdfFilterFormatWOPort, proto, ipNetAddr)
+
 	if portRange != "any" {
-		portList := strings.Split(portRange, "-")
-		if !(len(portList) == 2) {
-			return "", 0, 0, pfcpsim.NewInvalidFormatError("Port range. Please make sure to use dash '-' to separate the two ports")
+		ports, err := parsePortRange(portRange)
+		if err != nil {
+			return "", 0, 0, 0, err
+		}
+
+		sdfFilter = fmt.Sprintf(sdfFilterFormatWPort, proto, ipNetAddr, ports)
+	}
+
+	if err := validateSDFFilter(sdfFilter); err != nil {
+		return "", 0, 0, 0, err
+	}
+
+	return sdfFilter, gateStatus, precedenceUint, qfi, nil
+}
+
+// parseAppFilterMBRs returns the uplink and downlink MBRs, in kbps, of the application QERs of an application filter.
+// The MBR tokens are optional and come in pairs after the QFI, e.g. 'udp:10.0.0.0/8:80:allow:100:9:5000:20000'.
+// Returns false if they are omitted: the QERs get the MBRs of the request then.
+func parseAppFilterMBRs(filter string) (uint64, uint64, bool, error) {
+	result := splitAppFilter(filter)
+	if len(result) < 8 {
+		return 0, 0, false, nil
+	}
+
+	ulMbr, err := strconv.ParseUint(result[6], 10, 32)
+	if err != nil {
+		return 0, 0, false, pfcpsim.NewInvalidFormatError("Uplink MBR. Please make sure it is a non-negative number", err)
+	}
+
+	dlMbr, err := strconv.ParseUint(result[7], 10, 32)
+	if err != nil {
+		return 0, 0, false, pfcpsim.NewInvalidFormatError("Downlink MBR. Please make sure it is a non-negative number", err)
+	}
+
+	return ulMbr, dlMbr, true, nil
+}
+
+// parseAppFilterProtocol returns the SDF filter protocol for the protocol token of an application filter.
+// The token is either a protocol name ('ip', 'udp' or 'tcp'), the 'any' wildcard, which maps to 'ip',
+// or an IP protocol number between 0 and 255, e.g. 132 for SCTP.
+func parseAppFilterProtocol(proto string) (string, error) {
+	switch proto {
+	case "ip", "udp", "tcp":
+		return proto, nil
+	case "any":
+		return "ip", nil
+	}
+
+	number, err := strconv.ParseUint(proto, 10, 8)
+	if err != nil {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("protocol %q. "+
+			"Please make sure to use 'ip', 'udp', 'tcp', 'any' or a number between 0 and 255", proto), err)
+	}
+
+	return strconv.FormatUint(number, 10), nil
+}
+
+// validateSDFFilter checks that filter is a well-formed 'permit out <proto> from <address> [ports] to <address> [ports]'
+// SDF filter description, where an address is either 'any', 'assigned' or an IP prefix.
+// Returns an error describing the first malformed token, if any.
+func validateSDFFilter(filter string) error {
+	tokens := strings.Fields(filter)
+	if len(tokens) < 7 || tokens[0] != "permit" || tokens[1] != "out" {
+		return pfcpsim.NewInvalidFormatError(fmt.Sprintf("SDF filter %q. It must start with 'permit out'", filter))
+	}
+
+	if err := validateSDFProtocol(tokens[2]); err != nil {
+		return err
+	}
+
+	if tokens[3] != "from" {
+		return pfcpsim.NewInvalidFormatError(fmt.Sprintf("SDF filter %q. Expected 'from' after the protocol, got %q", filter, tokens[3]))
+	}
+
+	to := -1
+
+	for i := 4; i < len(tokens); i++ {
+		if tokens[i] == "to" {
+			to = i
+			break
+		}
+	}
+
+	if to < 0 {
+		return pfcpsim.NewInvalidFormatError(fmt.Sprintf("SDF filter %q. Missing 'to'", filter))
+	}
+
+	if err := validateSDFEndpoint(tokens[4:to]); err != nil {
+		return err
+	}
+
+	return validateSDFEndpoint(tokens[to+1:])
+}
+
+// validateSDFProtocol returns error if proto is neither a supported protocol name nor an IP protocol number.
+func validateSDFProtocol(proto string) error {
+	if proto == "ip" || proto == "udp" || proto == "tcp" {
+		return nil
+	}
+
+	if _, err := strconv.ParseUint(proto, 10, 8); err != nil {
+		return pfcpsim.NewInvalidFormatError(fmt.Sprintf("SDF filter protocol %q. "+
+			"Please make sure to use 'ip', 'udp', 'tcp' or a number between 0 and 255", proto), err)
+	}
+
+	return nil
+}
+
+// validateSDFEndpoint returns error if tokens are not an address, optionally followed by a port or a port range.
+func validateSDFEndpoint(tokens []string) error {
+	if len(tokens) == 0 || len(tokens) > 2 {
+		return pfcpsim.NewInvalidFormatError(fmt.Sprintf("SDF filter endpoint %q. "+
+			"Please make sure to specify an address optionally followed by ports", strings.Join(tokens, " ")))
+	}
+
+	if address := tokens[0]; address != "any" && address != "assigned" {
+		if _, _, err := net.ParseCIDR(address); err != nil {
+			return pfcpsim.NewInvalidFormatError(fmt.Sprintf("SDF filter address %q", address), err)
+		}
+	}
+
+	if len(tokens) == 2 {
+		if _, err := parsePortRange(tokens[1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reverseSDFFilter returns the SDF filter description matching the traffic in the opposite direction of filter,
+// by swapping its source and destination, ports included.
+// E.g. 'permit out udp from 10.0.0.0/8 to assigned 80' becomes 'permit out udp from assigned 80 to 10.0.0.0/8'.
+func reverseSDFFilter(filter string) (string, error) {
+	tokens := strings.Fields(filter)
+
+	from, to := -1, -1
+
+	for i, token := range tokens {
+		switch {
+		case token == "from" && from < 0:
+			from = i
+		case token == "to" && from >= 0 && to < 0:
+			to = i
+		}
+	}
+
+	if from < 0 || to < 0 || to == from+1 || to == len(tokens)-1 {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("SDF filter %v", filter))
+	}
+
+	reversed := append([]string{}, tokens[:from+1]...)
+	reversed = append(reversed, tokens[to+1:]...)
+	reversed = append(reversed, "to")
+	reversed = append(reversed, tokens[from+1:to]...)
+
+	return strings.Join(reversed, " "), nil
+}
+
+// pfcpPDNType returns the value of the PDN Type IE matching pdnType.
+func pfcpPDNType(pdnType pb.PdnType) uint8 {
+	switch pdnType {
+	case pb.PdnType_IPV6:
+		return ie.PDNTypeIPv6
+	case pb.PdnType_IPV4V6:
+		return ie.PDNTypeIPv4v6
+	default:
+		return ie.PDNTypeIPv4
+	}
+}
+
+// pfcpDstInterface returns the value of the Destination Interface IE matching iface,
+// defaultIface if iface is DESTINATION_DEFAULT.
+func pfcpDstInterface(iface pb.DestinationInterface, defaultIface uint8) uint8 {
+	switch iface {
+	case pb.DestinationInterface_DESTINATION_ACCESS:
+		return ie.DstInterfaceAccess
+	case pb.DestinationInterface_DESTINATION_CORE:
+		return ie.DstInterfaceCore
+	case pb.DestinationInterface_DESTINATION_SGI_LAN:
+		return ie.DstInterfaceSGiLANN6LAN
+	case pb.DestinationInterface_DESTINATION_CP_FUNCTION:
+		return ie.DstInterfaceCPFunction
+	case pb.DestinationInterface_DESTINATION_LI_FUNCTION:
+		return ie.DstInterfaceLIFunction
+	default:
+		return defaultIface
+	}
+}
+
+// checkSessionGBR returns error if the session GBRs ulGbr and dlGbr can't be enforced by the session QER,
+// whose MBRs are ulAmbr and dlAmbr.
+func checkSessionGBR(ulGbr, dlGbr, ulAmbr, dlAmbr int32, skipSessionQER bool) error {
+	if ulGbr == 0 && dlGbr == 0 {
+		return nil
+	}
+
+	if ulGbr < 0 || dlGbr < 0 {
+		return fmt.Errorf("%w: they must not be negative, got UL %v DL %v", errInvalidSessionGBR, ulGbr, dlGbr)
+	}
+
+	if skipSessionQER || (ulAmbr == 0 && dlAmbr == 0) {
+		return fmt.Errorf("%w: they require the session QER, which is created only if the session AMBRs are set",
+			errInvalidSessionGBR)
+	}
+
+	if ulGbr > ulAmbr || dlGbr > dlAmbr {
+		return fmt.Errorf("%w: UL %v DL %v must not exceed the session AMBRs UL %v DL %v",
+			errInvalidSessionGBR, ulGbr, dlGbr, ulAmbr, dlAmbr)
+	}
+
+	return nil
+}
+
+// newAppQER returns a Create QER IE for an application QER. The QER limits the bitrate only if ulMbr or dlMbr,
+// in kbps, is not 0.
+func newAppQER(id uint32, qfi, gateStatus uint8, ulMbr, dlMbr uint64) *ie.IE {
+	qer := session.NewQERBuilder().
+		WithID(id).
+		WithMethod(session.Create).
+		WithQFI(qfi).
+		WithGateStatus(gateStatus)
+
+	if ulMbr != 0 || dlMbr != 0 {
+		qer.WithUplinkMBR(ulMbr).WithDownlinkMBR(dlMbr)
+	}
+
+	return qer.Build()
+}
+
+// pdrQERIDs returns the IDs of the QERs applied by a PDR, in the order they are sent: the session QER, unless skipped,
+// precedes the application QER, unless appQERFirst is set.
+func pdrQERIDs(sessQerID, appQerID uint32, skipSessionQER, appQERFirst bool) []uint32 {
+	switch {
+	case skipSessionQER:
+		return []uint32{appQerID}
+	case appQERFirst:
+		return []uint32{appQerID, sessQerID}
+	default:
+		return []uint32{sessQerID, appQerID}
+	}
+}
+
+// hasExplicitPrecedence returns true if the application filter specifies the precedence of its PDRs.
+func hasExplicitPrecedence(filter string) bool {
+	return len(splitAppFilter(filter)) >= 5
+}
+
+// autoPrecedence returns the precedence that order assigns to the k-th application filter.
+func autoPrecedence(order pb.PrecedenceOrder, k int) uint32 {
+	switch order {
+	case pb.PrecedenceOrder_PRECEDENCE_INCREASING:
+		return defaultAppFilterPrecedence + uint32(k)
+	case pb.PrecedenceOrder_PRECEDENCE_DECREASING:
+		return defaultAppFilterPrecedence - uint32(k)
+	default:
+		return defaultAppFilterPrecedence
+	}
+}
+
+// newUpdatePDR returns an Update PDR IE for the PDR with pdrID among pdrs, the PDRs sess was created with, setting
+// sdfFilter and precedence in place of the current ones unless empty or 0. The rest of the PDR is left unchanged.
+// It also returns a copy of pdrs where the PDR is replaced by its updated version, to re-establish sess with.
+func newUpdatePDR(sess *pfcpsim.PFCPSession, pdrs []*ie.IE, pdrID uint16, sdfFilter string, precedence uint32) (*ie.IE, []*ie.IE, error) {
+	for k, pdr := range pdrs {
+		if id, err := pdr.PDRID(); err != nil || id != pdrID {
+			continue
 		}
 
-		lowerPort, err := strconv.Atoi(portList[0])
+		farID, err := pdr.FARID()
 		if err != nil {
-			return "", 0, 0, pfcpsim.NewInvalidFormatError("Port range.", err)
+			return nil, nil, err
+		}
+
+		if precedence == 0 {
+			if precedence, err = pdr.Precedence(); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		builder := session.NewPDRBuilder().
+			WithID(pdrID).
+			WithFARID(farID).
+			WithPrecedence(precedence)
+
+		// only uplink PDRs match an F-TEID
+		uplink := false
+
+		for _, child := range pdr.ChildIEs {
+			switch child.Type {
+			case ie.QERID:
+				qerID, err := child.QERID()
+				if err != nil {
+					return nil, nil, err
+				}
+
+				builder.AddQERID(qerID)
+			case ie.PDI:
+				for _, pdiChild := range child.ChildIEs {
+					switch pdiChild.Type {
+					case ie.SourceInterface:
+						iface, err := pdiChild.SourceInterface()
+						if err != nil {
+							return nil, nil, err
+						}
+
+						builder.WithSourceInterface(iface)
+					case ie.FTEID:
+						fteid, err := pdiChild.FTEID()
+						if err != nil {
+							return nil, nil, err
+						}
+
+						// the F-TEIDs chosen by the remote peer replace the requests to choose them
+						if allocated, ok := sess.AllocatedFTEID(pdrID); ok {
+							fteid = allocated
+						}
+
+						if fteid.TEID == 0 {
+							return nil, nil, fmt.Errorf("%w for PDR %v", errFTEIDUnknown, pdrID)
+						}
+
+						n3Address := fteid.IPv4Address
+						if n3Address == nil {
+							n3Address = fteid.IPv6Address
+						}
+
+						builder.WithTEID(fteid.TEID).WithN3Address(n3Address.String())
+
+						uplink = true
+					case ie.UEIPAddress:
+						ueAddress, err := pdiChild.UEIPAddress()
+						if err != nil {
+							return nil, nil, err
+						}
+
+						if ueAddress.IPv4Address != nil {
+							builder.WithUEAddress(ueAddress.IPv4Address.String())
+						}
+
+						if ueAddress.IPv6Address != nil {
+							builder.WithUEIPv6Address(ueAddress.IPv6Address.String())
+						}
+					case ie.SDFFilter:
+						if sdfFilter != "" {
+							continue
+						}
+
+						current, err := pdiChild.SDFFilter()
+						if err != nil {
+							return nil, nil, err
+						}
+
+						sdfFilter = current.FlowDescription
+					}
+				}
+			}
+		}
+
+		builder.WithSDFFilter(sdfFilter, false)
+
+		if uplink {
+			builder.MarkAsUplink()
+		} else {
+			builder.MarkAsDownlink()
 		}
 
-		upperPort, err := strconv.Atoi(portList[1])
+		updated := append([]*ie.IE(nil), pdrs...)
+		updated[k] = builder.WithMethod(session.Create).BuildPDR()
+
+		return builder.WithMethod(session.Update).BuildPDR(), updated, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w with ID %v", errPDRNotFound, pdrID)
+}
+
+// splitAppFilter splits an application filter into its tokens.
+// IPv6 prefixes must be enclosed in square brackets, e.g. 'ip:[2001:db8::/32]:any:allow:100'.
+// Returns nil if the brackets are malformed.
+func splitAppFilter(filter string) []string {
+	tokens := strings.SplitN(filter, ":", 2)
+	if len(tokens) < 2 || !strings.HasPrefix(tokens[1], "[") {
+		return strings.Split(filter, ":")
+	}
+
+	end := strings.Index(tokens[1], "]")
+	if end < 0 {
+		return nil
+	}
+
+	proto, prefix, rest := tokens[0], tokens[1][1:end], tokens[1][end+1:]
+	if !strings.HasPrefix(rest, ":") {
+		return nil
+	}
+
+	return append([]string{proto, prefix}, strings.Split(rest[1:], ":")...)
+}
+
+// checkAppFiltersIPVersion verifies that the IP prefixes of appFilters have the same IP version of ueAddresses,
+// which are the destination of the SDF filters. Filters that can't be parsed are ignored.
+func checkAppFiltersIPVersion(appFilters []string, ueAddresses []net.IP) error {
+	for _, appFilter := range appFilters {
+		tokens := splitAppFilter(appFilter)
+		if len(tokens) < 2 || tokens[1] == "any" {
+			continue
+		}
+
+		prefix, _, err := net.ParseCIDR(tokens[1])
 		if err != nil {
-			return "", 0, 0, pfcpsim.NewInvalidFormatError("Port range.", err)
+			continue
+		}
+
+		for _, ueAddress := range ueAddresses {
+			if (prefix.To4() == nil) != (ueAddress.To4() == nil) {
+				return pfcpsim.NewInvalidFormatError(fmt.Sprintf(
+					"Application filter %v and UE address %v have different IP versions", appFilter, ueAddress))
+			}
 		}
+	}
+
+	return nil
+}
+
+// parsePortRange parses either a single port (e.g. '80') or a port range (e.g. '8080-8090')
+// and returns it formatted as in a SDF filter description.
+func parsePortRange(portRange string) (string, error) {
+	portList := strings.Split(portRange, "-")
+	if len(portList) > 2 {
+		return "", pfcpsim.NewInvalidFormatError("Port range. Please make sure to use dash '-' to separate the two ports")
+	}
 
-		if lowerPort > upperPort {
-			return "", 0, 0, pfcpsim.NewInvalidFormatError("Port range. Lower port is greater than upper port")
+	ports := make([]uint64, 0, len(portList))
+
+	for _, p := range portList {
+		port, err := strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			return "", pfcpsim.NewInvalidFormatError("Port range. Ports must be numbers between 0 and 65535", err)
 		}
-		return fmt.Sprintf(sdfFilterFormatWPort, proto, ipNetAddr, lowerPort, upperPort), gateStatus, precedenceUint, nil
-	} else {
-		return fmt.Sprintf(sdfFilterFormatWOPort, proto, ipNetAddr), gateStatus, precedenceUint, nil
+
+		ports = append(ports, port)
+	}
+
+	if len(ports) == 1 {
+		return strconv.FormatUint(ports[0], 10), nil
+	}
+
+	if ports[0] > ports[1] {
+		return "", pfcpsim.NewInvalidFormatError(fmt.Sprintf("Port range. Lower port %v is greater than upper port %v", ports[0], ports[1]))
 	}
+
+	return fmt.Sprintf("%v-%v", ports[0], ports[1]), nil
 }
@@ -4,12 +4,65 @@
 package pfcpsim
 
 import (
+	"net"
 	"testing"
+	"time"
 
+	"github.com/ardzoht/pfcpsim/internal/fakeupf"
 	"github.com/stretchr/testify/require"
 	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
 )
 
+// setupAssociation connects the global sim to a new FakeUPF and sets up the association.
+// Global state is restored when the test completes.
+func setupAssociation(t testing.TB) *fakeupf.FakeUPF {
+	upf, err := fakeupf.New()
+	require.NoError(t, err)
+
+	remotePeerAddress = upf.Addr()
+	upfN3Address = "198.18.0.1"
+	sim = newSim("127.0.0.1")
+
+	require.NoError(t, connectPFCPSim())
+	require.NoError(t, sim.SetupAssociation())
+
+	t.Cleanup(func() {
+		sim.DisconnectN4()
+		upf.Close()
+
+		sim = nil
+		setRemotePeerConnected(false)
+		remotePeerAddress = ""
+		upfN3Address = ""
+		activeSessions = newSessionStore()
+	})
+
+	return upf
+}
+
+func Test_isIPv6Peer(t *testing.T) {
+	require.False(t, isIPv6Peer("10.0.0.1"))
+	require.False(t, isIPv6Peer("10.0.0.1:8805"))
+	require.True(t, isIPv6Peer("2001:db8::1"))
+	require.True(t, isIPv6Peer("[2001:db8::1]:8805"))
+	require.False(t, isIPv6Peer("upf.local"))
+}
+
+func Test_validateRemotePeerAddress(t *testing.T) {
+	for _, address := range []string{
+		"10.0.0.1", "10.0.0.1:8805", "2001:db8::1", "[2001:db8::1]", "[2001:db8::1]:8805", "upf", "upf.local:8806",
+	} {
+		require.NoError(t, validateRemotePeerAddress(address), address)
+	}
+
+	for _, address := range []string{
+		"", "upf_1", "-upf.local", "upf..local", "10.0.0.1:", "10.0.0.1:0", "10.0.0.1:65536", "10.0.0.1:port", "[2001:db8::1]:x",
+	} {
+		require.Error(t, validateRemotePeerAddress(address), address)
+	}
+}
+
 func Test_parseAppFilter(t *testing.T) {
 	type args struct {
 		filterString string
@@ -19,6 +72,7 @@ func Test_parseAppFilter(t *testing.T) {
 		SDFFilter  string
 		gateStatus uint8
 		precedence uint32
+		qfi        uint8
 	}
 
 	tests := []struct {
@@ -37,6 +91,32 @@ func Test_parseAppFilter(t *testing.T) {
 				precedence: 100,
 			},
 		},
+		{name: "Correct app filter without precedence",
+			args: &args{
+				filterString: "udp:10.0.0.0/8:80-80:allow",
+			},
+			want: &want{
+				SDFFilter:  "permit out udp from 10.0.0.0/8 to assigned 80-80",
+				gateStatus: ie.GateStatusOpen,
+				precedence: defaultAppFilterPrecedence,
+			},
+		},
+		{name: "Correct app filter with explicit precedence",
+			args: &args{
+				filterString: "ip:any:any:allow:50",
+			},
+			want: &want{
+				SDFFilter:  "permit out ip from any to assigned",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 50,
+			},
+		},
+		{name: "Incorrect app filter with empty precedence",
+			args: &args{
+				filterString: "ip:any:any:allow:",
+			},
+			wantErr: true,
+		},
 		{name: "Correct app filter with deny",
 			args: &args{
 				filterString: "udp:10.0.0.0/8:80-80:deny:101",
@@ -87,6 +167,138 @@ func Test_parseAppFilter(t *testing.T) {
 				precedence: 103,
 			},
 		},
+		{name: "Correct app filter with single port",
+			args: &args{
+				filterString: "tcp:10.0.0.0/8:443:allow:100",
+			},
+			want: &want{
+				SDFFilter:  "permit out tcp from 10.0.0.0/8 to assigned 443",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 100,
+			},
+		},
+		{name: "Correct app filter with port range",
+			args: &args{
+				filterString: "udp:10.0.0.0/8:8080-8090:allow:100",
+			},
+			want: &want{
+				SDFFilter:  "permit out udp from 10.0.0.0/8 to assigned 8080-8090",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 100,
+			},
+		},
+		{name: "incorrect app filter inverted port range",
+			args: &args{
+				filterString: "udp:10.0.0.0/8:8090-8080:allow:100",
+			},
+			wantErr: true,
+		},
+		{name: "incorrect app filter port out of range",
+			args: &args{
+				filterString: "udp:10.0.0.0/8:80-65536:allow:100",
+			},
+			wantErr: true,
+		},
+		{name: "incorrect app filter port range with too many ports",
+			args: &args{
+				filterString: "udp:10.0.0.0/8:80-81-82:allow:100",
+			},
+			wantErr: true,
+		},
+		{name: "Correct app filter with IPv6 prefix",
+			args: &args{
+				filterString: "udp:[2001:db8::/32]:80-88:allow:100",
+			},
+			want: &want{
+				SDFFilter:  "permit out udp from 2001:db8::/32 to assigned 80-88",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 100,
+			},
+		},
+		{name: "Correct app filter with IPv6 prefix without precedence",
+			args: &args{
+				filterString: "ip:[::/0]:any:deny",
+			},
+			want: &want{
+				SDFFilter:  "permit out ip from ::/0 to assigned",
+				gateStatus: ie.GateStatusClosed,
+				precedence: defaultAppFilterPrecedence,
+			},
+		},
+		{name: "incorrect app filter IPv6 prefix without brackets",
+			args: &args{
+				filterString: "ip:2001:db8::/32:any:allow:100",
+			},
+			wantErr: true,
+		},
+		{name: "incorrect app filter IPv6 prefix with unterminated bracket",
+			args: &args{
+				filterString: "ip:[2001:db8::/32:any:allow:100",
+			},
+			wantErr: true,
+		},
+		{name: "Correct app filter with protocol number",
+			args: &args{
+				filterString: "132:10.0.0.0/8:any:allow:100",
+			},
+			want: &want{
+				SDFFilter:  "permit out 132 from 10.0.0.0/8 to assigned",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 100,
+			},
+		},
+		{name: "Correct app filter with protocol number and ports",
+			args: &args{
+				filterString: "17:10.0.0.0/8:80-88:allow:100",
+			},
+			want: &want{
+				SDFFilter:  "permit out 17 from 10.0.0.0/8 to assigned 80-88",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 100,
+			},
+		},
+		{name: "Correct app filter with lowest protocol number",
+			args: &args{
+				filterString: "0:any:any:allow:100",
+			},
+			want: &want{
+				SDFFilter:  "permit out 0 from any to assigned",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 100,
+			},
+		},
+		{name: "Correct app filter with highest protocol number",
+			args: &args{
+				filterString: "255:any:any:deny:100",
+			},
+			want: &want{
+				SDFFilter:  "permit out 255 from any to assigned",
+				gateStatus: ie.GateStatusClosed,
+				precedence: 100,
+			},
+		},
+		{name: "Correct app filter with wildcard protocol",
+			args: &args{
+				filterString: "any:10.0.0.0/8:any:allow:100",
+			},
+			want: &want{
+				SDFFilter:  "permit out ip from 10.0.0.0/8 to assigned",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 100,
+			},
+		},
+		{name: "incorrect app filter protocol number out of range",
+			args: &args{
+				filterString: "256:10.0.0.0/8:any:allow:100",
+			},
+			wantErr: true,
+		},
+		{name: "incorrect app filter negative protocol number",
+			args: &args{
+				filterString: "-1:10.0.0.0/8:any:allow:100",
+			},
+			wantErr: true,
+		},
 		{name: "incorrect app filter bad protocol",
 			args: &args{
 				filterString: "test:10.0.0.0/8:80-80:allow",
@@ -101,13 +313,82 @@ func Test_parseAppFilter(t *testing.T) {
 			want:    &want{},
 			wantErr: true,
 		},
-		{name: "incorrect app filter missing precedence",
+		{name: "incorrect app filter bad IP format without precedence",
 			args: &args{
 				filterString: "ip:10/8:80-80:allow",
 			},
 			want:    &want{},
 			wantErr: true,
 		},
+		{name: "Correct app filter with QFI",
+			args: &args{
+				filterString: "udp:10.0.0.0/8:80:allow:100:9",
+			},
+			want: &want{
+				SDFFilter:  "permit out udp from 10.0.0.0/8 to assigned 80",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 100,
+				qfi:        9,
+			},
+		},
+		{name: "Correct app filter with IPv6 prefix and QFI",
+			args: &args{
+				filterString: "ip:[2001:db8::/32]:any:allow:100:5",
+			},
+			want: &want{
+				SDFFilter:  "permit out ip from 2001:db8::/32 to assigned",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 100,
+				qfi:        5,
+			},
+		},
+		{name: "incorrect app filter QFI out of range",
+			args: &args{
+				filterString: "ip:10.0.0.0/8:any:allow:100:64",
+			},
+			wantErr: true,
+		},
+		{name: "incorrect app filter zero QFI",
+			args: &args{
+				filterString: "ip:10.0.0.0/8:any:allow:100:0",
+			},
+			wantErr: true,
+		},
+		{name: "Correct app filter with MBRs",
+			args: &args{
+				filterString: "udp:10.0.0.0/8:80:allow:100:9:5000:20000",
+			},
+			want: &want{
+				SDFFilter:  "permit out udp from 10.0.0.0/8 to assigned 80",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 100,
+				qfi:        9,
+			},
+		},
+		{name: "incorrect app filter with uplink MBR only",
+			args: &args{
+				filterString: "ip:10.0.0.0/8:any:allow:100:9:1",
+			},
+			wantErr: true,
+		},
+		{name: "incorrect app filter negative MBR",
+			args: &args{
+				filterString: "ip:10.0.0.0/8:any:allow:100:9:-1:1000",
+			},
+			wantErr: true,
+		},
+		{name: "incorrect app filter bad MBR",
+			args: &args{
+				filterString: "ip:10.0.0.0/8:any:allow:100:9:1000:fast",
+			},
+			wantErr: true,
+		},
+		{name: "incorrect app filter too many tokens",
+			args: &args{
+				filterString: "ip:10.0.0.0/8:any:allow:100:9:1000:2000:1",
+			},
+			wantErr: true,
+		},
 		{name: "incorrect app filter bad precedence",
 			args: &args{
 				filterString: "ip:10/8:80-80:allow:test",
@@ -120,7 +401,7 @@ func Test_parseAppFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(
 			tt.name, func(t *testing.T) {
-				filter, gateStatus, precedence, err := parseAppFilter(tt.args.filterString)
+				filter, gateStatus, precedence, qfi, err := parseAppFilter(tt.args.filterString)
 				if tt.wantErr {
 					require.Error(t, err)
 					return
@@ -129,7 +410,227 @@ func Test_parseAppFilter(t *testing.T) {
 				require.Equal(t, tt.want.SDFFilter, filter)
 				require.Equal(t, tt.want.gateStatus, gateStatus)
 				require.Equal(t, tt.want.precedence, precedence)
+				require.Equal(t, tt.want.qfi, qfi)
 			},
 		)
 	}
 }
+
+func Test_parseAppFilterMBRs(t *testing.T) {
+	tests := []struct {
+		filter       string
+		ulMbr, dlMbr uint64
+		ok           bool
+	}{
+		{filter: "ip:any:any:allow"},
+		{filter: "ip:any:any:allow:100:9"},
+		{filter: "udp:10.0.0.0/8:80:allow:100:9:5000:20000", ulMbr: 5000, dlMbr: 20000, ok: true},
+		{filter: "ip:[2001:db8::/32]:any:allow:100:5:0:1000", ulMbr: 0, dlMbr: 1000, ok: true},
+	}
+
+	for _, tt := range tests {
+		ulMbr, dlMbr, ok, err := parseAppFilterMBRs(tt.filter)
+		require.NoError(t, err, tt.filter)
+		require.Equal(t, tt.ok, ok, tt.filter)
+		require.Equal(t, tt.ulMbr, ulMbr, tt.filter)
+		require.Equal(t, tt.dlMbr, dlMbr, tt.filter)
+	}
+
+	_, _, _, err := parseAppFilterMBRs("ip:any:any:allow:100:9:1000:-1")
+	require.Error(t, err)
+}
+
+func Test_reverseSDFFilter(t *testing.T) {
+	tests := []struct {
+		filter   string
+		expected string
+		wantErr  bool
+	}{
+		{filter: "permit out udp from 10.0.0.0/8 to assigned 80-88", expected: "permit out udp from assigned 80-88 to 10.0.0.0/8"},
+		{filter: "permit out ip from any to assigned", expected: "permit out ip from assigned to any"},
+		{filter: "permit out tcp from 2001:db8::/32 to assigned 443", expected: "permit out tcp from assigned 443 to 2001:db8::/32"},
+		{filter: "permit out ip from any", wantErr: true},
+		{filter: "permit out ip to assigned", wantErr: true},
+		{filter: "permit out ip from any to", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			reversed, err := reverseSDFFilter(tt.filter)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, reversed)
+		})
+	}
+}
+
+func Test_validateSDFFilter(t *testing.T) {
+	for _, filter := range []string{
+		"permit out ip from any to assigned",
+		"permit out udp from 10.0.0.0/8 to assigned 80-88",
+		"permit out tcp from 2001:db8::/32 443 to assigned",
+		"permit out 17 from any to assigned 53",
+	} {
+		require.NoError(t, validateSDFFilter(filter), filter)
+	}
+
+	for _, filter := range []string{
+		"",
+		"deny out ip from any to assigned",
+		"permit in ip from any to assigned",
+		"permit out icmp from any to assigned",
+		"permit out 256 from any to assigned",
+		"permit out ip to any from assigned",
+		"permit out ip from any assigned",
+		"permit out ip from 10/8 to assigned",
+		"permit out ip from 10.0.0.1 to assigned",
+		"permit out ip from any to",
+		"permit out udp from any to assigned 80-",
+		"permit out udp from any to assigned 70000",
+		"permit out udp from any to assigned 90-80",
+		"permit out udp from any 80 81 to assigned",
+	} {
+		require.Error(t, validateSDFFilter(filter), filter)
+	}
+}
+
+func Test_checkAppFiltersIPVersion(t *testing.T) {
+	ipv4UE := []net.IP{net.ParseIP("17.0.0.1")}
+	ipv6UE := []net.IP{net.ParseIP("2001:db8:1::1")}
+
+	tests := []struct {
+		name        string
+		appFilters  []string
+		ueAddresses []net.IP
+		wantErr     bool
+	}{
+		{name: "IPv4 filter and UE address", appFilters: []string{"ip:10.0.0.0/8:any:allow:100"}, ueAddresses: ipv4UE},
+		{name: "IPv6 filter and UE address", appFilters: []string{"ip:[2001:db8::/32]:any:allow:100"}, ueAddresses: ipv6UE},
+		{name: "any matches IPv6 UE address", appFilters: []string{"ip:any:any:allow:100"}, ueAddresses: ipv6UE},
+		{name: "IPv6 filter and IPv4 UE address", appFilters: []string{"ip:[2001:db8::/32]:any:allow:100"},
+			ueAddresses: ipv4UE, wantErr: true},
+		{name: "IPv4 filter and IPv6 UE address", appFilters: []string{"ip:any:any:allow:100", "ip:10.0.0.0/8:any:allow:100"},
+			ueAddresses: ipv6UE, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAppFiltersIPVersion(tt.appFilters, tt.ueAddresses)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_deleteIdleSessions(t *testing.T) {
+	upf := setupAssociation(t)
+
+	idleSess, err := sim.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+	activeSessions.Insert(1, idleSess)
+
+	reportedSess, err := sim.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+	activeSessions.Insert(11, reportedSess)
+
+	require.Equal(t, 0, deleteIdleSessions(time.Hour))
+
+	time.Sleep(200 * time.Millisecond)
+
+	// a usage report keeps the second session alive, a downlink data report doesn't keep the first one
+	require.NoError(t, upf.Send(message.NewSessionReportRequest(0, 0, reportedSess.LocalSEID(), 1, 0,
+		ie.NewReportType(0, 0, 1, 0),
+	)))
+	require.NoError(t, upf.Send(message.NewSessionReportRequest(0, 0, idleSess.LocalSEID(), 2, 0,
+		ie.NewReportType(0, 0, 0, 1),
+		ie.NewDownlinkDataReport(ie.NewPDRID(1)),
+	)))
+	require.Eventually(t, func() bool {
+		return len(upf.Received(message.MsgTypeSessionReportResponse)) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	require.Equal(t, 1, deleteIdleSessions(100*time.Millisecond))
+
+	_, ok := activeSessions.Get(1)
+	require.False(t, ok)
+
+	_, ok = activeSessions.Get(11)
+	require.True(t, ok)
+
+	require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 1)
+}
+
+func Test_allocateUEAddresses(t *testing.T) {
+	tests := []struct {
+		name       string
+		pools      []string
+		count      int
+		roundRobin bool
+		want       []string
+		wantErr    bool
+	}{
+		{name: "single pool",
+			pools: []string{"17.0.0.0/24"},
+			count: 3,
+			want:  []string{"17.0.0.1", "17.0.0.2", "17.0.0.3"},
+		},
+		{name: "pools exhausted in sequence",
+			pools: []string{"17.0.0.0/30", "18.0.0.0/24"},
+			count: 5,
+			want:  []string{"17.0.0.1", "17.0.0.2", "17.0.0.3", "18.0.0.1", "18.0.0.2"},
+		},
+		{name: "pools in round-robin",
+			pools:      []string{"17.0.0.0/24", "18.0.0.0/24"},
+			count:      4,
+			roundRobin: true,
+			want:       []string{"17.0.0.1", "18.0.0.1", "17.0.0.2", "18.0.0.2"},
+		},
+		{name: "round-robin skips exhausted pools",
+			pools:      []string{"17.0.0.0/31", "18.0.0.0/24"},
+			count:      4,
+			roundRobin: true,
+			want:       []string{"17.0.0.1", "18.0.0.1", "18.0.0.2", "18.0.0.3"},
+		},
+		{name: "not enough addresses",
+			pools:   []string{"17.0.0.0/30", "18.0.0.0/30"},
+			count:   7,
+			wantErr: true,
+		},
+		{name: "invalid pool",
+			pools:   []string{"17.0.0.0/24", "not-a-pool"},
+			count:   1,
+			wantErr: true,
+		},
+		{name: "no pools",
+			count:   1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := allocateUEAddresses(tt.pools, tt.count, tt.roundRobin)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			addresses := make([]string, 0, len(got))
+			for _, ip := range got {
+				addresses = append(addresses, ip.String())
+			}
+
+			require.Equal(t, tt.want, addresses)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// additionalPeerAddresses are the remote peers the simulator associates with besides remotePeerAddress
+	additionalPeerAddresses []string
+	// additionalPeers keeps the clients connected to additionalPeerAddresses, indexed by address
+	additionalPeers     = make(map[string]*pfcpsim.PFCPClient)
+	lockAdditionalPeers = new(sync.RWMutex)
+)
+
+// errPeerNotAssociated is returned when the requested remote peer is not one of the associated peers.
+var errPeerNotAssociated = errors.New("remote peer is not associated")
+
+// peerClient returns the client connected to the remote peer identified by address.
+// The client of remotePeerAddress is returned if address is empty.
+func peerClient(address string) (*pfcpsim.PFCPClient, error) {
+	if address == "" || address == remotePeerAddress {
+		return sim, nil
+	}
+
+	lockAdditionalPeers.RLock()
+	defer lockAdditionalPeers.RUnlock()
+
+	client, ok := additionalPeers[address]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", errPeerNotAssociated, address)
+	}
+
+	return client, nil
+}
+
+// dryRunClient returns the client building the requests of dry runs for the remote peer identified by address,
+// see peerClient. If not associated with remotePeerAddress, it returns a new client not connected to any peer,
+// advertising the configured Node ID and CSID.
+func dryRunClient(address string) (*pfcpsim.PFCPClient, error) {
+	if isRemotePeerConnected() || (address != "" && address != remotePeerAddress) {
+		return peerClient(address)
+	}
+
+	localAddr, err := localAddress(remotePeerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	client := pfcpsim.NewPFCPClient(localAddr)
+	client.SetCSID(csid)
+
+	if err := applyNodeID(client); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// sessionPeer returns the address of the remote peer the session identified by baseID was established with.
+// It is empty for the sessions of remotePeerAddress.
+func sessionPeer(baseID int) string {
+	record, _ := activeSessions.Record(baseID)
+
+	return record.Peer
+}
+
+// connectAdditionalPeers connects to each of additionalPeerAddresses not connected yet and sets up the association.
+func connectAdditionalPeers(ctx context.Context) error {
+	lockAdditionalPeers.Lock()
+	defer lockAdditionalPeers.Unlock()
+
+	for _, address := range additionalPeerAddresses {
+		if _, ok := additionalPeers[address]; ok {
+			continue
+		}
+
+		localAddr, err := localAddress(address)
+		if err != nil {
+			return err
+		}
+
+		client := newSim(localAddr)
+
+		if localN4Address != "" {
+			if err := client.SetLocalN4Address(localN4Address); err != nil {
+				return err
+			}
+		}
+
+		if err := client.ConnectN4(peerEndpoint(address)); err != nil {
+			return fmt.Errorf("could not connect to remote peer %v: %w", address, err)
+		}
+
+		client.SetCPFunctionFeatures(cpFunctionFeatures)
+		client.SetCSID(csid)
+		client.SetMaxMissedHeartbeats(maxMissedHeartbeats)
+		client.SetAutoReassociation(reassociationBackoff)
+		applyOperationTimeouts(client)
+		applySEIDAllocation(client)
+
+		if err := applyNodeID(client); err != nil {
+			client.DisconnectN4()
+			return err
+		}
+
+		if err := applyMessagePriority(client); err != nil {
+			client.DisconnectN4()
+			return err
+		}
+
+		if err := client.SetupAssociationWithRetry(ctx, associationRetries+1, associationRetryBackoff); err != nil {
+			client.DisconnectN4()
+			return fmt.Errorf("could not associate with remote peer %v: %w", address, err)
+		}
+
+		additionalPeers[address] = client
+	}
+
+	return nil
+}
+
+// disconnectAdditionalPeers closes the connections to the additional peers. If releaseAssociation is set,
+// the associations are released first.
+func disconnectAdditionalPeers(releaseAssociation bool) {
+	lockAdditionalPeers.Lock()
+	defer lockAdditionalPeers.Unlock()
+
+	for address, client := range additionalPeers {
+		if releaseAssociation && client.IsAssociationAlive() {
+			if _, err := client.ReleaseAssociation(); err != nil {
+				log.Warnf("Could not release the association with remote peer %v: %v", address, err)
+			}
+		}
+
+		client.DisconnectN4()
+
+		delete(additionalPeers, address)
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	log "github.com/sirupsen/logrus"
+)
+
+// reestablishSession establishes again the session identified by baseID, with the rules it was created with.
+// The previous session is deleted from the remote peer first, unless it is stale; failures are ignored,
+// since the peer may have lost it already.
+func reestablishSession(ctx context.Context, baseID int) error {
+	sess, ok := activeSessions.Get(baseID)
+	if !ok {
+		return fmt.Errorf("%w with baseID %v", errSessionNotActive, baseID)
+	}
+
+	rules, ok := activeSessions.Rules(baseID)
+	if !ok {
+		return fmt.Errorf("%w for baseID %v", errSessionRulesUnknown, baseID)
+	}
+
+	client, err := peerClient(sessionPeer(baseID))
+	if err != nil {
+		return err
+	}
+
+	if !sess.IsStale() {
+		if err := client.DeleteSessionWithContext(ctx, sess); err != nil {
+			log.Warnf("Could not delete session with baseID %v before re-establishing it: %v", baseID, err)
+		}
+	}
+
+	newSess, err := client.EstablishSessionWithPDNType(ctx, sess.PDNType(), rules.pdrs, rules.fars, rules.qers)
+	if err != nil {
+		return err
+	}
+
+	activeSessions.Insert(baseID, newSess)
+
+	return nil
+}
+
+// findSession returns the base ID of the session established with client and identified by localSEID.
+func findSession(client *pfcpsim.PFCPClient, localSEID uint64) (int, bool) {
+	baseID, found := 0, false
+
+	activeSessions.Range(func(index int, sess *pfcpsim.PFCPSession) bool {
+		if sess.LocalSEID() != localSEID {
+			return true
+		}
+
+		if c, err := peerClient(sessionPeer(index)); err != nil || c != client {
+			return true
+		}
+
+		baseID, found = index, true
+
+		return false
+	})
+
+	return baseID, found
+}
+
+// handleErrorIndication re-establishes the session of client identified by localSEID,
+// if reestablishOnErrorIndication is set.
+func handleErrorIndication(client *pfcpsim.PFCPClient, localSEID uint64) {
+	if !reestablishOnErrorIndication {
+		return
+	}
+
+	baseID, ok := findSession(client, localSEID)
+	if !ok {
+		return
+	}
+
+	if err := reestablishSession(context.Background(), baseID); err != nil {
+		log.Errorf("Could not re-establish session with baseID %v after an error indication: %v", baseID, err)
+		return
+	}
+
+	log.Infof("Session with baseID %v re-established after an error indication", baseID)
+}
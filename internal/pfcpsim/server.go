@@ -6,15 +6,19 @@ package pfcpsim
 import (
 	"context"
 	"fmt"
+	"math"
 	"net"
+	"strconv"
+	"time"
 
 	pb "github.com/ardzoht/pfcpsim/api"
 	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
 	"github.com/ardzoht/pfcpsim/pkg/pfcpsim/session"
-	"github.com/c-robinson/iplib"
 	log "github.com/sirupsen/logrus"
 	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
@@ -30,11 +34,36 @@ type pfcpSimService struct {
 // to deny traffic to the RFC1918 IPs, in case we have a ALLOW-PUBLIC)
 const SessionStep = 10
 
-func NewPFCPSimService(iface string) *pfcpSimService {
+// associationRetryBackoff is the time waited before retrying a failed association setup.
+// It doubles after every failed attempt.
+const associationRetryBackoff = time.Second
+
+// sessionBARID is the ID of the single BAR of each session, created when the session starts buffering.
+const sessionBARID = 1
+
+// defaultGTPUEchoTimeout is the time to wait for a GTP-U Echo Response, if not specified
+const defaultGTPUEchoTimeout = time.Second
+
+// NewPFCPSimService returns a new pfcpSimService. If idle is greater than zero, sessions that did not
+// receive any usage report within idle are deleted by a background sweeper.
+func NewPFCPSimService(iface string, idle time.Duration) *pfcpSimService {
 	interfaceName = iface
+	idleTimeout = idle
+
+	if idleTimeout > 0 {
+		go sweepIdleSessions(idleTimeout)
+	}
+
 	return &pfcpSimService{}
 }
 
+// RegisterServices registers on grpcServer the pfcpsim service, see NewPFCPSimService, and the server
+// reflection service, so that the API can be explored with tools like grpcurl.
+func RegisterServices(grpcServer *grpc.Server, iface string, idle time.Duration) {
+	pb.RegisterPFCPSimServer(grpcServer, NewPFCPSimService(iface, idle))
+	reflection.Register(grpcServer)
+}
+
 func checkServerStatus() error {
 	if !isConfigured() {
 		return status.Error(codes.Aborted, "Server is not configured")
@@ -48,16 +77,12 @@ func checkServerStatus() error {
 }
 
 func (P pfcpSimService) Configure(ctx context.Context, request *pb.ConfigureRequest) (*pb.Response, error) {
-	if net.ParseIP(request.UpfN3Address) == nil {
-		errMsg := fmt.Sprintf("Error while parsing UPF N3 address: %v", request.UpfN3Address)
-		log.Error(errMsg)
-		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	configurationMsg, err := configure(request)
+	if err != nil {
+		log.Error(err)
+		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
 	}
-	// remotePeerAddress is validated in pfcpsim
-	remotePeerAddress = request.RemotePeerAddress
-	upfN3Address = request.UpfN3Address
 
-	configurationMsg := fmt.Sprintf("Server is configured. Remote peer address: %v, N3 interface address: %v ", remotePeerAddress, upfN3Address)
 	log.Info(configurationMsg)
 
 	return &pb.Response{
@@ -80,7 +105,28 @@ func (P pfcpSimService) Associate(ctx context.Context, empty *pb.EmptyRequest) (
 		}
 	}
 
-	if err := sim.SetupAssociation(); err != nil {
+	sim.SetCPFunctionFeatures(cpFunctionFeatures)
+	sim.SetCSID(csid)
+	sim.SetAutoReassociation(reassociationBackoff)
+	applyOperationTimeouts(sim)
+	applySEIDAllocation(sim)
+
+	if err := applyNodeID(sim); err != nil {
+		log.Error(err.Error())
+		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	if err := applyMessagePriority(sim); err != nil {
+		log.Error(err.Error())
+		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	if err := sim.SetupAssociationWithRetry(ctx, associationRetries+1, associationRetryBackoff); err != nil {
+		log.Error(err.Error())
+		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	if err := connectAdditionalPeers(ctx); err != nil {
 		log.Error(err.Error())
 		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
 	}
@@ -94,21 +140,76 @@ func (P pfcpSimService) Associate(ctx context.Context, empty *pb.EmptyRequest) (
 	}, nil
 }
 
-func (P pfcpSimService) Disassociate(ctx context.Context, empty *pb.EmptyRequest) (*pb.Response, error) {
+func (P pfcpSimService) Disassociate(ctx context.Context, request *pb.DisassociateRequest) (*pb.Response, error) {
 	if err := checkServerStatus(); err != nil {
 		return &pb.Response{}, err
 	}
 
-	if err := sim.TeardownAssociation(); err != nil {
+	cause, err := sim.ReleaseAssociation()
+	if err != nil {
 		log.Error(err.Error())
 		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
 	}
 
 	sim.DisconnectN4()
+	disconnectAdditionalPeers(true)
+
+	setRemotePeerConnected(false)
 
-	remotePeerConnected = false
+	if !request.KeepSessions {
+		activeSessions.Clear()
+	}
 
 	infoMsg := "Association teardown completed and connection to remote peer closed"
+	if cause == 0 {
+		infoMsg = "Remote peer did not answer Association Release Request: association released locally and connection to remote peer closed"
+		log.Warn(infoMsg)
+	} else {
+		log.Info(infoMsg)
+	}
+
+	return &pb.Response{
+		StatusCode: int32(codes.OK),
+		Message:    infoMsg,
+		Cause:      uint32(cause),
+	}, nil
+}
+
+func (P pfcpSimService) UpdateAssociation(ctx context.Context, request *pb.UpdateAssociationRequest) (*pb.Response, error) {
+	if err := checkServerStatus(); err != nil {
+		return &pb.Response{}, err
+	}
+
+	if request.CpFunctionFeatures > math.MaxUint8 {
+		errMsg := fmt.Sprintf("CP function features must be between 0 and %v", math.MaxUint8)
+		log.Error(errMsg)
+
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	if request.GracefulReleasePeriodMs < 0 {
+		errMsg := "Graceful release period must not be negative"
+		log.Error(errMsg)
+
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	client, err := peerClient(request.Peer)
+	if err != nil {
+		log.Error(err)
+		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	gracefulReleasePeriod := time.Duration(request.GracefulReleasePeriodMs) * time.Millisecond
+
+	err = client.UpdateAssociationWithContext(ctx, uint8(request.CpFunctionFeatures), gracefulReleasePeriod)
+	if err != nil {
+		log.Error(err)
+		return &pb.Response{}, rejectionError(ctx, codes.Aborted, err.Error(), err)
+	}
+
+	infoMsg := fmt.Sprintf("Association updated with remote peer %v: UP function features %v",
+		client.PeerNodeID(), pfcpsim.UPFunctionFeatureNames(client.PeerUPFunctionFeatures()))
 	log.Info(infoMsg)
 
 	return &pb.Response{
@@ -117,14 +218,94 @@ func (P pfcpSimService) Disassociate(ctx context.Context, empty *pb.EmptyRequest
 	}, nil
 }
 
-func (P pfcpSimService) CreateSession(ctx context.Context, request *pb.CreateSessionRequest) (*pb.Response, error) {
+func (P pfcpSimService) SetRecoveryTimeStamp(ctx context.Context, request *pb.RecoveryTimeStampRequest) (*pb.Response, error) {
 	if err := checkServerStatus(); err != nil {
 		return &pb.Response{}, err
 	}
 
+	if request.Timestamp != "" && request.AdvanceSeconds != 0 {
+		errMsg := "Please specify either the Recovery Time Stamp or the number of seconds to advance it by, not both"
+		log.Error(errMsg)
+
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	if request.AdvanceSeconds < 0 {
+		errMsg := "Number of seconds to advance the Recovery Time Stamp by must not be negative"
+		log.Error(errMsg)
+
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	client, err := peerClient(request.Peer)
+	if err != nil {
+		log.Error(err)
+		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	recoveryTimeStamp := time.Now()
+
+	switch {
+	case request.Timestamp != "":
+		recoveryTimeStamp, err = time.Parse(time.RFC3339, request.Timestamp)
+		if err != nil {
+			errMsg := fmt.Sprintf("Could not parse Recovery Time Stamp %v: please use the RFC 3339 format", request.Timestamp)
+			log.Error(errMsg)
+
+			return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+		}
+	case request.AdvanceSeconds > 0:
+		recoveryTimeStamp = client.RecoveryTimeStamp().Add(time.Duration(request.AdvanceSeconds) * time.Second)
+	}
+
+	client.SetRecoveryTimeStamp(recoveryTimeStamp)
+
+	// the sessions are kept locally: from the peer's perspective they are lost, which is what is being tested
+	if err := client.SetupAssociationWithRetry(ctx, associationRetries+1, associationRetryBackoff); err != nil {
+		log.Error(err)
+		return &pb.Response{}, rejectionError(ctx, codes.Aborted, err.Error(), err)
+	}
+
+	infoMsg := fmt.Sprintf("Association set up again with remote peer %v advertising Recovery Time Stamp %v: "+
+		"the sessions established before are no longer valid for the remote peer",
+		client.PeerNodeID(), recoveryTimeStamp.Format(time.RFC3339))
+	log.Info(infoMsg)
+
+	return &pb.Response{
+		StatusCode: int32(codes.OK),
+		Message:    infoMsg,
+	}, nil
+}
+
+func (P pfcpSimService) CreateSession(ctx context.Context, request *pb.CreateSessionRequest) (*pb.CreateSessionResponse, error) {
+	if request.DryRun {
+		// dry runs only build the requests, hence they don't need the association
+		if !isConfigured() {
+			return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, "Server is not configured")
+		}
+	} else if err := checkServerStatus(); err != nil {
+		return &pb.CreateSessionResponse{}, err
+	}
+
 	baseID := int(request.BaseID)
 	count := int(request.Count)
 
+	if err := checkSessionsNotExist(baseID, count); err != nil {
+		log.Error(err)
+		return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	getClient := peerClient
+	if request.DryRun {
+		getClient = dryRunClient
+	}
+
+	client, err := getClient(request.Peer)
+	if err != nil {
+		log.Error(err)
+		return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, err.Error())
+	}
+
 	uplinkDstIp := request.UlTunnelDstIP
 	if uplinkDstIp == "" {
 		uplinkDstIp = "0.0.0.0"
@@ -135,60 +316,206 @@ func (P pfcpSimService) CreateSession(ctx context.Context, request *pb.CreateSes
 		downlinkDstIp = request.NodeBAddress
 	}
 
-	teidAlloc := request.TeidAllocFlag
+	teidAlloc := request.TeidAllocFlag || request.TeidAllocation == pb.TeidAllocation_UPF_ALLOCATED
 	bidirectionalSDF := request.BidirectionalSDFFlag
 
-	lastUEAddr, _, err := net.ParseCIDR(request.UeAddressPool)
-	if err != nil {
-		errMsg := fmt.Sprintf(" Could not parse Address Pool: %v", err)
+	if bidirectionalSDF && request.DirectionalSDFFlag {
+		errMsg := "Bidirectional and directional SDF filters can't be requested together"
 		log.Error(errMsg)
-		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+		return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	if request.SessionsPerSecond < 0 {
+		errMsg := fmt.Sprintf("Sessions per second must not be negative, got %v", request.SessionsPerSecond)
+		log.Error(errMsg)
+		return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	if request.AppUlMbr < 0 || request.AppDlMbr < 0 {
+		errMsg := fmt.Sprintf("Application MBRs must not be negative, got UL %v DL %v", request.AppUlMbr, request.AppDlMbr)
+		log.Error(errMsg)
+		return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	for _, iface := range []pb.DestinationInterface{request.UplinkDstInterface, request.DownlinkDstInterface} {
+		if _, ok := pb.DestinationInterface_name[int32(iface)]; !ok {
+			errMsg := fmt.Sprintf("Unknown destination interface %v", iface)
+			log.Error(errMsg)
+			return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, errMsg)
+		}
+	}
+
+	uplinkDstInterface := pfcpDstInterface(request.UplinkDstInterface, ieLib.DstInterfaceCore)
+	downlinkDstInterface := pfcpDstInterface(request.DownlinkDstInterface, ieLib.DstInterfaceAccess)
+
+	pools := request.UeAddressPools
+	if request.UeAddressPool != "" {
+		pools = append([]string{request.UeAddressPool}, pools...)
+	}
+
+	var ueAddresses, ueIPv6Addresses []net.IP
+
+	if request.PdnType != pb.PdnType_IPV6 {
+		ueAddresses, err = allocateUEAddresses(pools, count, request.UeAddressPoolsRoundRobin)
+		if err != nil {
+			errMsg := fmt.Sprintf("Could not assign UE addresses: %v", err)
+			log.Error(errMsg)
+			return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, errMsg)
+		}
+	}
+
+	if request.PdnType != pb.PdnType_IPV4 {
+		ueIPv6Addresses, err = allocateIPv6UEAddresses(request.UeIPv6AddressPool, count)
+		if err != nil {
+			errMsg := fmt.Sprintf("Could not assign UE IPv6 addresses: %v", err)
+			log.Error(errMsg)
+			return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, errMsg)
+		}
 	}
 
-	var qfi uint8 = 0
+	var qfi = uint8(defaultQFI)
 
 	if request.Qfi != 0 {
 		qfi = uint8(request.Qfi)
 	}
 
+	ulAmbr, dlAmbr := request.UlAmbr, request.DlAmbr
+	if ulAmbr == 0 && dlAmbr == 0 {
+		ulAmbr, dlAmbr = defaultUlAmbr, defaultDlAmbr
+	}
+
+	if err = checkSessionGBR(request.UlGbr, request.DlGbr, ulAmbr, dlAmbr, request.SkipSessionQER); err != nil {
+		log.Error(err)
+		return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, err.Error())
+	}
+
 	if err = isNumOfAppFiltersCorrect(request.AppFilters); err != nil {
-		return &pb.Response{}, err
+		return &pb.CreateSessionResponse{}, err
 	}
 
-	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
+	// without application filters, sessions get a single pair of rules matching all the traffic,
+	// enforcing only the session QER
+	appFilters := request.AppFilters
+	passThrough := len(appFilters) == 0
+
+	if passThrough {
+		if request.SkipSessionQER {
+			errMsg := "Sessions without application filters require the session QER"
+			log.Error(errMsg)
+			return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, errMsg)
+		}
+
+		appFilters = []string{passThroughAppFilter}
+	}
+
+	for _, appFilter := range request.AppFilters {
+		SDFFilter, _, _, _, err := parseAppFilter(appFilter)
+		if err != nil {
+			return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, err.Error())
+		}
+
+		log.Infof("Successfully parsed application filter. SDF Filter: %v", SDFFilter)
+	}
+
+	// dual-stack UEs can match filters of both IP versions
+	if request.PdnType != pb.PdnType_IPV4V6 {
+		if err = checkAppFiltersIPVersion(request.AppFilters, append(ueAddresses, ueIPv6Addresses...)); err != nil {
+			log.Error(err)
+			return &pb.CreateSessionResponse{}, status.Error(codes.Aborted, err.Error())
+		}
+	}
+
+	// sessions holds the sessions established by this request, indexed by their position in the request
+	sessions := make([]*pb.CreatedSession, count)
+
+	// establish creates the k-th session, identified by base ID i
+	establish := func(k int, i int) (err error) {
+		ueAddress := addressAt(ueAddresses, k)
+		ueIPv6Address := addressAt(ueIPv6Addresses, k)
+
 		// using variables to ease comprehension on how rules are linked together
 		uplinkTEID := uint32(i)
 
-		ueAddress := iplib.NextIP(lastUEAddr)
-		lastUEAddr = ueAddress
+		switch {
+		case teidAlloc:
+			// the remote peer allocates the uplink TEID
+		case request.DryRun:
+			// dry runs don't reserve any TEID
+		case request.TeidAllocation == pb.TeidAllocation_GLOBAL:
+			if uplinkTEID, err = activeSessions.AllocateTEID(i); err != nil {
+				return err
+			}
+		default:
+			activeSessions.ReserveTEID(i, uplinkTEID)
+		}
+
+		defer func() {
+			if err != nil && !request.DryRun {
+				activeSessions.ReleaseTEID(i)
+			}
+		}()
 
 		sessQerID := uint32(0)
 
 		var pdrs, fars, qers []*ieLib.IE
 
-		if (request.UlAmbr != 0) || (request.DlAmbr != 0) {
+		// appRules keeps the IDs of the rules of each app filter, to be looked up by ModifySession
+		appRules := make([]appRuleIDs, len(appFilters))
+
+		if !request.SkipSessionQER && (passThrough || (ulAmbr != 0) || (dlAmbr != 0)) {
 			sessQerID = 100
-			qers = []*ieLib.IE{
-				// session QER
-				session.NewQERBuilder().
-					WithID(sessQerID).
-					WithMethod(session.Create).
-					WithUplinkMBR(uint64(request.UlAmbr)).
-					WithDownlinkMBR(uint64(request.DlAmbr)).
-					Build(),
+
+			sessQER := session.NewQERBuilder().
+				WithID(sessQerID).
+				WithMethod(session.Create)
+
+			// pass-through sessions get the session QER even if it does not enforce any AMBR
+			if (ulAmbr != 0) || (dlAmbr != 0) {
+				sessQER.WithUplinkMBR(uint64(ulAmbr)).WithDownlinkMBR(uint64(dlAmbr))
+			}
+
+			if (request.UlGbr != 0) || (request.DlGbr != 0) {
+				sessQER.WithUplinkGBR(uint64(request.UlGbr)).WithDownlinkGBR(uint64(request.DlGbr))
 			}
+
+			qers = []*ieLib.IE{sessQER.Build()}
 		}
 
 		// create as many PDRs, FARs and App QERs as the number of app filters provided through pfcpctl
 		ID := uint16(i)
 
-		for _, appFilter := range request.AppFilters {
-			SDFFilter, gateStatus, precedence, err := parseAppFilter(appFilter)
+		for j, appFilter := range appFilters {
+			SDFFilter, gateStatus, precedence, appQFI, err := parseAppFilter(appFilter)
 			if err != nil {
-				return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+				return err
 			}
 
-			log.Infof("Successfully parsed application filter. SDF Filter: %v", SDFFilter)
+			// an explicit precedence takes precedence over the one assigned by the order
+			if !hasExplicitPrecedence(appFilter) {
+				precedence = autoPrecedence(request.PrecedenceOrder, j)
+			}
+
+			// the QFI of the app filter takes precedence over the one of the request
+			if appQFI == 0 {
+				appQFI = qfi
+			}
+
+			// and so do its MBRs
+			appUlMbr, appDlMbr, hasAppMBRs, err := parseAppFilterMBRs(appFilter)
+			if err != nil {
+				return err
+			}
+
+			if !hasAppMBRs {
+				appUlMbr, appDlMbr = uint64(request.AppUlMbr), uint64(request.AppDlMbr)
+			}
+
+			downlinkSDFFilter := SDFFilter
+			if request.DirectionalSDFFlag && SDFFilter != "" {
+				if downlinkSDFFilter, err = reverseSDFFilter(SDFFilter); err != nil {
+					return err
+				}
+			}
 
 			uplinkPdrID := ID
 			downlinkPdrID := ID + 1
@@ -199,90 +526,246 @@ func (P pfcpSimService) CreateSession(ctx context.Context, request *pb.CreateSes
 			uplinkAppQerID := uint32(ID)
 			downlinkAppQerID := uint32(ID + 1)
 
-			uplinkPDR := session.NewPDRBuilder().
-				WithID(uplinkPdrID).
-				WithMethod(session.Create).
-				WithTEID(uplinkTEID).
-				WithFARID(uplinkFarID).
-				AddQERID(sessQerID).
-				AddQERID(uplinkAppQerID).
-				WithN3Address(upfN3Address).
-				WithSDFFilter(SDFFilter, bidirectionalSDF).
-				WithPrecedence(precedence).
-				WithTeidAlloc(teidAlloc).
-				MarkAsUplink().
-				BuildPDR()
-
-			downlinkPDR := session.NewPDRBuilder().
-				WithID(downlinkPdrID).
-				WithMethod(session.Create).
-				WithPrecedence(precedence).
-				WithUEAddress(ueAddress.String()).
-				WithSDFFilter(SDFFilter, bidirectionalSDF).
-				AddQERID(sessQerID).
-				AddQERID(downlinkAppQerID).
-				WithFARID(downlinkFarID).
-				WithTeidAlloc(teidAlloc).
-				MarkAsDownlink().
-				BuildPDR()
-
-			pdrs = append(pdrs, uplinkPDR)
-			pdrs = append(pdrs, downlinkPDR)
-
-			uplinkFAR := session.NewFARBuilder().
-				WithID(uplinkFarID).
-				WithAction(session.ActionForward).
-				WithDstInterface(ieLib.DstInterfaceCore).
-				WithMethod(session.Create).
-				WithUplinkIP(uplinkDstIp).
-				BuildFAR()
-
-			downlinkFAR := session.NewFARBuilder().
-				WithID(downlinkFarID).
-				WithAction(session.ActionForward).
-				WithMethod(session.Create).
-				WithDstInterface(ieLib.DstInterfaceAccess).
-				WithTEID(uplinkTEID).
-				WithDownlinkIP(downlinkDstIp).
-				BuildFAR()
-
-			fars = append(fars, uplinkFAR)
-			fars = append(fars, downlinkFAR)
-
-			uplinkAppQER := session.NewQERBuilder().
-				WithID(uplinkAppQerID).
-				WithMethod(session.Create).
-				WithQFI(qfi).
-				WithGateStatus(gateStatus).
-				Build()
-
-			downlinkAppQER := session.NewQERBuilder().
-				WithID(downlinkAppQerID).
-				WithMethod(session.Create).
-				WithQFI(qfi).
-				WithGateStatus(gateStatus).
-				Build()
-
-			qers = append(qers, uplinkAppQER)
-			qers = append(qers, downlinkAppQER)
+			uplinkQerIDs := pdrQERIDs(sessQerID, uplinkAppQerID, request.SkipSessionQER, request.AppQERFirst)
+			downlinkQerIDs := pdrQERIDs(sessQerID, downlinkAppQerID, request.SkipSessionQER, request.AppQERFirst)
+
+			if passThrough {
+				uplinkQerIDs, downlinkQerIDs = []uint32{sessQerID}, []uint32{sessQerID}
+			}
+
+			// traffic matching a denied application is dropped rather than forwarded
+			farAction := session.ActionForward
+			if gateStatus == ieLib.GateStatusClosed {
+				farAction = session.ActionDrop
+			}
+
+			if request.Direction != pb.Direction_DOWNLINK {
+				uplinkPDRBuilder := session.NewPDRBuilder().
+					WithID(uplinkPdrID).
+					WithMethod(session.Create).
+					WithTEID(uplinkTEID).
+					WithFARID(uplinkFarID)
+
+				uplinkPDR := uplinkPDRBuilder.
+					AddQERIDs(uplinkQerIDs...).
+					WithN3Address(upfN3Address).
+					WithSDFFilter(SDFFilter, bidirectionalSDF).
+					WithPrecedence(precedence).
+					WithTeidAlloc(teidAlloc).
+					MarkAsUplink().
+					BuildPDR()
+
+				uplinkFAR := session.NewFARBuilder().
+					WithID(uplinkFarID).
+					WithAction(farAction).
+					WithDstInterface(uplinkDstInterface).
+					WithMethod(session.Create)
+
+				if farAction == session.ActionForward {
+					uplinkFAR.WithUplinkIP(uplinkDstIp)
+				}
+
+				pdrs = append(pdrs, uplinkPDR)
+				fars = append(fars, uplinkFAR.BuildFAR())
+
+				appRules[j].UplinkPDRID = uplinkPdrID
+				appRules[j].UplinkFARID = uplinkFarID
+
+				if !passThrough {
+					qers = append(qers, newAppQER(uplinkAppQerID, appQFI, gateStatus, appUlMbr, appDlMbr))
+					appRules[j].UplinkQERID = uplinkAppQerID
+				}
+			}
+
+			if request.Direction != pb.Direction_UPLINK {
+				downlinkPDRBuilder := session.NewPDRBuilder().
+					WithID(downlinkPdrID).
+					WithMethod(session.Create).
+					WithPrecedence(precedence).
+					WithUEAddress(ueAddress).
+					WithUEIPv6Address(ueIPv6Address).
+					WithSDFFilter(downlinkSDFFilter, bidirectionalSDF)
+
+				downlinkPDR := downlinkPDRBuilder.
+					AddQERIDs(downlinkQerIDs...).
+					WithFARID(downlinkFarID).
+					WithTeidAlloc(teidAlloc).
+					MarkAsDownlink().
+					BuildPDR()
+
+				downlinkFAR := session.NewFARBuilder().
+					WithID(downlinkFarID).
+					WithAction(farAction).
+					WithMethod(session.Create).
+					WithDstInterface(downlinkDstInterface)
+
+				if farAction == session.ActionForward {
+					downlinkFAR.WithTEID(uplinkTEID).WithDownlinkIP(downlinkDstIp)
+				}
+
+				pdrs = append(pdrs, downlinkPDR)
+				fars = append(fars, downlinkFAR.BuildFAR())
+
+				appRules[j].DownlinkPDRID = downlinkPdrID
+				appRules[j].DownlinkFARID = downlinkFarID
+
+				if !passThrough {
+					qers = append(qers, newAppQER(downlinkAppQerID, appQFI, gateStatus, appUlMbr, appDlMbr))
+					appRules[j].DownlinkQERID = downlinkAppQerID
+				}
+			}
 
 			ID += 2
 		}
 
-		sess, err := sim.EstablishSession(pdrs, fars, qers)
+		if request.DryRun {
+			sessions[k], err = newDryRunSession(client, i, pfcpPDNType(request.PdnType), pdrs, fars, qers)
+			if err != nil {
+				return err
+			}
+
+			sessions[k].UeAddress = ueAddress
+			sessions[k].UeIPv6Address = ueIPv6Address
+
+			if !teidAlloc {
+				sessions[k].UplinkTEID = uplinkTEID
+			}
+
+			return nil
+		}
+
+		sess, err := establishSession(ctx, client, i, pfcpPDNType(request.PdnType), pdrs, fars, qers)
 		if err != nil {
-			return &pb.Response{}, status.Error(codes.Internal, err.Error())
+			return err
+		}
+
+		record := newSessionRecord(ueAddress, ueIPv6Address, pdrs, fars, qers)
+		record.Peer = request.Peer
+		record.AppRules = appRules
+		record.SessionQERID = sessQerID
+		record.UplinkDstInterface = request.UplinkDstInterface
+		record.DownlinkDstInterface = request.DownlinkDstInterface
+
+		log.Debugf("Session with baseID %v established with local SEID %v and remote SEID %v",
+			i, sess.LocalSEID(), sess.PeerSEID())
+
+		activeSessions.Insert(i, sess)
+		activeSessions.SetRecord(i, record)
+		activeSessions.SetRules(i, sessionRules{pdrs: pdrs, fars: fars, qers: qers})
+
+		sessions[k] = &pb.CreatedSession{
+			BaseID:        int32(i),
+			LocalSEID:     sess.LocalSEID(),
+			LocalAddress:  client.LocalAddr(),
+			UeAddress:     ueAddress,
+			UeIPv6Address: ueIPv6Address,
+			FailedRules:   newFailedRules(sess.FailedRules()),
+		}
+
+		if teidAlloc {
+			// keep the TEID allocated by the remote peer for the first uplink PDR, if reported
+			if fteid, ok := sess.AllocatedFTEID(uint16(i)); ok {
+				activeSessions.ReserveTEID(i, fteid.TEID)
+				sessions[k].UplinkTEID = fteid.TEID
+			}
+		} else {
+			sessions[k].UplinkTEID = uplinkTEID
+		}
+
+		return nil
+	}
+
+	var (
+		// created keeps the base IDs of the sessions established so far
+		created []int
+		errMsg  string
+		// failures keep the errors of the sessions not established, in base ID order
+		failures []error
+	)
+
+	limiter := newRateLimiter(request.SessionsPerSecond)
+	start := time.Now()
+
+	if request.Concurrency > 1 {
+		errs := runConcurrently(count, int(request.Concurrency), func(k int) error {
+			// stop establishing sessions once the client gives up
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+
+			return establish(k, baseID+k*SessionStep)
+		})
+
+		for k := 0; k < count; k++ {
+			if _, failed := errs[k]; !failed {
+				created = append(created, baseID+k*SessionStep)
+			}
+		}
+
+		if len(errs) > 0 {
+			errMsg = fmt.Sprintf("%v of %v sessions could not be established: %v",
+				len(errs), count, summarizeSessionErrors(baseID, errs))
+			failures = orderedSessionErrors(errs)
+		}
+	} else {
+		for k := 0; k < count; k++ {
+			if err := limiter.Wait(ctx); err != nil {
+				errMsg = fmt.Sprintf("Session creation interrupted after %v of %v sessions: %v", k, count, err)
+				break
+			}
+
+			if err := establish(k, baseID+k*SessionStep); err != nil {
+				errMsg = err.Error()
+				failures = []error{err}
+
+				break
+			}
+
+			created = append(created, baseID+k*SessionStep)
+		}
+	}
+
+	if errMsg != "" {
+		log.Error(errMsg)
+
+		// Make the creation atomic: do not leave behind the sessions established before the failure
+		if !request.DryRun {
+			rollbackSessions(created)
+		}
+
+		return &pb.CreateSessionResponse{}, rejectionError(ctx, codes.Internal, errMsg, failures...)
+	}
+
+	if request.DryRun {
+		infoMsg := fmt.Sprintf("%v Session Establishment Requests were built using %v as baseID, none was sent", count, baseID)
+		log.Info(infoMsg)
+
+		return &pb.CreateSessionResponse{
+			StatusCode: int32(codes.OK),
+			Message:    infoMsg,
+			Sessions:   sessions,
+		}, nil
+	}
+
+	if request.TtlMs > 0 {
+		for _, i := range created {
+			scheduleSessionExpiry(i, time.Duration(request.TtlMs)*time.Millisecond)
 		}
-		insertSession(i, sess)
 	}
 
 	infoMsg := fmt.Sprintf("%v sessions were established using %v as baseID, UlAmbr %v DlAmbr %v, Qfi %v ",
-		count, baseID, request.UlAmbr, request.DlAmbr, request.Qfi)
+		count, baseID, ulAmbr, dlAmbr, qfi)
+
+	if limiter != nil {
+		infoMsg += fmt.Sprintf("at %.2f sessions per second ", float64(count)/time.Since(start).Seconds())
+	}
+
 	log.Info(infoMsg)
 
-	return &pb.Response{
+	return &pb.CreateSessionResponse{
 		StatusCode: int32(codes.OK),
 		Message:    infoMsg,
+		Sessions:   sessions,
 	}, nil
 }
 
@@ -296,15 +779,24 @@ func (P pfcpSimService) ModifySession(ctx context.Context, request *pb.ModifySes
 	count := int(request.Count)
 	nodeBaddress := request.NodeBAddress
 
-	if len(activeSessions) < count {
-		err := pfcpsim.NewNotEnoughSessionsError()
+	if err := checkSessionsExist(baseID, count); err != nil {
 		log.Error(err)
 		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
 	}
 
+	if len(request.QueryURRIDs) > 0 {
+		return queryUsage(ctx, baseID, count, request.QueryURRIDs)
+	}
+
+	uplinkDstIp := request.UlTunnelDstIP
+	if uplinkDstIp == "" {
+		uplinkDstIp = "0.0.0.0"
+	}
+
 	var actions uint8 = 0
 
-	if request.BufferFlag || request.NotifyCPFlag {
+	buffering := request.BufferFlag || request.NotifyCPFlag
+	if buffering {
 		// We currently support only both flags set
 		actions |= session.ActionNotify
 		actions |= session.ActionBuffer
@@ -317,24 +809,111 @@ func (P pfcpSimService) ModifySession(ctx context.Context, request *pb.ModifySes
 		return &pb.Response{}, err
 	}
 
-	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
-		var newFARs, qers []*ieLib.IE
+	if request.SuggestedBufferingPacketsCount < 0 || request.SuggestedBufferingPacketsCount > math.MaxUint8 {
+		errMsg := fmt.Sprintf("Suggested buffering packets count must be between 0 and %v", math.MaxUint8)
+		log.Error(errMsg)
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	if request.DlDataNotificationDelayMs < 0 {
+		errMsg := "Downlink data notification delay cannot be negative"
+		log.Error(errMsg)
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	// the SDF filters and the precedences the PDRs are updated with, in the order of the PDR updates
+	updateSDFFilters := make([]string, len(request.PdrUpdates))
+	updatePrecedences := make([]uint32, len(request.PdrUpdates))
+
+	for k, update := range request.PdrUpdates {
+		if update.PdrID < request.BaseID || int(update.PdrID)+(count-1)*SessionStep > math.MaxUint16 {
+			errMsg := fmt.Sprintf("PDR ID %v of the PDR updates must be between baseID and %v for all the sessions", update.PdrID, math.MaxUint16)
+			log.Error(errMsg)
+			return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+		}
+
+		if update.Precedence < 0 {
+			errMsg := fmt.Sprintf("Precedence of the update of PDR %v cannot be negative", update.PdrID)
+			log.Error(errMsg)
+			return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+		}
+
+		updatePrecedences[k] = uint32(update.Precedence)
+
+		if update.AppFilter == "" {
+			continue
+		}
+
+		sdfFilter, _, precedence, _, err := parseAppFilter(update.AppFilter)
+		if err != nil {
+			log.Error(err)
+			return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+		}
+
+		updateSDFFilters[k] = sdfFilter
+
+		if updatePrecedences[k] == 0 && hasExplicitPrecedence(update.AppFilter) {
+			updatePrecedences[k] = precedence
+		}
+	}
+
+	var failedRules []*pb.FailedRule
+
+	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
+		if err := ctx.Err(); err != nil {
+			errMsg := fmt.Sprintf("Session modification interrupted at baseID %v: %v", i, err)
+			log.Error(errMsg)
+			return &pb.Response{}, contextAwareError(ctx, codes.Internal, errMsg)
+		}
+
+		var newFARs, qers []*ieLib.IE
 
-		ID := uint32(i + 1)
 		teid := uint32(i + 1)
 
-		if request.BufferFlag || request.NotifyCPFlag {
+		if buffering {
 			teid = 0 // When buffering, TEID = 0.
 		}
 
-		sessQerID := uint32(0)
+		sess, ok := activeSessions.Get(i)
+		if !ok {
+			errMsg := fmt.Sprintf("Could not retrieve session with index %v", i)
+			log.Error(errMsg)
+			return &pb.Response{}, status.Error(codes.Internal, errMsg)
+		}
+
+		if sess.IsStale() {
+			errMsg := fmt.Sprintf("Session with index %v is stale: remote peer restarted", i)
+			log.Error(errMsg)
+			return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+		}
+
+		// the rules are updated using the IDs they were created with
+		record, _ := activeSessions.Record(i)
+
+		if len(request.AppFilters) > len(record.AppRules) {
+			errMsg := fmt.Sprintf("Session with index %v was created with %v app filters, %v requested",
+				i, len(record.AppRules), len(request.AppFilters))
+			log.Error(errMsg)
+			return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+		}
+
+		var bar *ieLib.IE
+
+		if buffering {
+			bar = newSessionBAR(sess, request.SuggestedBufferingPacketsCount, request.DlDataNotificationDelayMs)
+		}
 
 		if (request.UlAmbr != 0) || (request.DlAmbr != 0) {
-			sessQerID = 1
+			if record.SessionQERID == 0 {
+				errMsg := fmt.Sprintf("Session with index %v was created without session QER: its AMBR can't be modified", i)
+				log.Error(errMsg)
+				return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+			}
+
 			qers = []*ieLib.IE{
 				// session QER
 				session.NewQERBuilder().
-					WithID(sessQerID).
+					WithID(record.SessionQERID).
 					WithMethod(session.Update).
 					WithUplinkMBR(uint64(request.UlAmbr)).
 					WithDownlinkMBR(uint64(request.DlAmbr)).
@@ -342,77 +921,498 @@ func (P pfcpSimService) ModifySession(ctx context.Context, request *pb.ModifySes
 			}
 		}
 
-		for range request.AppFilters {
-			downlinkFAR := session.NewFARBuilder().
-				WithID(ID). // Same FARID that was generated in create sessions
-				WithMethod(session.Update).
-				WithAction(actions).
-				WithDstInterface(ieLib.DstInterfaceAccess).
-				WithTEID(teid).
-				WithDownlinkIP(nodeBaddress).
-				WithEndMarker(request.EndMarkerFlag).
-				BuildFAR()
+		for _, rules := range record.AppRules[:len(request.AppFilters)] {
+			if request.UplinkEndMarkerFlag && rules.UplinkFARID != 0 {
+				uplinkFAR := session.NewFARBuilder().
+					WithID(rules.UplinkFARID).
+					WithMethod(session.Update).
+					WithAction(session.ActionForward).
+					WithDstInterface(pfcpDstInterface(record.UplinkDstInterface, ieLib.DstInterfaceCore)).
+					WithUplinkIP(uplinkDstIp).
+					WithEndMarker(true).
+					BuildFAR()
 
-			newFARs = append(newFARs, downlinkFAR)
+				newFARs = append(newFARs, uplinkFAR)
+			}
 
-			ID += 2
+			if rules.DownlinkFARID == 0 {
+				continue
+			}
+
+			newFARs = append(newFARs, newDownlinkFAR(record, rules.DownlinkFARID, actions, teid, nodeBaddress, request.EndMarkerFlag))
+		}
+
+		var updatedPDRs []*ieLib.IE
+
+		rules, hasRules := activeSessions.Rules(i)
+
+		for k, update := range request.PdrUpdates {
+			if !hasRules {
+				errMsg := fmt.Sprintf("Session with index %v can't update its PDRs: %v", i, errSessionRulesUnknown)
+				log.Error(errMsg)
+				return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+			}
+
+			// the PDR IDs of the sessions are offset as their base IDs
+			pdrID := uint16(int(update.PdrID) - baseID + i)
+
+			updatePDR, pdrs, err := newUpdatePDR(sess, rules.pdrs, pdrID, updateSDFFilters[k], updatePrecedences[k])
+			if err != nil {
+				errMsg := fmt.Sprintf("Session with index %v can't update its PDRs: %v", i, err)
+				log.Error(errMsg)
+				return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+			}
+
+			updatedPDRs = append(updatedPDRs, updatePDR)
+			rules.pdrs = pdrs
 		}
 
-		sess, ok := getSession(i)
+		client, err := peerClient(sessionPeer(i))
+		if err != nil {
+			log.Error(err)
+			return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+		}
+
+		// the rules reported as failed by the previous modifications are not part of the response
+		reported := len(sess.FailedRules())
+
+		err = client.ModifySessionWithContext(ctx, sess, updatedPDRs, newFARs, qers, bar)
+		if err != nil {
+			return &pb.Response{}, rejectionError(ctx, codes.Internal, err.Error(), err)
+		}
+
+		// the session is re-established with the updated PDRs
+		if len(updatedPDRs) > 0 {
+			activeSessions.SetRules(i, rules)
+		}
+
+		failedRules = append(failedRules, newFailedRules(sess.FailedRules()[reported:])...)
+	}
+
+	infoMsg := fmt.Sprintf("%v sessions were modified using %v as baseID, UlAmbr %v DlAmbr %v",
+		count, baseID, request.UlAmbr, request.DlAmbr)
+	if len(failedRules) > 0 {
+		infoMsg += fmt.Sprintf("; %v rules reported as failed by the remote peer", len(failedRules))
+	}
+
+	log.Info(infoMsg)
+
+	return &pb.Response{
+		StatusCode:  int32(codes.OK),
+		Message:     infoMsg,
+		FailedRules: failedRules,
+	}, nil
+}
+
+// queryUsage queries the usage measured by the URRs with urrIDs of count sessions starting from baseID,
+// returning a "usage" report for each Usage Report received from the remote peer.
+func queryUsage(ctx context.Context, baseID int, count int, urrIDs []uint32) (*pb.Response, error) {
+	for _, id := range urrIDs {
+		if id == 0 {
+			errMsg := "URR IDs to query cannot be 0"
+			log.Error(errMsg)
+			return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+		}
+	}
+
+	var usageReports []*pb.SessionReport
+
+	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
+		if err := ctx.Err(); err != nil {
+			errMsg := fmt.Sprintf("Usage query interrupted at baseID %v: %v", i, err)
+			log.Error(errMsg)
+			return &pb.Response{}, contextAwareError(ctx, codes.Internal, errMsg)
+		}
+
+		sess, ok := activeSessions.Get(i)
 		if !ok {
 			errMsg := fmt.Sprintf("Could not retrieve session with index %v", i)
 			log.Error(errMsg)
 			return &pb.Response{}, status.Error(codes.Internal, errMsg)
 		}
 
-		err := sim.ModifySession(sess, nil, newFARs, qers)
+		if sess.IsStale() {
+			errMsg := fmt.Sprintf("Session with index %v is stale: remote peer restarted", i)
+			log.Error(errMsg)
+			return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+		}
+
+		client, err := peerClient(sessionPeer(i))
+		if err != nil {
+			log.Error(err)
+			return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+		}
+
+		reports, err := client.QueryURRs(ctx, sess, urrIDs...)
 		if err != nil {
-			return &pb.Response{}, status.Error(codes.Internal, err.Error())
+			return &pb.Response{}, rejectionError(ctx, codes.Internal, err.Error(), err)
 		}
+
+		usageReports = append(usageReports, newUsageReports(sess.LocalSEID(), reports)...)
 	}
 
-	infoMsg := fmt.Sprintf("%v sessions were modified using %v as baseID, UlAmbr %v DlAmbr %v",
-		count, baseID, request.UlAmbr, request.DlAmbr)
+	infoMsg := fmt.Sprintf("Usage of URRs %v queried for %v sessions using %v as baseID: %v usage reports received",
+		urrIDs, count, baseID, len(usageReports))
 	log.Info(infoMsg)
 
 	return &pb.Response{
-		StatusCode: int32(codes.OK),
-		Message:    infoMsg,
+		StatusCode:   int32(codes.OK),
+		Message:      infoMsg,
+		UsageReports: usageReports,
 	}, nil
 }
 
-func (P pfcpSimService) DeleteSession(ctx context.Context, request *pb.DeleteSessionRequest) (*pb.Response, error) {
+func (P pfcpSimService) DeleteSession(ctx context.Context, request *pb.DeleteSessionRequest) (*pb.DeleteSessionResponse, error) {
 	if err := checkServerStatus(); err != nil {
-		return &pb.Response{}, err
+		return &pb.DeleteSessionResponse{}, err
 	}
 
 	baseID := int(request.BaseID)
 	count := int(request.Count)
 
-	if len(activeSessions) < count {
-		err := pfcpsim.NewNotEnoughSessionsError()
+	if err := checkSessionsExist(baseID, count); err != nil {
 		log.Error(err)
-		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+		return &pb.DeleteSessionResponse{}, status.Error(codes.Aborted, err.Error())
 	}
 
-	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
-		sess, ok := getSession(i)
-		if !ok {
-			errMsg := "Session was nil. Check baseID"
+	var (
+		deleted []int32
+		// errs keep the errors of the sessions not deleted, indexed by their position in the request
+		errs = make(map[int]error)
+	)
+
+	for k := 0; k < count; k++ {
+		i := baseID + k*SessionStep
+
+		if err := ctx.Err(); err != nil {
+			errMsg := fmt.Sprintf("Session deletion interrupted at baseID %v: %v", i, err)
 			log.Error(errMsg)
-			return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+			return &pb.DeleteSessionResponse{}, contextAwareError(ctx, codes.Aborted, errMsg)
 		}
 
-		err := sim.DeleteSession(sess)
+		sess, ok := activeSessions.Get(i)
+		if !ok {
+			errs[k] = fmt.Errorf("%w with baseID %v", errSessionNotActive, i)
+			continue
+		}
+
+		cancelSessionExpiry(i)
+
+		if err := deleteRemoteSession(ctx, sessionPeer(i), sess); err != nil {
+			log.Errorf("Could not delete session with baseID %v: %v", i, err)
+			errs[k] = err
+
+			continue
+		}
+		// remove from activeSessions
+		activeSessions.Delete(i)
+
+		deleted = append(deleted, int32(i))
+	}
+
+	if len(deleted) == 0 {
+		errMsg := fmt.Sprintf("None of the %v sessions could be deleted: %v", count, summarizeSessionErrors(baseID, errs))
+		log.Error(errMsg)
+
+		return &pb.DeleteSessionResponse{}, rejectionError(ctx, codes.Aborted, errMsg, orderedSessionErrors(errs)...)
+	}
+
+	infoMsg := fmt.Sprintf("%v sessions deleted; activeSessions: %v", len(deleted), activeSessions.Len())
+	if len(errs) > 0 {
+		infoMsg += fmt.Sprintf("; %v sessions could not be deleted: %v", len(errs), summarizeSessionErrors(baseID, errs))
+		log.Warn(infoMsg)
+	} else {
+		log.Info(infoMsg)
+	}
+
+	return &pb.DeleteSessionResponse{
+		StatusCode:     int32(codes.OK),
+		Message:        infoMsg,
+		DeletedBaseIDs: deleted,
+		Failures:       newFailedDeletions(baseID, errs),
+		ActiveSessions: int32(activeSessions.Len()),
+	}, nil
+}
+
+func (P pfcpSimService) CreateSessionAndDelete(ctx context.Context, request *pb.CreateSessionAndDeleteRequest) (*pb.CreateSessionAndDeleteResponse, error) {
+	if err := checkServerStatus(); err != nil {
+		return &pb.CreateSessionAndDeleteResponse{}, err
+	}
+
+	baseID := int(request.BaseID)
+	iterations := int(request.Iterations)
+
+	if iterations <= 0 {
+		errMsg := fmt.Sprintf("Iterations must be positive, got %v", iterations)
+		log.Error(errMsg)
+		return &pb.CreateSessionAndDeleteResponse{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	if request.Concurrency < 0 {
+		errMsg := fmt.Sprintf("Concurrency must not be negative, got %v", request.Concurrency)
+		log.Error(errMsg)
+		return &pb.CreateSessionAndDeleteResponse{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	concurrency := 1
+	if request.Concurrency > 1 {
+		concurrency = int(request.Concurrency)
+	}
+
+	if concurrency > iterations {
+		concurrency = iterations
+	}
+
+	if baseID <= 0 || baseID+(concurrency-1)*SessionStep+1 > math.MaxUint16 {
+		errMsg := fmt.Sprintf("BaseID must be between 1 and %v for all the concurrent cycles", math.MaxUint16-1-(concurrency-1)*SessionStep)
+		log.Error(errMsg)
+		return &pb.CreateSessionAndDeleteResponse{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	if err := checkSessionsNotExist(baseID, concurrency); err != nil {
+		log.Error(err)
+		return &pb.CreateSessionAndDeleteResponse{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	client, err := peerClient(request.Peer)
+	if err != nil {
+		log.Error(err)
+		return &pb.CreateSessionAndDeleteResponse{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	ueAddresses, err := allocateUEAddresses([]string{request.UeAddressPool}, concurrency, false)
+	if err != nil {
+		errMsg := fmt.Sprintf("Could not assign UE addresses: %v", err)
+		log.Error(errMsg)
+		return &pb.CreateSessionAndDeleteResponse{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	baseIDs := make([]int, concurrency)
+	rules := make([]sessionRules, concurrency)
+
+	for k := range baseIDs {
+		baseIDs[k] = baseID + k*SessionStep
+
+		if rules[k], err = newCycleSessionRules(baseIDs[k], request.NodeBAddress, ueAddresses[k].String()); err != nil {
+			log.Error(err)
+			return &pb.CreateSessionAndDeleteResponse{}, status.Error(codes.Aborted, err.Error())
+		}
+	}
+
+	// the uplink TEIDs are the base IDs: keep them from being allocated to other sessions meanwhile
+	for _, i := range baseIDs {
+		activeSessions.ReserveTEID(i, uint32(i))
+	}
+
+	defer func() {
+		for _, i := range baseIDs {
+			activeSessions.ReleaseTEID(i)
+		}
+	}()
+
+	start := time.Now()
+	stats := runSessionCycles(ctx, client, iterations, baseIDs, rules)
+	elapsed := time.Since(start)
+
+	if err := ctx.Err(); err != nil {
+		errMsg := fmt.Sprintf("Session cycles interrupted after %v of %v cycles: %v", stats.cycles, iterations, err)
+		log.Error(errMsg)
+		return &pb.CreateSessionAndDeleteResponse{}, contextAwareError(ctx, codes.Aborted, errMsg)
+	}
+
+	cyclesPerSecond := float64(stats.cycles) / elapsed.Seconds()
+
+	infoMsg := fmt.Sprintf("%v sessions were established and deleted using %v as baseID in %v (%.1f cycles/s)",
+		stats.cycles, baseID, elapsed.Round(time.Millisecond), cyclesPerSecond)
+	if stats.establishmentErrors > 0 || stats.deletionErrors > 0 {
+		infoMsg += fmt.Sprintf("; %v sessions could not be established, %v could not be deleted",
+			stats.establishmentErrors, stats.deletionErrors)
+	}
+
+	log.Info(infoMsg)
+
+	return &pb.CreateSessionAndDeleteResponse{
+		StatusCode:          int32(codes.OK),
+		Message:             infoMsg,
+		Cycles:              int32(stats.cycles),
+		EstablishmentErrors: int32(stats.establishmentErrors),
+		DeletionErrors:      int32(stats.deletionErrors),
+		CyclesPerSecond:     cyclesPerSecond,
+	}, nil
+}
+
+func (P pfcpSimService) ClearAllSessions(ctx context.Context, empty *pb.EmptyRequest) (*pb.ClearAllSessionsResponse, error) {
+	if err := checkServerStatus(); err != nil {
+		return &pb.ClearAllSessionsResponse{}, err
+	}
+
+	deleted, failed := clearAllSessions()
+
+	failedBaseIDs := make([]int32, 0, len(failed))
+	for _, i := range failed {
+		failedBaseIDs = append(failedBaseIDs, int32(i))
+	}
+
+	infoMsg := fmt.Sprintf("%v sessions deleted", deleted)
+	if len(failed) > 0 {
+		infoMsg += fmt.Sprintf("; the remote peer failed to delete the sessions with baseIDs %v", failed)
+	}
+
+	log.Info(infoMsg)
+
+	return &pb.ClearAllSessionsResponse{
+		StatusCode:    int32(codes.OK),
+		Message:       infoMsg,
+		Deleted:       int32(deleted),
+		FailedBaseIDs: failedBaseIDs,
+	}, nil
+}
+
+func (P pfcpSimService) DeleteSessionSet(ctx context.Context, empty *pb.EmptyRequest) (*pb.ClearAllSessionsResponse, error) {
+	if err := checkServerStatus(); err != nil {
+		return &pb.ClearAllSessionsResponse{}, err
+	}
+
+	deleted, failed := deleteSessionSets(ctx)
+
+	failedBaseIDs := make([]int32, 0, len(failed))
+	for _, i := range failed {
+		failedBaseIDs = append(failedBaseIDs, int32(i))
+	}
+
+	infoMsg := fmt.Sprintf("%v sessions deleted", deleted)
+	if len(failed) > 0 {
+		infoMsg += fmt.Sprintf("; the remote peer failed to delete the sessions with baseIDs %v", failed)
+	}
+
+	log.Info(infoMsg)
+
+	return &pb.ClearAllSessionsResponse{
+		StatusCode:    int32(codes.OK),
+		Message:       infoMsg,
+		Deleted:       int32(deleted),
+		FailedBaseIDs: failedBaseIDs,
+	}, nil
+}
+
+func (P pfcpSimService) BufferAllSessions(ctx context.Context, request *pb.BufferAllSessionsRequest) (*pb.BufferAllSessionsResponse, error) {
+	if err := checkServerStatus(); err != nil {
+		return &pb.BufferAllSessionsResponse{}, err
+	}
+
+	if request.SuggestedBufferingPacketsCount < 0 || request.SuggestedBufferingPacketsCount > math.MaxUint8 {
+		errMsg := fmt.Sprintf("Suggested buffering packets count must be between 0 and %v", math.MaxUint8)
+		log.Error(errMsg)
+		return &pb.BufferAllSessionsResponse{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	if request.DlDataNotificationDelayMs < 0 {
+		errMsg := "Downlink data notification delay cannot be negative"
+		log.Error(errMsg)
+		return &pb.BufferAllSessionsResponse{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	modified, failed := bufferAllSessions(ctx, request.SuggestedBufferingPacketsCount, request.DlDataNotificationDelayMs)
+
+	failedBaseIDs := make([]int32, 0, len(failed))
+	for _, i := range failed {
+		failedBaseIDs = append(failedBaseIDs, int32(i))
+	}
+
+	infoMsg := fmt.Sprintf("%v sessions are buffering their downlink traffic", modified)
+	if len(failed) > 0 {
+		infoMsg += fmt.Sprintf("; failed to modify the sessions with baseIDs %v", failed)
+	}
+
+	log.Info(infoMsg)
+
+	return &pb.BufferAllSessionsResponse{
+		StatusCode:    int32(codes.OK),
+		Message:       infoMsg,
+		Modified:      int32(modified),
+		FailedBaseIDs: failedBaseIDs,
+	}, nil
+}
+
+func (P pfcpSimService) DumpState(ctx context.Context, request *pb.StateRequest) (*pb.Response, error) {
+	if request.Path == "" {
+		errMsg := "State file path not specified"
+		log.Error(errMsg)
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	dumped, err := dumpState(request.Path)
+	if err != nil {
+		errMsg := fmt.Sprintf("Could not dump state to %v: %v", request.Path, err)
+		log.Error(errMsg)
+		return &pb.Response{}, status.Error(codes.Internal, errMsg)
+	}
+
+	infoMsg := fmt.Sprintf("%v sessions dumped to %v", dumped, request.Path)
+	log.Info(infoMsg)
+
+	return &pb.Response{
+		StatusCode: int32(codes.OK),
+		Message:    infoMsg,
+	}, nil
+}
+
+func (P pfcpSimService) LoadState(ctx context.Context, request *pb.StateRequest) (*pb.Response, error) {
+	if err := checkServerStatus(); err != nil {
+		return &pb.Response{}, err
+	}
+
+	if request.Path == "" {
+		errMsg := "State file path not specified"
+		log.Error(errMsg)
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	loaded, err := loadState(request.Path)
+	if err != nil {
+		errMsg := fmt.Sprintf("Could not load state from %v: %v", request.Path, err)
+		log.Error(errMsg)
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	infoMsg := fmt.Sprintf("%v sessions loaded from %v; activeSessions: %v", loaded, request.Path, activeSessions.Len())
+	log.Info(infoMsg)
+
+	return &pb.Response{
+		StatusCode: int32(codes.OK),
+		Message:    infoMsg,
+	}, nil
+}
+
+func (P pfcpSimService) SendPFDManagement(ctx context.Context, request *pb.PFDManagementRequest) (*pb.Response, error) {
+	if err := checkServerStatus(); err != nil {
+		return &pb.Response{}, err
+	}
+
+	if len(request.Applications) == 0 {
+		errMsg := "No application PFDs provided"
+		log.Error(errMsg)
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	var appPFDs []*ieLib.IE
+
+	for _, app := range request.Applications {
+		appPFD, err := newApplicationPFDs(app)
 		if err != nil {
 			log.Error(err.Error())
 			return &pb.Response{}, status.Error(codes.Aborted, err.Error())
 		}
-		// remove from activeSessions
-		deleteSession(i)
+
+		appPFDs = append(appPFDs, appPFD)
 	}
 
-	infoMsg := fmt.Sprintf("%v sessions deleted; activeSessions: %v", count, len(activeSessions))
+	if err := sim.SendPFDManagement(appPFDs...); err != nil {
+		log.Error(err.Error())
+		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	infoMsg := fmt.Sprintf("PFDs of %v applications were provisioned", len(appPFDs))
 	log.Info(infoMsg)
 
 	return &pb.Response{
@@ -421,3 +1421,183 @@ func (P pfcpSimService) DeleteSession(ctx context.Context, request *pb.DeleteSes
 	}, nil
 }
 
+func (P pfcpSimService) GetPathFailures(ctx context.Context, empty *pb.EmptyRequest) (*pb.PathFailuresResponse, error) {
+	response := &pb.PathFailuresResponse{}
+
+	if sim == nil {
+		return response, nil
+	}
+
+	for _, failure := range sim.UserPlanePathFailures() {
+		log.Warnf("User plane path failure reported by %v towards %v", failure.NodeID, failure.RemotePeers)
+
+		response.Failures = append(response.Failures, &pb.PathFailure{
+			NodeID:      failure.NodeID,
+			RemotePeers: failure.RemotePeers,
+			ReportedAt:  failure.ReportedAt.Format(time.RFC3339),
+		})
+	}
+
+	return response, nil
+}
+
+func (P pfcpSimService) GetUPFunctionFeatures(ctx context.Context, empty *pb.EmptyRequest) (*pb.UPFunctionFeaturesResponse, error) {
+	response := &pb.UPFunctionFeaturesResponse{}
+
+	if sim == nil {
+		return response, nil
+	}
+
+	response.Features = sim.PeerUPFunctionFeatures()
+	response.Names = pfcpsim.UPFunctionFeatureNames(response.Features)
+
+	return response, nil
+}
+
+func (P pfcpSimService) EchoGTPU(ctx context.Context, request *pb.GTPUEchoRequest) (*pb.GTPUEchoResponse, error) {
+	if !isConfigured() {
+		log.Error("Server is not configured")
+		return &pb.GTPUEchoResponse{}, status.Error(codes.Aborted, "Server is not configured")
+	}
+
+	if request.Timeout < 0 {
+		errMsg := fmt.Sprintf("Timeout cannot be a negative number: %v", request.Timeout)
+		log.Error(errMsg)
+
+		return &pb.GTPUEchoResponse{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	timeout := defaultGTPUEchoTimeout
+	if request.Timeout != 0 {
+		timeout = time.Duration(request.Timeout) * time.Millisecond
+	}
+
+	echoCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rtt, err := pfcpsim.GTPUEcho(echoCtx, gtpuLocalAddress(), net.JoinHostPort(upfN3Address, strconv.Itoa(gtpuPort)))
+	if err != nil && echoCtx.Err() == nil {
+		errMsg := fmt.Sprintf("Could not send GTP-U Echo Request: %v", err)
+		log.Error(errMsg)
+
+		return &pb.GTPUEchoResponse{}, status.Error(codes.Internal, errMsg)
+	}
+
+	response := &pb.GTPUEchoResponse{StatusCode: int32(codes.OK)}
+
+	if err != nil {
+		response.Message = fmt.Sprintf("No GTP-U Echo Response from %v within %v", upfN3Address, timeout)
+		log.Warn(response.Message)
+
+		return response, nil
+	}
+
+	response.Reachable = true
+	response.RoundTripTime = rtt.Microseconds()
+	response.Message = fmt.Sprintf("GTP-U Echo Response from %v in %v", upfN3Address, rtt)
+	log.Info(response.Message)
+
+	return response, nil
+}
+
+func (P pfcpSimService) Health(ctx context.Context, empty *pb.EmptyRequest) (*pb.HealthResponse, error) {
+	response := &pb.HealthResponse{
+		Status:         "not configured",
+		Configured:     isConfigured(),
+		ActiveSessions: int32(activeSessions.Len()),
+	}
+
+	if !response.Configured {
+		return response, nil
+	}
+
+	response.Status = "configured"
+
+	if !isAssociated() {
+		return response, nil
+	}
+
+	response.Associated = true
+	response.N4PathUp = sim.IsN4PathUp()
+
+	response.Status = "associated"
+	if !response.N4PathUp {
+		response.Status = "n4 path down"
+	}
+
+	return response, nil
+}
+
+func (P pfcpSimService) Info(ctx context.Context, empty *pb.EmptyRequest) (*pb.InfoResponse, error) {
+	return &pb.InfoResponse{
+		Version:      Version,
+		BuildCommit:  BuildCommit,
+		PfcpFeatures: pfcpFeatures,
+	}, nil
+}
+
+func (P pfcpSimService) AssociationStatus(ctx context.Context, empty *pb.EmptyRequest) (*pb.AssociationStatusResponse, error) {
+	if !isAssociated() {
+		return &pb.AssociationStatusResponse{Message: "Not associated"}, nil
+	}
+
+	associatedAt := sim.AssociatedAt()
+	features := sim.PeerUPFunctionFeatures()
+
+	response := &pb.AssociationStatusResponse{
+		Associated:             true,
+		PeerAddress:            remotePeerAddress,
+		PeerNodeID:             sim.PeerNodeID(),
+		RecoveryTimeStamp:      sim.RecoveryTimeStamp().Format(time.RFC3339),
+		AssociatedAt:           associatedAt.Format(time.RFC3339),
+		AssociatedFor:          time.Since(associatedAt).Milliseconds(),
+		UpFunctionFeatures:     features,
+		UpFunctionFeatureNames: pfcpsim.UPFunctionFeatureNames(features),
+		CpFunctionFeatures:     uint32(sim.CPFunctionFeatures()),
+		N4PathUp:               sim.IsN4PathUp(),
+	}
+
+	if peerRecovery := sim.PeerRecoveryTimeStamp(); !peerRecovery.IsZero() {
+		response.PeerRecoveryTimeStamp = peerRecovery.Format(time.RFC3339)
+	}
+
+	response.Message = fmt.Sprintf("Associated with %v (Node ID %v) for %v",
+		response.PeerAddress, response.PeerNodeID, time.Duration(response.AssociatedFor)*time.Millisecond)
+
+	return response, nil
+}
+
+func (P pfcpSimService) SetLogging(ctx context.Context, request *pb.LoggingRequest) (*pb.Response, error) {
+	if err := setLogging(request.Level, request.Format); err != nil {
+		log.Error(err)
+		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	infoMsg := fmt.Sprintf("Logging level: %v, format: %v", log.GetLevel(), logFormat())
+	log.Info(infoMsg)
+
+	return &pb.Response{
+		StatusCode: int32(codes.OK),
+		Message:    infoMsg,
+	}, nil
+}
+
+func (P pfcpSimService) SubscribeReports(empty *pb.EmptyRequest, stream pb.PFCPSim_SubscribeReportsServer) error {
+	reports := addReportSubscriber()
+	defer removeReportSubscriber(reports)
+
+	log.Info("New subscriber to session reports")
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			log.Info("Subscriber to session reports disconnected")
+			return nil
+		case report := <-reports:
+			if err := stream.Send(report); err != nil {
+				log.Errorf("Could not send session report: %v", err)
+				return status.Error(codes.Aborted, err.Error())
+			}
+		}
+	}
+}
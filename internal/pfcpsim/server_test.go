@@ -0,0 +1,3392 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/ardzoht/pfcpsim/api"
+	"github.com/ardzoht/pfcpsim/internal/fakeupf"
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim/session"
+	"github.com/c-robinson/iplib"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+)
+
+// startServer serves a pfcpSimService on a random local port and returns a client connected to it.
+func startServer(t testing.TB) pb.PFCPSimClient {
+	return pb.NewPFCPSimClient(dialServer(t))
+}
+
+// dialServer serves the pfcpsim services on a random local port and returns a connection to them.
+func dialServer(t testing.TB) *grpc.ClientConn {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer()
+	RegisterServices(grpcServer, "", 0)
+
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		grpcServer.Stop()
+	})
+
+	return conn
+}
+
+func numReportSubscribers() int {
+	lockReportSubscribers.Lock()
+	defer lockReportSubscribers.Unlock()
+
+	return len(reportSubscribers)
+}
+
+func TestSubscribeReports(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	sess, err := sim.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.SubscribeReports(ctx, &pb.EmptyRequest{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return numReportSubscribers() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, upf.Send(message.NewSessionReportRequest(0, 0, sess.LocalSEID(), 1, 0,
+		ie.NewReportType(0, 0, 1, 0),
+		ie.NewUsageReportWithinSessionReportRequest(
+			ie.NewURRID(7),
+			ie.NewURSEQN(1),
+			ie.NewUsageReportTrigger(0, 0, 0),
+			ie.NewVolumeMeasurement(0x07, 300, 100, 200, 0, 0, 0),
+			ie.NewDurationMeasurement(10*time.Second),
+		),
+	)))
+
+	report, err := stream.Recv()
+	require.NoError(t, err)
+
+	require.Equal(t, sess.LocalSEID(), report.Seid)
+	require.Equal(t, reportTypeUsage, report.Type)
+	require.Equal(t, uint32(7), report.UrrID)
+	require.Equal(t, uint64(300), report.TotalVolume)
+	require.Equal(t, uint64(100), report.UplinkVolume)
+	require.Equal(t, uint64(200), report.DownlinkVolume)
+	require.Equal(t, uint32(10), report.Duration)
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		return numReportSubscribers() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSendPFDManagement(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	_, err := client.SendPFDManagement(context.Background(), &pb.PFDManagementRequest{
+		Applications: []*pb.ApplicationPFDs{
+			{
+				ApplicationID:    "app1",
+				FlowDescriptions: []string{"permit out ip from 10.0.0.1 to assigned"},
+				Urls:             []string{"http://example.com"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	reqs := upf.Received(message.MsgTypePFDManagementRequest)
+	require.Len(t, reqs, 1)
+	require.Len(t, reqs[0].(*message.PFDManagementRequest).ApplicationIDsPFDs, 1)
+
+	_, err = client.SendPFDManagement(context.Background(), &pb.PFDManagementRequest{
+		Applications: []*pb.ApplicationPFDs{{FlowDescriptions: []string{"permit out ip from any to assigned"}}},
+	})
+	require.Error(t, err)
+
+	upf.HandleFunc(message.MsgTypePFDManagementRequest, func(req message.Message) message.Message {
+		return message.NewPFDManagementResponse(req.Sequence(), ie.NewCause(ie.CauseRequestRejected), nil)
+	})
+
+	_, err = client.SendPFDManagement(context.Background(), &pb.PFDManagementRequest{
+		Applications: []*pb.ApplicationPFDs{{ApplicationID: "app1"}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf("Request rejected with cause %v", ie.CauseRequestRejected))
+}
+
+func TestGetPathFailures(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	res, err := client.GetPathFailures(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+	require.Empty(t, res.Failures)
+
+	require.NoError(t, upf.Send(message.NewNodeReportRequest(1,
+		upf.NodeID(),
+		ie.NewNodeReportType(0x01),
+		ie.NewUserPlanePathFailureReport(
+			ie.NewRemoteGTPUPeer(0x02, "198.18.0.10", "", 0, ""),
+		),
+	)))
+
+	require.Eventually(t, func() bool {
+		res, err = client.GetPathFailures(context.Background(), &pb.EmptyRequest{})
+		return err == nil && len(res.Failures) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.Equal(t, "127.0.0.1", res.Failures[0].NodeID)
+	require.Equal(t, []string{"198.18.0.10"}, res.Failures[0].RemotePeers)
+}
+
+func TestGetUPFunctionFeatures(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	res, err := client.GetUPFunctionFeatures(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+	require.Empty(t, res.Features)
+	require.Empty(t, res.Names)
+
+	upf.HandleFunc(message.MsgTypeAssociationSetupRequest, func(req message.Message) message.Message {
+		return message.NewAssociationSetupResponse(req.Sequence(),
+			upf.NodeID(),
+			ie.NewCause(ie.CauseRequestAccepted),
+			ie.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()),
+			ie.NewUPFunctionFeatures(0x10, 0x00), // FTUP
+		)
+	})
+
+	_, err = client.Configure(context.Background(), &pb.ConfigureRequest{
+		UpfN3Address:       "198.18.0.1",
+		RemotePeerAddress:  upf.Addr(),
+		CpFunctionFeatures: 0x01, // LOAD
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { cpFunctionFeatures = 0 })
+
+	_, err = client.Associate(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+
+	res, err = client.GetUPFunctionFeatures(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x10, 0x00}, res.Features)
+	require.Equal(t, []string{"FTUP"}, res.Names)
+
+	received := upf.Received(message.MsgTypeAssociationSetupRequest)
+	assocReq := received[len(received)-1].(*message.AssociationSetupRequest)
+	require.True(t, assocReq.CPFunctionFeatures.HasLOAD())
+}
+
+func TestUpdateAssociation(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	upf.HandleFunc(message.MsgTypeAssociationUpdateRequest, func(req message.Message) message.Message {
+		return message.NewAssociationUpdateResponse(req.Sequence(),
+			upf.NodeID(),
+			ie.NewCause(ie.CauseRequestAccepted),
+			ie.NewUPFunctionFeatures(0x10, 0x00), // FTUP
+		)
+	})
+
+	_, err := client.UpdateAssociation(context.Background(), &pb.UpdateAssociationRequest{CpFunctionFeatures: 0x100})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = client.UpdateAssociation(context.Background(), &pb.UpdateAssociationRequest{
+		CpFunctionFeatures:      0x01, // LOAD
+		GracefulReleasePeriodMs: 30000,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, uint8(0x01), sim.CPFunctionFeatures())
+
+	res, err := client.GetUPFunctionFeatures(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"FTUP"}, res.Names)
+
+	updateReq := upf.Received(message.MsgTypeAssociationUpdateRequest)[0].(*message.AssociationUpdateRequest)
+	require.True(t, updateReq.CPFunctionFeatures.HasLOAD())
+	require.NotNil(t, updateReq.GracefulReleasePeriod)
+
+	_, err = client.UpdateAssociation(context.Background(), &pb.UpdateAssociationRequest{Peer: "198.18.0.99"})
+	require.Equal(t, codes.Aborted, status.Code(err))
+}
+
+func TestSetRecoveryTimeStamp(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	lastRecoveryTimeStamp := func() time.Time {
+		received := upf.Received(message.MsgTypeAssociationSetupRequest)
+		assocReq := received[len(received)-1].(*message.AssociationSetupRequest)
+
+		ts, err := assocReq.RecoveryTimeStamp.RecoveryTimeStamp()
+		require.NoError(t, err)
+
+		return ts
+	}
+
+	restartedAt := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := client.SetRecoveryTimeStamp(context.Background(), &pb.RecoveryTimeStampRequest{
+		Timestamp: restartedAt.Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, upf.Received(message.MsgTypeAssociationSetupRequest), 2)
+	require.Equal(t, restartedAt.Unix(), lastRecoveryTimeStamp().Unix())
+	require.Equal(t, restartedAt.Unix(), sim.RecoveryTimeStamp().Unix())
+	require.True(t, sim.IsAssociationAlive())
+
+	_, err = client.SetRecoveryTimeStamp(context.Background(), &pb.RecoveryTimeStampRequest{AdvanceSeconds: 60})
+	require.NoError(t, err)
+	require.Equal(t, restartedAt.Add(time.Minute).Unix(), lastRecoveryTimeStamp().Unix())
+
+	_, err = client.SetRecoveryTimeStamp(context.Background(), &pb.RecoveryTimeStampRequest{Timestamp: "yesterday"})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = client.SetRecoveryTimeStamp(context.Background(), &pb.RecoveryTimeStampRequest{
+		Timestamp:      restartedAt.Format(time.RFC3339),
+		AdvanceSeconds: 60,
+	})
+	require.Equal(t, codes.Aborted, status.Code(err))
+	require.Len(t, upf.Received(message.MsgTypeAssociationSetupRequest), 3)
+}
+
+func TestHealth(t *testing.T) {
+	upf, err := fakeupf.New()
+	require.NoError(t, err)
+
+	client := startServer(t)
+
+	t.Cleanup(func() {
+		sim.DisconnectN4()
+		upf.Close()
+
+		sim = nil
+		setRemotePeerConnected(false)
+		remotePeerAddress = ""
+		upfN3Address = ""
+		maxMissedHeartbeats = pfcpsim.DefaultMaxMissedHeartbeats
+		activeSessions = newSessionStore()
+	})
+
+	health := func() *pb.HealthResponse {
+		res, err := client.Health(context.Background(), &pb.EmptyRequest{})
+		require.NoError(t, err)
+
+		return res
+	}
+
+	res := health()
+	require.Equal(t, "not configured", res.Status)
+	require.False(t, res.Configured)
+	require.False(t, res.Associated)
+
+	_, err = client.Configure(context.Background(), &pb.ConfigureRequest{
+		UpfN3Address:        "198.18.0.1",
+		RemotePeerAddress:   upf.Addr(),
+		MaxMissedHeartbeats: 1,
+	})
+	require.NoError(t, err)
+
+	res = health()
+	require.Equal(t, "configured", res.Status)
+	require.True(t, res.Configured)
+	require.False(t, res.Associated)
+
+	_, err = client.Associate(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+
+	_, err = client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         2,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+	})
+	require.NoError(t, err)
+
+	res = health()
+	require.Equal(t, "associated", res.Status)
+	require.True(t, res.Associated)
+	require.True(t, res.N4PathUp)
+	require.Equal(t, int32(2), res.ActiveSessions)
+
+	// the remote peer stops answering the heartbeats
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message { return nil })
+	sim.SetPFCPResponseTimeout(50 * time.Millisecond)
+	require.Error(t, sim.SendAndRecvHeartbeat())
+
+	res = health()
+	require.Equal(t, "n4 path down", res.Status)
+	require.True(t, res.Associated)
+	require.False(t, res.N4PathUp)
+
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message {
+		return message.NewHeartbeatResponse(req.Sequence(), ie.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()))
+	})
+	require.NoError(t, sim.SendAndRecvHeartbeat())
+	require.Equal(t, "associated", health().Status)
+
+	_, err = client.ClearAllSessions(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+
+	_, err = client.Disassociate(context.Background(), &pb.DisassociateRequest{})
+	require.NoError(t, err)
+
+	res = health()
+	require.Equal(t, "configured", res.Status)
+	require.False(t, res.Associated)
+	require.Zero(t, res.ActiveSessions)
+}
+
+func TestInfo(t *testing.T) {
+	client := startServer(t)
+
+	res, err := client.Info(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Version)
+	require.NotEmpty(t, res.BuildCommit)
+	require.Contains(t, res.PfcpFeatures, "session-establishment")
+}
+
+func TestReflection(t *testing.T) {
+	conn := dialServer(t)
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, service := range res.GetListServicesResponse().GetService() {
+		services = append(services, service.Name)
+	}
+
+	require.Contains(t, services, "api.PFCPSim")
+}
+
+func TestAssociationStatus(t *testing.T) {
+	client := startServer(t)
+
+	res, err := client.AssociationStatus(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+	require.False(t, res.Associated)
+	require.Equal(t, "Not associated", res.Message)
+
+	upf, err := fakeupf.New()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		sim.DisconnectN4()
+		upf.Close()
+
+		sim = nil
+		setRemotePeerConnected(false)
+		remotePeerAddress = ""
+		upfN3Address = ""
+		cpFunctionFeatures = 0
+	})
+
+	upf.HandleFunc(message.MsgTypeAssociationSetupRequest, func(req message.Message) message.Message {
+		return message.NewAssociationSetupResponse(req.Sequence(),
+			ie.NewNodeID("", "", "upf.5gc.local"),
+			ie.NewCause(ie.CauseRequestAccepted),
+			ie.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()),
+			ie.NewUPFunctionFeatures(0x10, 0x00), // FTUP
+		)
+	})
+
+	_, err = client.Configure(context.Background(), &pb.ConfigureRequest{
+		UpfN3Address:       "198.18.0.1",
+		RemotePeerAddress:  upf.Addr(),
+		CpFunctionFeatures: 0x01, // LOAD
+	})
+	require.NoError(t, err)
+
+	before := time.Now()
+
+	_, err = client.Associate(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+
+	res, err = client.AssociationStatus(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+	require.True(t, res.Associated)
+	require.Equal(t, upf.Addr(), res.PeerAddress)
+	require.Equal(t, "upf.5gc.local", res.PeerNodeID)
+	require.Equal(t, sim.RecoveryTimeStamp().Format(time.RFC3339), res.RecoveryTimeStamp)
+	require.Equal(t, upf.RecoveryTimeStamp().Format(time.RFC3339), res.PeerRecoveryTimeStamp)
+	require.Equal(t, []byte{0x10, 0x00}, res.UpFunctionFeatures)
+	require.Equal(t, []string{"FTUP"}, res.UpFunctionFeatureNames)
+	require.Equal(t, uint32(0x01), res.CpFunctionFeatures)
+	require.True(t, res.N4PathUp)
+	require.LessOrEqual(t, res.AssociatedFor, time.Since(before).Milliseconds())
+
+	associatedAt, err := time.Parse(time.RFC3339, res.AssociatedAt)
+	require.NoError(t, err)
+	require.False(t, associatedAt.Before(before.Truncate(time.Second)))
+
+	_, err = client.Disassociate(context.Background(), &pb.DisassociateRequest{})
+	require.NoError(t, err)
+
+	res, err = client.AssociationStatus(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+	require.False(t, res.Associated)
+	require.Empty(t, res.PeerNodeID)
+}
+
+func TestPeerRestartReports(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	sess, err := sim.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+	activeSessions.Insert(1, sess)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.SubscribeReports(ctx, &pb.EmptyRequest{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return numReportSubscribers() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	upf.SetRecoveryTimeStamp(upf.RecoveryTimeStamp().Add(time.Minute))
+	require.Error(t, sim.SendAndRecvHeartbeat())
+
+	report, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, sess.LocalSEID(), report.Seid)
+	require.Equal(t, reportTypePeerRestart, report.Type)
+
+	// The peer no longer knows the stale session: it is only removed locally
+	_, err = client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{Count: 1, BaseID: 1})
+	require.NoError(t, err)
+	require.Empty(t, upf.Received(message.MsgTypeSessionDeletionRequest))
+}
+
+func TestN4PathReports(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	sim.SetPFCPResponseTimeout(50 * time.Millisecond)
+
+	sess, err := sim.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+	activeSessions.Insert(1, sess)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.SubscribeReports(ctx, &pb.EmptyRequest{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return numReportSubscribers() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message {
+		return nil
+	})
+	require.Error(t, sim.SendAndRecvHeartbeat())
+
+	report, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, sess.LocalSEID(), report.Seid)
+	require.Equal(t, reportTypePathFailure, report.Type)
+
+	// sessions can't be managed while the path is down
+	_, err = client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        2,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.Error(t, err)
+
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message {
+		return message.NewHeartbeatResponse(req.Sequence(), ie.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()))
+	})
+	require.NoError(t, sim.SendAndRecvHeartbeat())
+
+	report, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, sess.LocalSEID(), report.Seid)
+	require.Equal(t, reportTypePathRecovery, report.Type)
+	require.True(t, sim.IsAssociationAlive())
+}
+
+func TestReassociationReports(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	sim.SetPFCPResponseTimeout(50 * time.Millisecond)
+	sim.SetAutoReassociation(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.SubscribeReports(ctx, &pb.EmptyRequest{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return numReportSubscribers() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// the peer drops out, then comes back before the second re-association attempt
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message {
+		return nil
+	})
+
+	var setups int32
+
+	upf.HandleFunc(message.MsgTypeAssociationSetupRequest, func(req message.Message) message.Message {
+		if atomic.AddInt32(&setups, 1) == 1 {
+			return nil
+		}
+
+		return message.NewAssociationSetupResponse(req.Sequence(),
+			upf.NodeID(),
+			ie.NewCause(ie.CauseRequestAccepted),
+			ie.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()),
+		)
+	})
+
+	require.Error(t, sim.SendAndRecvHeartbeat())
+
+	for _, expected := range []*pb.SessionReport{
+		{Type: reportTypePathFailure},
+		{Type: reportTypeReassociationFailure, Attempt: 1},
+		{Type: reportTypeReassociation, Attempt: 2},
+	} {
+		report, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, expected.Type, report.Type)
+		require.Equal(t, expected.Attempt, report.Attempt)
+	}
+
+	require.True(t, sim.IsAssociationAlive())
+
+	// sessions can be managed again
+	_, err = client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+}
+
+func TestReestablishOnErrorIndication(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	reestablishOnErrorIndication = true
+	t.Cleanup(func() { reestablishOnErrorIndication = false })
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+
+	sess, ok := activeSessions.Get(1)
+	require.True(t, ok)
+
+	established := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, established, 1)
+
+	require.NoError(t, upf.Send(message.NewSessionReportRequest(0, 0, sess.LocalSEID(), 1, 0,
+		ie.NewReportType(0, 1, 0, 0),
+	)))
+
+	require.Eventually(t, func() bool {
+		return len(upf.Received(message.MsgTypeSessionEstablishmentRequest)) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 1)
+
+	require.Eventually(t, func() bool {
+		newSess, ok := activeSessions.Get(1)
+		return ok && newSess.LocalSEID() != sess.LocalSEID()
+	}, time.Second, 10*time.Millisecond)
+
+	// the session is established again with the same rules
+	reestablished := upf.Received(message.MsgTypeSessionEstablishmentRequest)[1].(*message.SessionEstablishmentRequest)
+	require.Equal(t, established[0].(*message.SessionEstablishmentRequest).CreatePDR, reestablished.CreatePDR)
+	require.Equal(t, established[0].(*message.SessionEstablishmentRequest).CreateFAR, reestablished.CreateFAR)
+}
+
+func TestDisassociate(t *testing.T) {
+	t.Run("release handshake", func(t *testing.T) {
+		upf := setupAssociation(t)
+		client := startServer(t)
+
+		res, err := client.Disassociate(context.Background(), &pb.DisassociateRequest{})
+		require.NoError(t, err)
+		require.Equal(t, uint32(ie.CauseRequestAccepted), res.Cause)
+		require.False(t, isRemotePeerConnected())
+		require.Len(t, upf.Received(message.MsgTypeAssociationReleaseRequest), 1)
+	})
+
+	t.Run("fallback when peer does not answer", func(t *testing.T) {
+		upf := setupAssociation(t)
+		client := startServer(t)
+
+		sim.SetPFCPResponseTimeout(100 * time.Millisecond)
+		upf.HandleFunc(message.MsgTypeAssociationReleaseRequest, func(req message.Message) message.Message {
+			return nil
+		})
+
+		res, err := client.Disassociate(context.Background(), &pb.DisassociateRequest{})
+		require.NoError(t, err)
+		require.Zero(t, res.Cause)
+		require.False(t, isRemotePeerConnected())
+	})
+
+	createSessions := func(t *testing.T, client pb.PFCPSimClient) {
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:         2,
+			BaseID:        1,
+			NodeBAddress:  "198.18.0.10",
+			UeAddressPool: "17.0.0.0/24",
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, activeSessions.Len())
+	}
+
+	t.Run("forget sessions", func(t *testing.T) {
+		setupAssociation(t)
+		client := startServer(t)
+
+		createSessions(t, client)
+
+		_, err := client.Disassociate(context.Background(), &pb.DisassociateRequest{})
+		require.NoError(t, err)
+		require.Zero(t, activeSessions.Len())
+	})
+
+	t.Run("keep sessions", func(t *testing.T) {
+		upf := setupAssociation(t)
+		client := startServer(t)
+
+		createSessions(t, client)
+
+		_, err := client.Disassociate(context.Background(), &pb.DisassociateRequest{KeepSessions: true})
+		require.NoError(t, err)
+		require.False(t, isRemotePeerConnected())
+		require.Equal(t, 2, activeSessions.Len())
+
+		// the sessions can be managed again once associated anew
+		_, err = client.Associate(context.Background(), &pb.EmptyRequest{})
+		require.NoError(t, err)
+
+		_, err = client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{Count: 2, BaseID: 1})
+		require.NoError(t, err)
+		require.Zero(t, activeSessions.Len())
+		require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 2)
+	})
+}
+
+func TestShutdown(t *testing.T) {
+	t.Run("release association", func(t *testing.T) {
+		upf := setupAssociation(t)
+
+		Shutdown(true)
+
+		require.False(t, isRemotePeerConnected())
+		require.Len(t, upf.Received(message.MsgTypeAssociationReleaseRequest), 1)
+	})
+
+	t.Run("keep association", func(t *testing.T) {
+		upf := setupAssociation(t)
+
+		Shutdown(false)
+
+		require.False(t, isRemotePeerConnected())
+		require.Empty(t, upf.Received(message.MsgTypeAssociationReleaseRequest))
+	})
+}
+
+// establishedUEAddresses returns the UE addresses of the downlink PDRs sent by the simulator, in order.
+func establishedUEAddresses(t *testing.T, upf *fakeupf.FakeUPF) []string {
+	var addresses []string
+
+	for _, msg := range upf.Received(message.MsgTypeSessionEstablishmentRequest) {
+		for _, pdr := range msg.(*message.SessionEstablishmentRequest).CreatePDR {
+			ueIP, err := pdr.UEIPAddress()
+			if err != nil {
+				continue
+			}
+
+			addresses = append(addresses, ueIP.IPv4Address.String())
+		}
+	}
+
+	return addresses
+}
+
+func TestCreateSessionWithMultipleUEPools(t *testing.T) {
+	t.Run("round-robin distribution", func(t *testing.T) {
+		upf := setupAssociation(t)
+		client := startServer(t)
+
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:                    4,
+			BaseID:                   1,
+			NodeBAddress:             "198.18.0.10",
+			UeAddressPools:           []string{"17.0.0.0/24", "18.0.0.0/24"},
+			UeAddressPoolsRoundRobin: true,
+			AppFilters:               []string{"ip:any:any:allow:100"},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"17.0.0.1", "18.0.0.1", "17.0.0.2", "18.0.0.2"}, establishedUEAddresses(t, upf))
+	})
+
+	t.Run("addresses returned by base ID", func(t *testing.T) {
+		setupAssociation(t)
+		client := startServer(t)
+
+		res, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:                    4,
+			BaseID:                   1,
+			NodeBAddress:             "198.18.0.10",
+			UeAddressPools:           []string{"17.0.0.0/24", "18.0.0.0/24"},
+			UeAddressPoolsRoundRobin: true,
+			AppFilters:               []string{"ip:any:any:allow:100"},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Sessions, 4)
+
+		// each pool hands out its addresses in iplib order, starting after the network address
+		next := []net.IP{net.ParseIP("17.0.0.0"), net.ParseIP("18.0.0.0")}
+
+		for k, created := range res.Sessions {
+			next[k%2] = iplib.NextIP(next[k%2])
+
+			require.Equal(t, int32(1+k*SessionStep), created.BaseID)
+			require.Equal(t, next[k%2].String(), created.UeAddress)
+		}
+	})
+
+	t.Run("pools exhausted", func(t *testing.T) {
+		upf := setupAssociation(t)
+		client := startServer(t)
+
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:          8,
+			BaseID:         1,
+			NodeBAddress:   "198.18.0.10",
+			UeAddressPool:  "17.0.0.0/30",
+			UeAddressPools: []string{"18.0.0.0/30"},
+			AppFilters:     []string{"ip:any:any:allow:100"},
+		})
+		require.Error(t, err)
+		require.Empty(t, upf.Received(message.MsgTypeSessionEstablishmentRequest))
+	})
+}
+
+func TestCreateSessionDirection(t *testing.T) {
+	tests := []struct {
+		name             string
+		direction        pb.Direction
+		expectedPDRs     int
+		expectedFARs     int
+		expectedQERs     int
+		expectedSrcIface uint8
+	}{
+		{name: "both", direction: pb.Direction_BOTH, expectedPDRs: 2, expectedFARs: 2, expectedQERs: 3},
+		{name: "uplink", direction: pb.Direction_UPLINK, expectedPDRs: 1, expectedFARs: 1, expectedQERs: 2,
+			expectedSrcIface: ie.SrcInterfaceAccess},
+		{name: "downlink", direction: pb.Direction_DOWNLINK, expectedPDRs: 1, expectedFARs: 1, expectedQERs: 2,
+			expectedSrcIface: ie.SrcInterfaceCore},
+	}
+
+	for _, scenario := range tests {
+		t.Run(scenario.name, func(t *testing.T) {
+			upf := setupAssociation(t)
+			client := startServer(t)
+
+			_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+				Count:         1,
+				BaseID:        1,
+				NodeBAddress:  "198.18.0.10",
+				UeAddressPool: "17.0.0.0/24",
+				AppFilters:    []string{"ip:any:any:allow:100"},
+				UlAmbr:        1000,
+				DlAmbr:        1000,
+				Direction:     scenario.direction,
+			})
+			require.NoError(t, err)
+
+			received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+			require.Len(t, received, 1)
+
+			req := received[0].(*message.SessionEstablishmentRequest)
+			require.Len(t, req.CreatePDR, scenario.expectedPDRs)
+			require.Len(t, req.CreateFAR, scenario.expectedFARs)
+			// the session QER is always created
+			require.Len(t, req.CreateQER, scenario.expectedQERs)
+
+			if scenario.direction == pb.Direction_BOTH {
+				return
+			}
+
+			srcIface, err := req.CreatePDR[0].SourceInterface()
+			require.NoError(t, err)
+			require.Equal(t, scenario.expectedSrcIface, srcIface)
+		})
+	}
+}
+
+func TestCreateSessionDenyFilter(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:10.0.0.0/8:any:deny:10", "ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, received, 1)
+
+	fars := received[0].(*message.SessionEstablishmentRequest).CreateFAR
+	require.Len(t, fars, 4)
+
+	// uplink and downlink FARs of the deny filter come first
+	expectedActions := []uint8{session.ActionDrop, session.ActionDrop, session.ActionForward, session.ActionForward}
+
+	for i, far := range fars {
+		applyAction, err := far.ApplyAction()
+		require.NoError(t, err)
+		require.Equal(t, expectedActions[i], applyAction, "unexpected apply action for FAR %v", i)
+	}
+}
+
+func TestCreateSessionPerAppFilterQFI(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:          1,
+		BaseID:         1,
+		NodeBAddress:   "198.18.0.10",
+		UeAddressPool:  "17.0.0.0/24",
+		AppFilters:     []string{"udp:10.0.0.0/8:80:allow:10:5", "ip:any:any:allow:100"},
+		Qfi:            9,
+		SkipSessionQER: true,
+	})
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, received, 1)
+
+	qers := received[0].(*message.SessionEstablishmentRequest).CreateQER
+	require.Len(t, qers, 4)
+
+	// uplink and downlink QERs of the first filter use its own QFI, the others fall back to the request one
+	expectedQFIs := map[uint32]uint8{1: 5, 2: 5, 3: 9, 4: 9}
+
+	for _, qer := range qers {
+		id, err := qer.QERID()
+		require.NoError(t, err)
+
+		qfi, err := qer.QFI()
+		require.NoError(t, err)
+		require.Equal(t, expectedQFIs[id], qfi, "unexpected QFI for QER %v", id)
+	}
+}
+
+func TestCreateSessionPassThrough(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+	})
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, received, 1)
+
+	estReq := received[0].(*message.SessionEstablishmentRequest)
+	require.Len(t, estReq.CreatePDR, 2)
+	require.Len(t, estReq.CreateFAR, 2)
+
+	// the session QER is the only one, even without AMBR
+	require.Len(t, estReq.CreateQER, 1)
+
+	sessQerID, err := estReq.CreateQER[0].QERID()
+	require.NoError(t, err)
+
+	_, err = estReq.CreateQER[0].MBRUL()
+	require.Error(t, err)
+
+	for _, pdr := range estReq.CreatePDR {
+		sdf, err := pdr.SDFFilter()
+		require.NoError(t, err)
+		require.Contains(t, sdf.FlowDescription, "from any")
+
+		children, err := pdr.CreatePDR()
+		require.NoError(t, err)
+
+		var qerIDs []uint32
+
+		for _, child := range children {
+			if child.Type != ie.QERID {
+				continue
+			}
+
+			id, err := child.QERID()
+			require.NoError(t, err)
+
+			qerIDs = append(qerIDs, id)
+		}
+
+		require.Equal(t, []uint32{sessQerID}, qerIDs)
+	}
+
+	t.Run("without session QER", func(t *testing.T) {
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:          1,
+			BaseID:         1 + SessionStep,
+			NodeBAddress:   "198.18.0.10",
+			UeAddressPool:  "17.0.0.0/24",
+			SkipSessionQER: true,
+		})
+		require.Equal(t, codes.Aborted, status.Code(err))
+	})
+}
+
+func TestCreateSessionSEIDAllocation(t *testing.T) {
+	for _, allocation := range []pb.SeidAllocation{
+		pb.SeidAllocation_SEID_SEQUENTIAL,
+		pb.SeidAllocation_SEID_RANDOM,
+		pb.SeidAllocation_SEID_BASE_ID,
+	} {
+		allocation := allocation
+
+		t.Run(allocation.String(), func(t *testing.T) {
+			seidAllocation = allocation
+			t.Cleanup(func() {
+				seidAllocation = pb.SeidAllocation_SEID_SEQUENTIAL
+			})
+
+			setupAssociation(t)
+			applySEIDAllocation(sim)
+
+			client := startServer(t)
+
+			res, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+				Count:         5,
+				BaseID:        10,
+				NodeBAddress:  "198.18.0.10",
+				UeAddressPool: "17.0.0.0/24",
+				AppFilters:    []string{"ip:any:any:allow:100"},
+			})
+			require.NoError(t, err)
+			require.Len(t, res.Sessions, 5)
+
+			seids := make(map[uint64]struct{})
+
+			for _, created := range res.Sessions {
+				sess, ok := activeSessions.Get(int(created.BaseID))
+				require.True(t, ok)
+				require.Equal(t, created.LocalSEID, sess.LocalSEID())
+
+				if allocation == pb.SeidAllocation_SEID_BASE_ID {
+					require.Equal(t, uint64(created.BaseID), created.LocalSEID)
+				}
+
+				seids[created.LocalSEID] = struct{}{}
+			}
+
+			require.Len(t, seids, 5)
+		})
+	}
+}
+
+func TestCreateSessionDstInterface(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	request := &pb.CreateSessionRequest{
+		Count:              1,
+		BaseID:             1,
+		NodeBAddress:       "198.18.0.10",
+		UeAddressPool:      "17.0.0.0/24",
+		AppFilters:         []string{"ip:any:any:allow:100"},
+		UplinkDstInterface: pb.DestinationInterface_DESTINATION_SGI_LAN,
+	}
+
+	_, err := client.CreateSession(context.Background(), request)
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, received, 1)
+
+	// the downlink FAR keeps the default destination interface
+	expected := map[uint32]uint8{1: ie.DstInterfaceSGiLANN6LAN, 2: ie.DstInterfaceAccess}
+
+	fars := received[0].(*message.SessionEstablishmentRequest).CreateFAR
+	require.Len(t, fars, 2)
+
+	for _, far := range fars {
+		id, err := far.FARID()
+		require.NoError(t, err)
+
+		var iface uint8
+
+		// go-pfcp reads the Destination Interface from the Forwarding Parameters, not from the Create FAR
+		for _, child := range far.ChildIEs {
+			if child.Type == ie.ForwardingParameters {
+				iface, err = child.DestinationInterface()
+				require.NoError(t, err)
+			}
+		}
+
+		require.Equal(t, expected[id], iface, "unexpected destination interface for FAR %v", id)
+	}
+
+	t.Run("unknown interface", func(t *testing.T) {
+		request.BaseID += SessionStep
+		request.DownlinkDstInterface = pb.DestinationInterface(42)
+
+		_, err := client.CreateSession(context.Background(), request)
+		require.Equal(t, codes.Aborted, status.Code(err))
+		require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 1)
+	})
+}
+
+func TestCreateSessionPerAppFilterMBR(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	request := &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters: []string{
+			"udp:10.0.0.0/8:80:allow:10:5:50000:200000",
+			"tcp:10.0.0.0/8:443:allow:20:5:100:500",
+			"ip:any:any:allow:100",
+		},
+		SkipSessionQER: true,
+		AppUlMbr:       1000,
+		AppDlMbr:       2000,
+	}
+
+	_, err := client.CreateSession(context.Background(), request)
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, received, 1)
+
+	qers := received[0].(*message.SessionEstablishmentRequest).CreateQER
+	require.Len(t, qers, 6)
+
+	// the QERs of the filters specifying their MBRs use them, the others fall back to the request ones
+	expectedMBRs := map[uint32][2]uint64{
+		1: {50000, 200000}, 2: {50000, 200000},
+		3: {100, 500}, 4: {100, 500},
+		5: {1000, 2000}, 6: {1000, 2000},
+	}
+
+	for _, qer := range qers {
+		id, err := qer.QERID()
+		require.NoError(t, err)
+
+		ulMbr, err := qer.MBRUL()
+		require.NoError(t, err)
+
+		dlMbr, err := qer.MBRDL()
+		require.NoError(t, err)
+
+		require.Equal(t, expectedMBRs[id], [2]uint64{ulMbr, dlMbr}, "unexpected MBRs for QER %v", id)
+	}
+
+	t.Run("no MBR", func(t *testing.T) {
+		request.BaseID += SessionStep
+		request.AppFilters = []string{"ip:any:any:allow:100"}
+		request.AppUlMbr, request.AppDlMbr = 0, 0
+
+		_, err := client.CreateSession(context.Background(), request)
+		require.NoError(t, err)
+
+		received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+		require.Len(t, received, 2)
+
+		for _, qer := range received[1].(*message.SessionEstablishmentRequest).CreateQER {
+			_, err := qer.MBRUL()
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("negative MBR", func(t *testing.T) {
+		request.BaseID += SessionStep
+		request.AppUlMbr = -1
+
+		_, err := client.CreateSession(context.Background(), request)
+		require.Error(t, err)
+		require.Equal(t, codes.Aborted, status.Code(err))
+	})
+}
+
+func TestCreateSessionPrecedenceOrder(t *testing.T) {
+	filters := []string{"udp:10.0.0.0/8:80:allow", "tcp:any:any:allow", "ip:any:any:allow:10"}
+
+	tests := []struct {
+		name  string
+		order pb.PrecedenceOrder
+		// expected precedence of the uplink and downlink PDRs of each filter
+		expected []uint32
+	}{
+		{name: "default", order: pb.PrecedenceOrder_PRECEDENCE_DEFAULT, expected: []uint32{100, 100, 10}},
+		{name: "increasing", order: pb.PrecedenceOrder_PRECEDENCE_INCREASING, expected: []uint32{100, 101, 10}},
+		{name: "decreasing", order: pb.PrecedenceOrder_PRECEDENCE_DECREASING, expected: []uint32{100, 99, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upf := setupAssociation(t)
+
+			_, err := NewPFCPSimService("", 0).CreateSession(context.Background(), &pb.CreateSessionRequest{
+				Count:           1,
+				BaseID:          1,
+				NodeBAddress:    "198.18.0.10",
+				UeAddressPool:   "17.0.0.0/24",
+				AppFilters:      filters,
+				PrecedenceOrder: tt.order,
+			})
+			require.NoError(t, err)
+
+			received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+			require.Len(t, received, 1)
+
+			pdrs := received[0].(*message.SessionEstablishmentRequest).CreatePDR
+			require.Len(t, pdrs, 2*len(filters))
+
+			for k, pdr := range pdrs {
+				precedence, err := pdr.Precedence()
+				require.NoError(t, err)
+				require.Equal(t, tt.expected[k/2], precedence, "unexpected precedence for PDR %v", k)
+			}
+		})
+	}
+}
+
+func TestCreateSessionDirectionalSDF(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	request := &pb.CreateSessionRequest{
+		Count:              1,
+		BaseID:             1,
+		NodeBAddress:       "198.18.0.10",
+		UeAddressPool:      "17.0.0.0/24",
+		AppFilters:         []string{"udp:10.0.0.0/8:80:allow:100"},
+		DirectionalSDFFlag: true,
+	}
+
+	_, err := client.CreateSession(context.Background(), request)
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, received, 1)
+
+	filters := make(map[uint16]string)
+
+	for _, pdr := range received[0].(*message.SessionEstablishmentRequest).CreatePDR {
+		id, err := pdr.PDRID()
+		require.NoError(t, err)
+
+		sdf, err := pdr.SDFFilter()
+		require.NoError(t, err)
+
+		filters[id] = sdf.FlowDescription
+	}
+
+	require.Equal(t, map[uint16]string{
+		1: "permit out udp from 10.0.0.0/8 to assigned 80",
+		2: "permit out udp from assigned 80 to 10.0.0.0/8",
+	}, filters)
+
+	request.BaseID = 2
+	request.BidirectionalSDFFlag = true
+
+	_, err = client.CreateSession(context.Background(), request)
+	require.Equal(t, codes.Aborted, status.Code(err))
+}
+
+func TestCreateSessionDryRun(t *testing.T) {
+	newRequest := func(dryRun bool) *pb.CreateSessionRequest {
+		return &pb.CreateSessionRequest{
+			Count:         2,
+			BaseID:        1,
+			NodeBAddress:  "198.18.0.10",
+			UeAddressPool: "17.0.0.0/24",
+			AppFilters:    []string{"udp:10.0.0.0/8:80:allow:100"},
+			DryRun:        dryRun,
+		}
+	}
+
+	checkDryRun := func(t *testing.T, res *pb.CreateSessionResponse) {
+		require.Len(t, res.Sessions, 2)
+
+		for k, created := range res.Sessions {
+			baseID := 1 + k*SessionStep
+			require.Equal(t, int32(baseID), created.BaseID)
+			require.Equal(t, uint32(baseID), created.UplinkTEID)
+			require.NotEmpty(t, created.UeAddress)
+
+			payload, err := hex.DecodeString(created.EstablishmentRequest)
+			require.NoError(t, err)
+
+			msg, err := message.Parse(payload)
+			require.NoError(t, err)
+
+			estReq, ok := msg.(*message.SessionEstablishmentRequest)
+			require.True(t, ok)
+			require.Len(t, estReq.CreatePDR, 2)
+			require.Len(t, estReq.CreateFAR, 2)
+			require.Len(t, estReq.CreateQER, 2)
+
+			fseid, err := estReq.CPFSEID.FSEID()
+			require.NoError(t, err)
+			require.Equal(t, uint64(baseID), fseid.SEID)
+		}
+
+		require.Zero(t, activeSessions.Len())
+
+		_, reserved := activeSessions.TEID(1)
+		require.False(t, reserved)
+	}
+
+	t.Run("associated", func(t *testing.T) {
+		upf := setupAssociation(t)
+		client := startServer(t)
+
+		res, err := client.CreateSession(context.Background(), newRequest(true))
+		require.NoError(t, err)
+
+		checkDryRun(t, res)
+		require.Empty(t, upf.Received(message.MsgTypeSessionEstablishmentRequest))
+
+		// the sessions can then be established for real
+		res, err = client.CreateSession(context.Background(), newRequest(false))
+		require.NoError(t, err)
+		require.Empty(t, res.Sessions[0].EstablishmentRequest)
+		require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 2)
+	})
+
+	t.Run("not associated", func(t *testing.T) {
+		client := startServer(t)
+
+		_, err := client.CreateSession(context.Background(), newRequest(true))
+		require.Equal(t, codes.Aborted, status.Code(err))
+
+		remotePeerAddress = "127.0.0.1"
+		upfN3Address = "198.18.0.1"
+		localN4Address = "127.0.0.1"
+
+		t.Cleanup(func() {
+			remotePeerAddress = ""
+			upfN3Address = ""
+			localN4Address = ""
+		})
+
+		res, err := client.CreateSession(context.Background(), newRequest(true))
+		require.NoError(t, err)
+
+		checkDryRun(t, res)
+	})
+}
+
+func TestCreateSessionResponse(t *testing.T) {
+	setupAssociation(t)
+	client := startServer(t)
+
+	res, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         3,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+		Concurrency:   2,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Message)
+	require.Len(t, res.Sessions, 3)
+
+	for k, created := range res.Sessions {
+		require.Equal(t, int32(1+k*SessionStep), created.BaseID)
+		require.Equal(t, sim.LocalAddr(), created.LocalAddress)
+		require.Equal(t, fmt.Sprintf("17.0.0.%v", k+1), created.UeAddress)
+
+		sess, ok := activeSessions.Get(int(created.BaseID))
+		require.True(t, ok)
+		require.Equal(t, sess.LocalSEID(), created.LocalSEID)
+	}
+}
+
+func TestCreateSessionPDNType(t *testing.T) {
+	tests := []struct {
+		name         string
+		pdnType      pb.PdnType
+		expectedIPv4 string
+		expectedIPv6 string
+		expectedPDN  uint8
+	}{
+		{name: "IPv4", pdnType: pb.PdnType_IPV4, expectedIPv4: "17.0.0.1", expectedPDN: ie.PDNTypeIPv4},
+		{name: "IPv6", pdnType: pb.PdnType_IPV6, expectedIPv6: "2001:db8:1::1", expectedPDN: ie.PDNTypeIPv6},
+		{name: "dual-stack", pdnType: pb.PdnType_IPV4V6, expectedIPv4: "17.0.0.1", expectedIPv6: "2001:db8:1::1",
+			expectedPDN: ie.PDNTypeIPv4v6},
+	}
+
+	for _, scenario := range tests {
+		t.Run(scenario.name, func(t *testing.T) {
+			upf := setupAssociation(t)
+			client := startServer(t)
+
+			res, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+				Count:             1,
+				BaseID:            1,
+				NodeBAddress:      "198.18.0.10",
+				UeAddressPool:     "17.0.0.0/24",
+				UeIPv6AddressPool: "2001:db8:1::/64",
+				PdnType:           scenario.pdnType,
+				AppFilters:        []string{"ip:any:any:allow:100"},
+			})
+			require.NoError(t, err)
+			require.Len(t, res.Sessions, 1)
+			require.Equal(t, scenario.expectedIPv4, res.Sessions[0].UeAddress)
+			require.Equal(t, scenario.expectedIPv6, res.Sessions[0].UeIPv6Address)
+
+			received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+			require.Len(t, received, 1)
+
+			pdnType, err := received[0].(*message.SessionEstablishmentRequest).PDNType.PDNType()
+			require.NoError(t, err)
+			require.Equal(t, scenario.expectedPDN, pdnType)
+
+			var ueIP *ie.UEIPAddressFields
+
+			for _, pdr := range received[0].(*message.SessionEstablishmentRequest).CreatePDR {
+				if fields, err := pdr.UEIPAddress(); err == nil {
+					ueIP = fields
+				}
+			}
+
+			require.NotNil(t, ueIP, "no downlink PDR with UE IP Address")
+			require.Equal(t, scenario.expectedIPv4 != "", ueIP.IPv4Address != nil)
+			require.Equal(t, scenario.expectedIPv6 != "", ueIP.IPv6Address != nil)
+
+			if scenario.expectedIPv4 != "" {
+				require.Equal(t, scenario.expectedIPv4, ueIP.IPv4Address.String())
+			}
+
+			if scenario.expectedIPv6 != "" {
+				require.Equal(t, scenario.expectedIPv6, ueIP.IPv6Address.String())
+			}
+		})
+	}
+
+	t.Run("IPv4 pool used as IPv6 pool", func(t *testing.T) {
+		setupAssociation(t)
+		client := startServer(t)
+
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:             1,
+			BaseID:            1,
+			NodeBAddress:      "198.18.0.10",
+			UeIPv6AddressPool: "17.0.0.0/24",
+			PdnType:           pb.PdnType_IPV6,
+			AppFilters:        []string{"ip:any:any:allow:100"},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestCreateSessionSkipSessionQER(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:          1,
+		BaseID:         1,
+		NodeBAddress:   "198.18.0.10",
+		UeAddressPool:  "17.0.0.0/24",
+		AppFilters:     []string{"ip:any:any:allow:100", "udp:10.0.0.0/8:80:allow:200"},
+		UlAmbr:         1000,
+		DlAmbr:         2000,
+		SkipSessionQER: true,
+	})
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, received, 1)
+
+	req := received[0].(*message.SessionEstablishmentRequest)
+
+	var qerIDs []uint32
+
+	for _, qer := range req.CreateQER {
+		id, err := qer.QERID()
+		require.NoError(t, err)
+
+		qerIDs = append(qerIDs, id)
+	}
+
+	require.ElementsMatch(t, []uint32{1, 2, 3, 4}, qerIDs)
+
+	var referencedQERIDs []uint32
+
+	for _, pdr := range req.CreatePDR {
+		children, err := pdr.CreatePDR()
+		require.NoError(t, err)
+
+		for _, child := range children {
+			if child.Type != ie.QERID {
+				continue
+			}
+
+			id, err := child.QERID()
+			require.NoError(t, err)
+
+			referencedQERIDs = append(referencedQERIDs, id)
+		}
+	}
+
+	require.ElementsMatch(t, []uint32{1, 2, 3, 4}, referencedQERIDs)
+}
+
+func TestCreateSessionQERBitrates(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	request := &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+		UlAmbr:        1000,
+		DlAmbr:        2000,
+		UlGbr:         300,
+		DlGbr:         400,
+	}
+
+	_, err := client.CreateSession(context.Background(), request)
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, received, 1)
+
+	var sessQER *ie.IE
+
+	for _, qer := range received[0].(*message.SessionEstablishmentRequest).CreateQER {
+		if id, err := qer.QERID(); err == nil && id == 100 {
+			sessQER = qer
+		}
+	}
+
+	require.NotNil(t, sessQER, "no session QER")
+
+	for expected, bitrate := range map[uint64]func() (uint64, error){
+		1000: sessQER.MBRUL, 2000: sessQER.MBRDL, 300: sessQER.GBRUL, 400: sessQER.GBRDL,
+	} {
+		value, err := bitrate()
+		require.NoError(t, err)
+		require.Equal(t, expected, value)
+	}
+
+	tests := []struct {
+		name         string
+		ulGbr, dlGbr int32
+		skip         bool
+	}{
+		{name: "negative GBR", ulGbr: -1},
+		{name: "GBR exceeding AMBR", ulGbr: 300, dlGbr: 3000},
+		{name: "no session QER", ulGbr: 300, dlGbr: 400, skip: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request.BaseID += SessionStep
+			request.UlGbr, request.DlGbr, request.SkipSessionQER = tt.ulGbr, tt.dlGbr, tt.skip
+
+			_, err := client.CreateSession(context.Background(), request)
+			require.Error(t, err)
+			require.Equal(t, codes.Aborted, status.Code(err))
+		})
+	}
+}
+
+func TestCreateSessionQEROrder(t *testing.T) {
+	for _, appQERFirst := range []bool{false, true} {
+		t.Run(fmt.Sprintf("app QER first %v", appQERFirst), func(t *testing.T) {
+			upf := setupAssociation(t)
+
+			_, err := NewPFCPSimService("", 0).CreateSession(context.Background(), &pb.CreateSessionRequest{
+				Count:         1,
+				BaseID:        1,
+				NodeBAddress:  "198.18.0.10",
+				UeAddressPool: "17.0.0.0/24",
+				AppFilters:    []string{"ip:any:any:allow:100"},
+				UlAmbr:        1000,
+				DlAmbr:        2000,
+				AppQERFirst:   appQERFirst,
+			})
+			require.NoError(t, err)
+
+			received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+			require.Len(t, received, 1)
+
+			req := received[0].(*message.SessionEstablishmentRequest)
+
+			// the session QER is the first created, followed by the application ones
+			sessQerID, err := req.CreateQER[0].QERID()
+			require.NoError(t, err)
+
+			for _, pdr := range req.CreatePDR {
+				children, err := pdr.CreatePDR()
+				require.NoError(t, err)
+
+				var qerIDs []uint32
+
+				for _, child := range children {
+					if child.Type != ie.QERID {
+						continue
+					}
+
+					id, err := child.QERID()
+					require.NoError(t, err)
+
+					qerIDs = append(qerIDs, id)
+				}
+
+				require.Len(t, qerIDs, 2)
+
+				if appQERFirst {
+					require.Equal(t, sessQerID, qerIDs[1])
+				} else {
+					require.Equal(t, sessQerID, qerIDs[0])
+				}
+			}
+		})
+	}
+}
+
+func TestCreateSessionTEIDAllocation(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	create := func(baseID, count int32, allocation pb.TeidAllocation) []uint32 {
+		res, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:          count,
+			BaseID:         baseID,
+			NodeBAddress:   "198.18.0.10",
+			UeAddressPool:  "17.0.0.0/24",
+			AppFilters:     []string{"ip:any:any:allow:100"},
+			TeidAllocation: allocation,
+		})
+		require.NoError(t, err)
+
+		var teids []uint32
+		for _, sess := range res.Sessions {
+			teids = append(teids, sess.UplinkTEID)
+		}
+
+		return teids
+	}
+
+	// per-session TEIDs are the base IDs
+	require.Equal(t, []uint32{2, 12}, create(2, 2, pb.TeidAllocation_PER_SESSION))
+
+	// global TEIDs avoid those already in use, also across requests
+	require.Equal(t, []uint32{1, 3, 4}, create(101, 3, pb.TeidAllocation_GLOBAL))
+	require.Equal(t, []uint32{5, 6}, create(201, 2, pb.TeidAllocation_GLOBAL))
+
+	// the TEIDs are sent in the uplink PDRs
+	var sent []uint32
+
+	for _, req := range upf.Received(message.MsgTypeSessionEstablishmentRequest) {
+		for _, pdr := range req.(*message.SessionEstablishmentRequest).CreatePDR {
+			pdi, err := pdr.PDI()
+			require.NoError(t, err)
+
+			for _, child := range pdi {
+				if child.Type != ie.FTEID {
+					continue
+				}
+
+				fteid, err := child.FTEID()
+				require.NoError(t, err)
+
+				sent = append(sent, fteid.TEID)
+			}
+		}
+	}
+
+	require.Equal(t, []uint32{2, 12, 1, 3, 4, 5, 6}, sent)
+
+	// TEIDs are freed on delete
+	_, err := client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{Count: 3, BaseID: 101})
+	require.NoError(t, err)
+
+	for _, baseID := range []int{101, 111, 121} {
+		_, ok := activeSessions.TEID(baseID)
+		require.False(t, ok)
+	}
+
+	// UPF allocated TEIDs are taken from the Created PDR and tracked too
+	require.Equal(t, []uint32{1}, create(301, 1, pb.TeidAllocation_UPF_ALLOCATED))
+
+	teid, ok := activeSessions.TEID(301)
+	require.True(t, ok)
+	require.Equal(t, uint32(1), teid)
+
+	// the F-TEID sent has the CH flag set, without TEID nor address
+	req := upf.Received(message.MsgTypeSessionEstablishmentRequest)[7].(*message.SessionEstablishmentRequest)
+	pdi, err := req.CreatePDR[0].PDI()
+	require.NoError(t, err)
+
+	for _, child := range pdi {
+		if child.Type == ie.FTEID {
+			fteid, err := child.FTEID()
+			require.NoError(t, err)
+			require.True(t, fteid.HasCh())
+			require.Zero(t, fteid.TEID)
+			require.Nil(t, fteid.IPv4Address)
+		}
+	}
+
+	sess, ok := activeSessions.Get(301)
+	require.True(t, ok)
+
+	allocated, ok := sess.AllocatedFTEID(301)
+	require.True(t, ok)
+	require.Equal(t, uint32(1), allocated.TEID)
+}
+
+func TestCreateSessionCanceled(t *testing.T) {
+	const count = 100
+
+	request := &pb.CreateSessionRequest{
+		Count:         count,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/16",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	}
+
+	t.Run("canceled between sessions", func(t *testing.T) {
+		upf := setupAssociation(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var establishments int32
+
+		upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+			if atomic.AddInt32(&establishments, 1) == 3 {
+				cancel()
+			}
+
+			return message.NewSessionEstablishmentResponse(0, 0, req.SEID(), req.Sequence(), 0,
+				upf.NodeID(),
+				ie.NewCause(ie.CauseRequestAccepted),
+				ie.NewFSEID(uint64(atomic.LoadInt32(&establishments)), net.ParseIP("127.0.0.1"), nil),
+			)
+		})
+
+		_, err := NewPFCPSimService("", 0).CreateSession(ctx, request)
+		require.Equal(t, codes.Canceled, status.Code(err))
+
+		require.Less(t, len(upf.Received(message.MsgTypeSessionEstablishmentRequest)), count)
+		require.Zero(t, activeSessions.Len())
+	})
+
+	t.Run("deadline exceeded waiting for a response", func(t *testing.T) {
+		upf := setupAssociation(t)
+
+		// the peer never answers
+		upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+
+		_, err := NewPFCPSimService("", 0).CreateSession(ctx, request)
+		require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		require.Less(t, time.Since(start), pfcpsim.DefaultResponseTimeout)
+
+		require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 1)
+		require.Zero(t, activeSessions.Len())
+	})
+}
+
+func TestCreateSessionRateLimit(t *testing.T) {
+	request := &pb.CreateSessionRequest{
+		Count:             5,
+		BaseID:            1,
+		NodeBAddress:      "198.18.0.10",
+		UeAddressPool:     "17.0.0.0/24",
+		AppFilters:        []string{"ip:any:any:allow:100"},
+		SessionsPerSecond: 10,
+	}
+
+	for _, concurrency := range []int32{1, 4} {
+		t.Run(fmt.Sprintf("concurrency %v", concurrency), func(t *testing.T) {
+			upf := setupAssociation(t)
+
+			request.Concurrency = concurrency
+			start := time.Now()
+
+			res, err := NewPFCPSimService("", 0).CreateSession(context.Background(), request)
+			require.NoError(t, err)
+
+			// the first session is established immediately, then one every 100ms
+			elapsed := time.Since(start)
+			require.GreaterOrEqual(t, elapsed, 400*time.Millisecond)
+			require.Less(t, elapsed, time.Second)
+
+			require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 5)
+			require.Contains(t, res.Message, "sessions per second")
+		})
+	}
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		upf := setupAssociation(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+		defer cancel()
+
+		request.Concurrency = 1
+		start := time.Now()
+
+		_, err := NewPFCPSimService("", 0).CreateSession(ctx, request)
+		require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		require.Less(t, time.Since(start), 400*time.Millisecond)
+
+		require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 2)
+		require.Zero(t, activeSessions.Len())
+	})
+
+	t.Run("negative rate", func(t *testing.T) {
+		setupAssociation(t)
+
+		_, err := NewPFCPSimService("", 0).CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:             1,
+			BaseID:            1,
+			NodeBAddress:      "198.18.0.10",
+			UeAddressPool:     "17.0.0.0/24",
+			AppFilters:        []string{"ip:any:any:allow:100"},
+			SessionsPerSecond: -1,
+		})
+		require.Equal(t, codes.Aborted, status.Code(err))
+	})
+}
+
+func TestCreateSessionOverlappingBaseIDs(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	request := &pb.CreateSessionRequest{
+		Count:         3,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	}
+
+	_, err := client.CreateSession(context.Background(), request)
+	require.NoError(t, err)
+
+	sessions := make(map[int]*pfcpsim.PFCPSession)
+
+	activeSessions.Range(func(index int, sess *pfcpsim.PFCPSession) bool {
+		sessions[index] = sess
+		return true
+	})
+
+	// the second request overlaps with the last two sessions of the first one
+	request.BaseID = 1 + 2*SessionStep
+
+	_, err = client.CreateSession(context.Background(), request)
+	require.Error(t, err)
+	require.Equal(t, codes.Aborted, status.Code(err))
+	require.Contains(t, err.Error(), fmt.Sprintf("%v", []int{1 + 2*SessionStep}))
+
+	// nothing was established for the rejected request
+	require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 3)
+	require.Equal(t, 3, activeSessions.Len())
+
+	for index, sess := range sessions {
+		stored, ok := activeSessions.Get(index)
+		require.True(t, ok)
+		require.Same(t, sess, stored)
+	}
+}
+
+func TestModifyAndDeleteSessionMissingBaseIDs(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         2,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+
+	// the requests cover both the active sessions and a missing one
+	missing := fmt.Sprintf("baseID %v", 1+2*SessionStep)
+
+	_, err = client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+		Count:        3,
+		BaseID:       1,
+		NodeBAddress: "198.18.0.11",
+		AppFilters:   []string{"ip:any:any:allow:100"},
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.Aborted, status.Code(err))
+	require.Contains(t, err.Error(), missing)
+	require.Empty(t, upf.Received(message.MsgTypeSessionModificationRequest))
+
+	_, err = client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{
+		Count:  3,
+		BaseID: 1,
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.Aborted, status.Code(err))
+	require.Contains(t, err.Error(), missing)
+	require.Empty(t, upf.Received(message.MsgTypeSessionDeletionRequest))
+	require.Equal(t, 2, activeSessions.Len())
+}
+
+func TestCreateSessionOnAdditionalPeer(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	otherUPF, err := fakeupf.New()
+	require.NoError(t, err)
+
+	localN4Address = "127.0.0.1"
+	additionalPeerAddresses = []string{otherUPF.Addr()}
+
+	t.Cleanup(func() {
+		disconnectAdditionalPeers(false)
+		otherUPF.Close()
+
+		localN4Address = ""
+		additionalPeerAddresses = nil
+	})
+
+	require.NoError(t, connectAdditionalPeers(context.Background()))
+	require.Len(t, otherUPF.Received(message.MsgTypeAssociationSetupRequest), 1)
+
+	for _, request := range []*pb.CreateSessionRequest{
+		{BaseID: 1},
+		{BaseID: 2, Peer: otherUPF.Addr()},
+	} {
+		request.Count = 1
+		request.NodeBAddress = "198.18.0.10"
+		request.UeAddressPool = "17.0.0.0/24"
+		request.AppFilters = []string{"ip:any:any:allow:100"}
+
+		_, err = client.CreateSession(context.Background(), request)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 1)
+	require.Len(t, otherUPF.Received(message.MsgTypeSessionEstablishmentRequest), 1)
+
+	// each session is deleted on the peer it was established with
+	_, err = client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{Count: 1, BaseID: 2})
+	require.NoError(t, err)
+
+	require.Empty(t, upf.Received(message.MsgTypeSessionDeletionRequest))
+	require.Len(t, otherUPF.Received(message.MsgTypeSessionDeletionRequest), 1)
+
+	_, err = client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{Count: 1, BaseID: 1})
+	require.NoError(t, err)
+
+	require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 1)
+
+	_, err = client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        3,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+		Peer:          "198.51.100.1",
+	})
+	require.Equal(t, codes.Aborted, status.Code(err))
+}
+
+func TestCreateSessionConcurrently(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	const count = 50
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         count,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+		Concurrency:   8,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, count, activeSessions.Len())
+	require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), count)
+
+	seids := make(map[uint64]struct{})
+
+	for k := 0; k < count; k++ {
+		sess, ok := activeSessions.Get(1 + k*SessionStep)
+		require.True(t, ok, "missing session with baseID %v", 1+k*SessionStep)
+
+		seids[sess.PeerSEID()] = struct{}{}
+	}
+
+	// every session got its own response
+	require.Len(t, seids, count)
+}
+
+func TestCreateSessionConcurrentlyWithFailures(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	var received int32
+
+	upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+		cause := ie.CauseRequestAccepted
+		if atomic.AddInt32(&received, 1)%2 == 0 {
+			cause = ie.CauseRuleCreationModificationFailure
+		}
+
+		return message.NewSessionEstablishmentResponse(0, 0, 0, req.Sequence(), 0,
+			upf.NodeID(),
+			ie.NewCause(cause),
+			ie.NewFSEID(uint64(req.Sequence()), net.ParseIP("127.0.0.1"), nil),
+		)
+	})
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         10,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+		Concurrency:   4,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "5 of 10 sessions could not be established")
+
+	// all the sessions were attempted, in spite of the failures, then the established ones were rolled back
+	require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 10)
+	require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 5)
+	require.Zero(t, activeSessions.Len())
+}
+
+func BenchmarkCreateSession(b *testing.B) {
+	for _, concurrency := range []int32{1, 16} {
+		b.Run(fmt.Sprintf("concurrency-%v", concurrency), func(b *testing.B) {
+			setupAssociation(b)
+			client := startServer(b)
+
+			for n := 0; n < b.N; n++ {
+				_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+					Count:         100,
+					BaseID:        int32(1 + n*100*SessionStep),
+					NodeBAddress:  "198.18.0.10",
+					UeAddressPool: "17.0.0.0/8",
+					AppFilters:    []string{"ip:any:any:allow:100"},
+					Concurrency:   concurrency,
+				})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func TestCreateSessionRollback(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	var received int32
+
+	// the third establishment fails
+	upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+		cause := ie.CauseRequestAccepted
+		if atomic.AddInt32(&received, 1) == 3 {
+			cause = ie.CauseNoResourcesAvailable
+		}
+
+		return message.NewSessionEstablishmentResponse(0, 0, 0, req.Sequence(), 0,
+			upf.NodeID(),
+			ie.NewCause(cause),
+			ie.NewFSEID(uint64(req.Sequence()), net.ParseIP("127.0.0.1"), nil),
+		)
+	})
+
+	// the rollback is best-effort: a failed deletion doesn't stop it
+	var deletions int32
+
+	upf.HandleFunc(message.MsgTypeSessionDeletionRequest, func(req message.Message) message.Message {
+		cause := ie.CauseRequestAccepted
+		if atomic.AddInt32(&deletions, 1) == 1 {
+			cause = ie.CauseSessionContextNotFound
+		}
+
+		return message.NewSessionDeletionResponse(0, 0, 0, req.Sequence(), 0, ie.NewCause(cause))
+	})
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         5,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.Error(t, err)
+
+	require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 3)
+	require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 2)
+	require.Zero(t, activeSessions.Len())
+}
+
+func TestCreateSessionAndDelete(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	newRequest := func(iterations, concurrency int32) *pb.CreateSessionAndDeleteRequest {
+		return &pb.CreateSessionAndDeleteRequest{
+			Iterations:    iterations,
+			BaseID:        1,
+			NodeBAddress:  "198.18.0.10",
+			UeAddressPool: "17.0.0.0/24",
+			Concurrency:   concurrency,
+		}
+	}
+
+	res, err := client.CreateSessionAndDelete(context.Background(), newRequest(5, 2))
+	require.NoError(t, err)
+	require.Equal(t, int32(5), res.Cycles)
+	require.Zero(t, res.EstablishmentErrors)
+	require.Zero(t, res.DeletionErrors)
+	require.Greater(t, res.CyclesPerSecond, 0.0)
+
+	require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 5)
+	require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 5)
+
+	// the sessions are not kept, and neither are their TEIDs
+	require.Zero(t, activeSessions.Len())
+
+	_, ok := activeSessions.TEID(1)
+	require.False(t, ok)
+
+	// the peer fails to establish one of the sessions
+	var establishments int32
+
+	upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+		if atomic.AddInt32(&establishments, 1) == 2 {
+			return message.NewSessionEstablishmentResponse(0, 0, 0, req.Sequence(), 0,
+				upf.NodeID(),
+				ie.NewCause(ie.CauseRequestRejected),
+			)
+		}
+
+		fseid, err := req.(*message.SessionEstablishmentRequest).CPFSEID.FSEID()
+		require.NoError(t, err)
+
+		return message.NewSessionEstablishmentResponse(0, 0, fseid.SEID, req.Sequence(), 0,
+			upf.NodeID(),
+			ie.NewCause(ie.CauseRequestAccepted),
+			ie.NewFSEID(fseid.SEID, net.ParseIP("127.0.0.1"), nil),
+		)
+	})
+
+	res, err = client.CreateSessionAndDelete(context.Background(), newRequest(3, 1))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), res.Cycles)
+	require.Equal(t, int32(1), res.EstablishmentErrors)
+	require.Zero(t, res.DeletionErrors)
+
+	_, err = client.CreateSessionAndDelete(context.Background(), newRequest(0, 1))
+	require.Error(t, err)
+}
+
+func TestClearAllSessions(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	for _, baseID := range []int32{1, 101, 201} {
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:         2,
+			BaseID:        baseID,
+			NodeBAddress:  "198.18.0.10",
+			UeAddressPool: "17.0.0.0/24",
+			AppFilters:    []string{"ip:any:any:allow:100"},
+		})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 6, activeSessions.Len())
+
+	// the peer fails to delete one of the sessions
+	var deletions int32
+
+	upf.HandleFunc(message.MsgTypeSessionDeletionRequest, func(req message.Message) message.Message {
+		cause := ie.CauseRequestAccepted
+		if atomic.AddInt32(&deletions, 1) == 1 {
+			cause = ie.CauseSessionContextNotFound
+		}
+
+		return message.NewSessionDeletionResponse(0, 0, 0, req.Sequence(), 0, ie.NewCause(cause))
+	})
+
+	res, err := client.ClearAllSessions(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(5), res.Deleted)
+	require.Len(t, res.FailedBaseIDs, 1)
+
+	require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 6)
+	require.Zero(t, activeSessions.Len())
+}
+
+func TestDeleteSessionSet(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	csid = 7
+	sim.SetCSID(csid)
+
+	t.Cleanup(func() {
+		csid = 0
+	})
+
+	createSessions := func(baseID int32) {
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:         2,
+			BaseID:        baseID,
+			NodeBAddress:  "198.18.0.10",
+			UeAddressPool: "17.0.0.0/24",
+			AppFilters:    []string{"ip:any:any:allow:100"},
+		})
+		require.NoError(t, err)
+	}
+
+	createSessions(1)
+
+	// sessions established outside of the set are deleted one by one
+	sim.SetCSID(0)
+	createSessions(101)
+	sim.SetCSID(csid)
+
+	res, err := client.DeleteSessionSet(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), res.Deleted)
+	require.Empty(t, res.FailedBaseIDs)
+
+	require.Len(t, upf.Received(message.MsgTypeSessionSetDeletionRequest), 1)
+	require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 2)
+	require.Zero(t, activeSessions.Len())
+
+	t.Run("not supported by the peer", func(t *testing.T) {
+		upf.HandleFunc(message.MsgTypeSessionSetDeletionRequest, func(req message.Message) message.Message {
+			return nil
+		})
+		sim.SetPFCPResponseTimeout(100 * time.Millisecond)
+
+		createSessions(201)
+
+		res, err := client.DeleteSessionSet(context.Background(), &pb.EmptyRequest{})
+		require.NoError(t, err)
+		require.Equal(t, int32(2), res.Deleted)
+
+		require.Len(t, upf.Received(message.MsgTypeSessionSetDeletionRequest), 2)
+		require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 4)
+		require.Zero(t, activeSessions.Len())
+	})
+}
+
+func TestBufferAllSessions(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	for _, baseID := range []int32{1, 101} {
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:         2,
+			BaseID:        baseID,
+			NodeBAddress:  "198.18.0.10",
+			UeAddressPool: "17.0.0.0/24",
+			AppFilters:    []string{"ip:any:any:allow:100"},
+		})
+		require.NoError(t, err)
+	}
+
+	// the peer fails to modify one of the sessions
+	var modifications int32
+
+	upf.HandleFunc(message.MsgTypeSessionModificationRequest, func(req message.Message) message.Message {
+		cause := ie.CauseRequestAccepted
+		if atomic.AddInt32(&modifications, 1) == 1 {
+			cause = ie.CauseRequestRejected
+		}
+
+		return message.NewSessionModificationResponse(0, 0, 0, req.Sequence(), 0, ie.NewCause(cause))
+	})
+
+	res, err := client.BufferAllSessions(context.Background(), &pb.BufferAllSessionsRequest{
+		SuggestedBufferingPacketsCount: 10,
+		DlDataNotificationDelayMs:      100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), res.Modified)
+	require.Len(t, res.FailedBaseIDs, 1)
+
+	received := upf.Received(message.MsgTypeSessionModificationRequest)
+	require.Len(t, received, 4)
+
+	for _, msg := range received {
+		req := msg.(*message.SessionModificationRequest)
+		require.NotNil(t, req.CreateBAR)
+
+		count, err := req.CreateBAR.SuggestedBufferingPacketsCount()
+		require.NoError(t, err)
+		require.Equal(t, uint8(10), count)
+
+		require.Len(t, req.UpdateFAR, 1)
+
+		applyAction, err := req.UpdateFAR[0].ApplyAction()
+		require.NoError(t, err)
+		require.Equal(t, uint8(session.ActionNotify|session.ActionBuffer), applyAction)
+	}
+
+	// the BAR of the sessions modified is updated the next time
+	res, err = client.BufferAllSessions(context.Background(), &pb.BufferAllSessionsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), res.Modified)
+	require.Empty(t, res.FailedBaseIDs)
+
+	var updated int
+
+	for _, msg := range upf.Received(message.MsgTypeSessionModificationRequest)[4:] {
+		if msg.(*message.SessionModificationRequest).UpdateBAR != nil {
+			updated++
+		}
+	}
+
+	require.Equal(t, 3, updated)
+}
+
+func TestConfigureLocalN4Address(t *testing.T) {
+	upf, err := fakeupf.New()
+	require.NoError(t, err)
+
+	client := startServer(t)
+
+	t.Cleanup(func() {
+		sim.DisconnectN4()
+		upf.Close()
+
+		sim = nil
+		setRemotePeerConnected(false)
+		remotePeerAddress = ""
+		localN4Address = ""
+		pfcpPort = 0
+	})
+
+	_, upfPort, err := net.SplitHostPort(upf.Addr())
+	require.NoError(t, err)
+
+	port, err := strconv.Atoi(upfPort)
+	require.NoError(t, err)
+
+	_, err = client.Configure(context.Background(), &pb.ConfigureRequest{
+		UpfN3Address:      "198.18.0.1",
+		RemotePeerAddress: "127.0.0.1",
+		LocalN4Address:    "127.0.0.2",
+		PfcpPort:          int32(port),
+	})
+	require.NoError(t, err)
+
+	_, err = client.Associate(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+
+	// messages are sent from the configured address, which is also advertised as Node ID
+	host, _, err := net.SplitHostPort(upf.PeerAddr())
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.2", host)
+
+	received := upf.Received(message.MsgTypeAssociationSetupRequest)
+	require.Len(t, received, 1)
+
+	nodeID, err := received[0].(*message.AssociationSetupRequest).NodeID.NodeID()
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.2", nodeID)
+}
+
+func TestConfigureOperationTimeouts(t *testing.T) {
+	upf, err := fakeupf.New()
+	require.NoError(t, err)
+
+	client := startServer(t)
+
+	t.Cleanup(func() {
+		sim.DisconnectN4()
+		upf.Close()
+
+		sim = nil
+		setRemotePeerConnected(false)
+		remotePeerAddress = ""
+		operationTimeouts = make(map[pfcpsim.Operation]time.Duration)
+	})
+
+	_, err = client.Configure(context.Background(), &pb.ConfigureRequest{
+		UpfN3Address:      "198.18.0.1",
+		RemotePeerAddress: upf.Addr(),
+		DeletionTimeout:   -1,
+	})
+	require.Error(t, err)
+
+	_, err = client.Configure(context.Background(), &pb.ConfigureRequest{
+		UpfN3Address:         "198.18.0.1",
+		RemotePeerAddress:    upf.Addr(),
+		EstablishmentTimeout: 200,
+		HeartbeatTimeout:     50,
+	})
+	require.NoError(t, err)
+
+	_, err = client.Associate(context.Background(), &pb.EmptyRequest{})
+	require.NoError(t, err)
+
+	require.Equal(t, 200*time.Millisecond, sim.OperationTimeout(pfcpsim.OperationEstablishment))
+	require.Equal(t, 50*time.Millisecond, sim.OperationTimeout(pfcpsim.OperationHeartbeat))
+	require.Equal(t, pfcpsim.DefaultResponseTimeout, sim.OperationTimeout(pfcpsim.OperationModification))
+}
+
+func TestConfigureNodeID(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodeIDType string
+		nodeID     string
+		expected   *ie.IE
+	}{
+		{name: "IPv4", nodeIDType: "ipv4", nodeID: "10.0.0.1", expected: ie.NewNodeID("10.0.0.1", "", "")},
+		{name: "IPv6", nodeIDType: "ipv6", nodeID: "2001:db8::1", expected: ie.NewNodeID("", "2001:db8::1", "")},
+		{name: "FQDN", nodeIDType: "fqdn", nodeID: "smf.5gc.local", expected: ie.NewNodeID("", "", "smf.5gc.local")},
+		{name: "inferred FQDN", nodeID: "smf.5gc.local", expected: ie.NewNodeID("", "", "smf.5gc.local")},
+	}
+
+	for _, tt := range tests {
+		nodeIDTypeName, nodeIDValue, expected := tt.nodeIDType, tt.nodeID, tt.expected
+
+		t.Run(tt.name, func(t *testing.T) {
+			upf, err := fakeupf.New()
+			require.NoError(t, err)
+
+			client := startServer(t)
+
+			t.Cleanup(func() {
+				sim.DisconnectN4()
+				upf.Close()
+
+				sim = nil
+				setRemotePeerConnected(false)
+				remotePeerAddress = ""
+				pfcpPort = 0
+				nodeIDType, nodeID = 0, ""
+			})
+
+			_, upfPort, err := net.SplitHostPort(upf.Addr())
+			require.NoError(t, err)
+
+			port, err := strconv.Atoi(upfPort)
+			require.NoError(t, err)
+
+			_, err = client.Configure(context.Background(), &pb.ConfigureRequest{
+				UpfN3Address:      "198.18.0.1",
+				RemotePeerAddress: "127.0.0.1",
+				PfcpPort:          int32(port),
+				NodeIDType:        nodeIDTypeName,
+				NodeID:            nodeIDValue,
+			})
+			require.NoError(t, err)
+
+			_, err = client.Associate(context.Background(), &pb.EmptyRequest{})
+			require.NoError(t, err)
+
+			received := upf.Received(message.MsgTypeAssociationSetupRequest)
+			require.Len(t, received, 1)
+			require.Equal(t, expected, received[0].(*message.AssociationSetupRequest).NodeID)
+		})
+	}
+}
+
+func TestConfigureValidation(t *testing.T) {
+	client := startServer(t)
+
+	tests := []struct {
+		name    string
+		request *pb.ConfigureRequest
+	}{
+		{name: "invalid local N4 address", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", LocalN4Address: "invalid"}},
+		{name: "negative PFCP port", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", PfcpPort: -1}},
+		{name: "PFCP port out of range", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", PfcpPort: 65536}},
+		{name: "CP function features out of range", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", CpFunctionFeatures: 256}},
+		{name: "negative max missed heartbeats", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", RemotePeerAddress: "127.0.0.1", MaxMissedHeartbeats: -1}},
+		{name: "negative re-association backoff", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", RemotePeerAddress: "127.0.0.1", ReassociationBackoffMs: -1}},
+		{name: "message priority out of range", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", RemotePeerAddress: "127.0.0.1", EnableMessagePriority: true, MessagePriority: 16}},
+		{name: "missing remote peer address", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1"}},
+		{name: "invalid remote peer host", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", RemotePeerAddress: "upf_1:8805"}},
+		{name: "invalid remote peer port", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", RemotePeerAddress: "127.0.0.1:port"}},
+		{name: "remote peer port out of range", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", RemotePeerAddress: "127.0.0.1:65536"}},
+		{name: "unknown Node ID type", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", RemotePeerAddress: "127.0.0.1", NodeIDType: "mac", NodeID: "smf.local"}},
+		{name: "Node ID not matching its type", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", RemotePeerAddress: "127.0.0.1", NodeIDType: "ipv4", NodeID: "smf.local"}},
+		{name: "missing Node ID", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", RemotePeerAddress: "127.0.0.1", NodeIDType: "fqdn"}},
+		{name: "invalid FQDN Node ID", request: &pb.ConfigureRequest{UpfN3Address: "198.18.0.1", RemotePeerAddress: "127.0.0.1", NodeID: "smf_1.local"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Configure(context.Background(), tt.request)
+			require.Error(t, err)
+		})
+	}
+}
+
+// hasEndMarker returns true if far requests the sending of end markers.
+func hasEndMarker(t *testing.T, far *ie.IE) bool {
+	children, err := far.UpdateFAR()
+	require.NoError(t, err)
+
+	for _, child := range children {
+		if child.Type != ie.UpdateForwardingParameters {
+			continue
+		}
+
+		params, err := child.UpdateForwardingParameters()
+		require.NoError(t, err)
+
+		for _, param := range params {
+			if param.Type == ie.PFCPSMReqFlags {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func TestModifySessionEndMarker(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+
+	modify := func(request *pb.ModifySessionRequest) []*ie.IE {
+		request.Count = 1
+		request.BaseID = 1
+		request.NodeBAddress = "198.18.0.11"
+		request.AppFilters = []string{"ip:any:any:allow:100"}
+
+		_, err := client.ModifySession(context.Background(), request)
+		require.NoError(t, err)
+
+		received := upf.Received(message.MsgTypeSessionModificationRequest)
+
+		return received[len(received)-1].(*message.SessionModificationRequest).UpdateFAR
+	}
+
+	// no end marker while buffering
+	fars := modify(&pb.ModifySessionRequest{BufferFlag: true, NotifyCPFlag: true, EndMarkerFlag: true})
+	require.Len(t, fars, 1)
+	require.False(t, hasEndMarker(t, fars[0]))
+
+	// switching back to forwarding
+	fars = modify(&pb.ModifySessionRequest{EndMarkerFlag: true})
+	require.Len(t, fars, 1)
+	require.True(t, hasEndMarker(t, fars[0]))
+
+	// uplink FARs are updated only if requested
+	fars = modify(&pb.ModifySessionRequest{UplinkEndMarkerFlag: true})
+	require.Len(t, fars, 2)
+
+	uplinkFarID, err := fars[0].FARID()
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), uplinkFarID)
+	require.True(t, hasEndMarker(t, fars[0]))
+	require.False(t, hasEndMarker(t, fars[1]))
+}
+
+func TestModifySessionBuffering(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+
+	modify := func(request *pb.ModifySessionRequest) *message.SessionModificationRequest {
+		request.Count = 1
+		request.BaseID = 1
+		request.NodeBAddress = "198.18.0.11"
+		request.AppFilters = []string{"ip:any:any:allow:100"}
+
+		_, err := client.ModifySession(context.Background(), request)
+		require.NoError(t, err)
+
+		received := upf.Received(message.MsgTypeSessionModificationRequest)
+
+		return received[len(received)-1].(*message.SessionModificationRequest)
+	}
+
+	farBARID := func(far *ie.IE) (uint8, error) {
+		children, err := far.UpdateFAR()
+		require.NoError(t, err)
+
+		for _, child := range children {
+			if child.Type == ie.BARID {
+				return child.BARID()
+			}
+		}
+
+		return 0, ie.ErrIENotFound
+	}
+
+	// the BAR is created when the session starts buffering
+	req := modify(&pb.ModifySessionRequest{
+		BufferFlag:                     true,
+		NotifyCPFlag:                   true,
+		SuggestedBufferingPacketsCount: 10,
+		DlDataNotificationDelayMs:      100,
+	})
+	require.NotNil(t, req.CreateBAR)
+	require.Nil(t, req.UpdateBAR)
+
+	barID, err := req.CreateBAR.BARID()
+	require.NoError(t, err)
+	require.Equal(t, uint8(sessionBARID), barID)
+
+	count, err := req.CreateBAR.SuggestedBufferingPacketsCount()
+	require.NoError(t, err)
+	require.Equal(t, uint8(10), count)
+
+	delay, err := req.CreateBAR.DownlinkDataNotificationDelay()
+	require.NoError(t, err)
+	require.Equal(t, 100*time.Millisecond, delay)
+
+	require.Len(t, req.UpdateFAR, 1)
+	farBarID, err := farBARID(req.UpdateFAR[0])
+	require.NoError(t, err)
+	require.Equal(t, barID, farBarID)
+
+	// forwarding FARs are not linked to the BAR
+	req = modify(&pb.ModifySessionRequest{})
+	require.Nil(t, req.CreateBAR)
+	require.Nil(t, req.UpdateBAR)
+	_, err = farBARID(req.UpdateFAR[0])
+	require.Error(t, err)
+
+	// the existing BAR is updated when buffering again
+	req = modify(&pb.ModifySessionRequest{BufferFlag: true, NotifyCPFlag: true, SuggestedBufferingPacketsCount: 20})
+	require.Nil(t, req.CreateBAR)
+	require.NotNil(t, req.UpdateBAR)
+
+	count, err = req.UpdateBAR.SuggestedBufferingPacketsCount()
+	require.NoError(t, err)
+	require.Equal(t, uint8(20), count)
+
+	farBarID, err = farBARID(req.UpdateFAR[0])
+	require.NoError(t, err)
+	require.Equal(t, uint8(sessionBARID), farBarID)
+
+	_, err = client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+		Count:                          1,
+		BaseID:                         1,
+		NodeBAddress:                   "198.18.0.11",
+		AppFilters:                     []string{"ip:any:any:allow:100"},
+		BufferFlag:                     true,
+		NotifyCPFlag:                   true,
+		SuggestedBufferingPacketsCount: 256,
+	})
+	require.Error(t, err)
+}
+
+func TestModifySessionRuleIDs(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	// a session whose rule IDs do not follow the layout of CreateSession
+	state, err := json.Marshal(simulatorState{Sessions: []sessionState{{
+		BaseID:    1,
+		LocalSEID: 1000,
+		PeerSEID:  2000,
+		sessionRecord: sessionRecord{
+			UeAddress: "17.0.0.1",
+			AppRules: []appRuleIDs{
+				{UplinkPDRID: 7, DownlinkPDRID: 42, UplinkFARID: 7, DownlinkFARID: 42},
+				{UplinkPDRID: 8, DownlinkPDRID: 43, UplinkFARID: 8, DownlinkFARID: 43},
+			},
+			SessionQERID: 9,
+		},
+	}}})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(path, state, 0o644))
+
+	_, err = client.LoadState(context.Background(), &pb.StateRequest{Path: path})
+	require.NoError(t, err)
+
+	farIDs := func(fars []*ie.IE) []uint32 {
+		var ids []uint32
+
+		for _, far := range fars {
+			id, err := far.FARID()
+			require.NoError(t, err)
+
+			ids = append(ids, id)
+		}
+
+		return ids
+	}
+
+	_, err = client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+		Count:               1,
+		BaseID:              1,
+		NodeBAddress:        "198.18.0.11",
+		AppFilters:          []string{"ip:any:any:allow:100", "udp:10.0.0.0/8:80:allow:200"},
+		UplinkEndMarkerFlag: true,
+		UlAmbr:              1000,
+		DlAmbr:              2000,
+	})
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionModificationRequest)
+	require.Len(t, received, 1)
+
+	req := received[0].(*message.SessionModificationRequest)
+	require.Equal(t, []uint32{7, 42, 8, 43}, farIDs(req.UpdateFAR))
+	require.Len(t, req.UpdateQER, 1)
+
+	qerID, err := req.UpdateQER[0].QERID()
+	require.NoError(t, err)
+	require.Equal(t, uint32(9), qerID)
+
+	// the first app filters only are modified
+	_, err = client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+		Count:        1,
+		BaseID:       1,
+		NodeBAddress: "198.18.0.11",
+		AppFilters:   []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+
+	received = upf.Received(message.MsgTypeSessionModificationRequest)
+	require.Equal(t, []uint32{42}, farIDs(received[len(received)-1].(*message.SessionModificationRequest).UpdateFAR))
+
+	t.Run("only the rules created are modified", func(t *testing.T) {
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:         1,
+			BaseID:        11,
+			NodeBAddress:  "198.18.0.10",
+			UeAddressPool: "17.0.0.0/24",
+			AppFilters:    []string{"ip:any:any:allow:100"},
+			Direction:     pb.Direction_DOWNLINK,
+		})
+		require.NoError(t, err)
+
+		_, err = client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+			Count:               1,
+			BaseID:              11,
+			NodeBAddress:        "198.18.0.11",
+			AppFilters:          []string{"ip:any:any:allow:100"},
+			UplinkEndMarkerFlag: true,
+		})
+		require.NoError(t, err)
+
+		received := upf.Received(message.MsgTypeSessionModificationRequest)
+		require.Equal(t, []uint32{12}, farIDs(received[len(received)-1].(*message.SessionModificationRequest).UpdateFAR))
+
+		// the session has neither a second app filter nor a session QER
+		_, err = client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+			Count:        1,
+			BaseID:       11,
+			NodeBAddress: "198.18.0.11",
+			AppFilters:   []string{"ip:any:any:allow:100", "udp:10.0.0.0/8:80:allow:200"},
+		})
+		require.Error(t, err)
+
+		_, err = client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+			Count:        1,
+			BaseID:       11,
+			NodeBAddress: "198.18.0.11",
+			UlAmbr:       1000,
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestModifySessionUpdatePDR(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         2,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"udp:10.0.0.0/8:80:allow:100"},
+	})
+	require.NoError(t, err)
+
+	_, err = client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+		Count:        2,
+		BaseID:       1,
+		NodeBAddress: "198.18.0.10",
+		PdrUpdates: []*pb.PDRUpdate{
+			{PdrID: 1, AppFilter: "tcp:192.168.0.0/16:443:allow", Precedence: 50},
+			// the SDF filter of the downlink PDR is kept
+			{PdrID: 2, Precedence: 70},
+		},
+	})
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionModificationRequest)
+	require.Len(t, received, 2)
+
+	for k, req := range received {
+		pdrs := req.(*message.SessionModificationRequest).UpdatePDR
+		require.Len(t, pdrs, 2)
+
+		// the PDR IDs are offset as the base IDs
+		expected := map[uint16]struct {
+			precedence uint32
+			filter     string
+		}{
+			uint16(1 + k*SessionStep): {50, "permit out tcp from 192.168.0.0/16 to assigned 443"},
+			uint16(2 + k*SessionStep): {70, "permit out udp from 10.0.0.0/8 to assigned 80"},
+		}
+
+		for _, pdr := range pdrs {
+			id, err := pdr.PDRID()
+			require.NoError(t, err)
+			require.Contains(t, expected, id)
+
+			precedence, err := pdr.Precedence()
+			require.NoError(t, err)
+			require.Equal(t, expected[id].precedence, precedence, "unexpected precedence for PDR %v", id)
+
+			pdi, err := pdr.PDI()
+			require.NoError(t, err)
+
+			var filter string
+
+			for _, child := range pdi {
+				if child.Type != ie.SDFFilter {
+					continue
+				}
+
+				sdf, err := child.SDFFilter()
+				require.NoError(t, err)
+
+				filter = sdf.FlowDescription
+			}
+
+			require.Equal(t, expected[id].filter, filter, "unexpected SDF filter for PDR %v", id)
+		}
+	}
+
+	t.Run("unknown PDR", func(t *testing.T) {
+		_, err := client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+			Count:        1,
+			BaseID:       1,
+			NodeBAddress: "198.18.0.10",
+			PdrUpdates:   []*pb.PDRUpdate{{PdrID: 5, Precedence: 50}},
+		})
+		require.Equal(t, codes.Aborted, status.Code(err))
+		require.Len(t, upf.Received(message.MsgTypeSessionModificationRequest), 2)
+	})
+}
+
+func TestModifySessionQueryURR(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	created, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+
+	upf.HandleFunc(message.MsgTypeSessionModificationRequest, func(req message.Message) message.Message {
+		return message.NewSessionModificationResponse(0, 0, 0, req.Sequence(), 0,
+			ie.NewCause(ie.CauseRequestAccepted),
+			ie.NewUsageReportWithinSessionModificationResponse(
+				ie.NewURRID(1),
+				ie.NewURSEQN(1),
+				ie.NewUsageReportTrigger(0x80, 0, 0),
+				ie.NewVolumeMeasurement(0x07, 300, 100, 200, 0, 0, 0),
+				ie.NewDurationMeasurement(10*time.Second),
+			),
+		)
+	})
+
+	res, err := client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+		Count:       1,
+		BaseID:      1,
+		QueryURRIDs: []uint32{1},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.UsageReports, 1)
+
+	report := res.UsageReports[0]
+	require.Equal(t, created.Sessions[0].LocalSEID, report.Seid)
+	require.Equal(t, reportTypeUsage, report.Type)
+	require.Equal(t, uint32(1), report.UrrID)
+	require.Equal(t, uint64(300), report.TotalVolume)
+	require.Equal(t, uint64(100), report.UplinkVolume)
+	require.Equal(t, uint64(200), report.DownlinkVolume)
+	require.Equal(t, uint32(10), report.Duration)
+
+	received := upf.Received(message.MsgTypeSessionModificationRequest)
+	require.Len(t, received, 1)
+
+	modifyReq := received[0].(*message.SessionModificationRequest)
+	require.Len(t, modifyReq.QueryURR, 1)
+	require.Empty(t, modifyReq.UpdateFAR)
+
+	urrID, err := modifyReq.QueryURR[0].URRID()
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), urrID)
+
+	t.Run("invalid URR ID", func(t *testing.T) {
+		_, err := client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+			Count:       1,
+			BaseID:      1,
+			QueryURRIDs: []uint32{0},
+		})
+		require.Equal(t, codes.Aborted, status.Code(err))
+		require.Len(t, upf.Received(message.MsgTypeSessionModificationRequest), 1)
+	})
+}
+
+func TestCreateSessionWithTTL(t *testing.T) {
+	t.Run("sessions expire", func(t *testing.T) {
+		upf := setupAssociation(t)
+		client := startServer(t)
+
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:         2,
+			BaseID:        1,
+			NodeBAddress:  "198.18.0.10",
+			UeAddressPool: "17.0.0.0/24",
+			AppFilters:    []string{"ip:any:any:allow:100"},
+			TtlMs:         100,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, activeSessions.Len())
+
+		require.Eventually(t, func() bool {
+			return activeSessions.Len() == 0
+		}, time.Second, 10*time.Millisecond)
+
+		require.Eventually(t, func() bool {
+			return len(upf.Received(message.MsgTypeSessionDeletionRequest)) == 2
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("explicit delete cancels expiry", func(t *testing.T) {
+		upf := setupAssociation(t)
+		client := startServer(t)
+
+		_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+			Count:         1,
+			BaseID:        1,
+			NodeBAddress:  "198.18.0.10",
+			UeAddressPool: "17.0.0.0/24",
+			AppFilters:    []string{"ip:any:any:allow:100"},
+			TtlMs:         200,
+		})
+		require.NoError(t, err)
+
+		_, err = client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{
+			Count:  1,
+			BaseID: 1,
+		})
+		require.NoError(t, err)
+
+		time.Sleep(400 * time.Millisecond)
+
+		require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 1)
+	})
+}
+
+func TestDumpAndLoadState(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	res, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         3,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100", "udp:10.0.0.0/8:80:allow:200"},
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	_, err = client.DumpState(context.Background(), &pb.StateRequest{Path: path})
+	require.NoError(t, err)
+
+	dumped := make(map[int]sessionState)
+
+	activeSessions.Range(func(index int, sess *pfcpsim.PFCPSession) bool {
+		record, ok := activeSessions.Record(index)
+		require.True(t, ok)
+
+		teid, _ := activeSessions.TEID(index)
+
+		dumped[index] = sessionState{
+			BaseID:        index,
+			LocalSEID:     sess.LocalSEID(),
+			PeerSEID:      sess.PeerSEID(),
+			UplinkTEID:    teid,
+			sessionRecord: record,
+		}
+
+		return true
+	})
+
+	require.Len(t, dumped, 3)
+	require.Equal(t, []uint16{1, 2, 3, 4}, dumped[1].PDRIDs)
+	require.Equal(t, res.Sessions[1].UeAddress, dumped[11].UeAddress)
+
+	// simulate a restart of pfcpsim, keeping the sessions on the remote peer
+	sim.DisconnectN4()
+	sim = newSim("127.0.0.1")
+	setRemotePeerConnected(false)
+	activeSessions = newSessionStore()
+
+	require.NoError(t, connectPFCPSim())
+	require.NoError(t, sim.SetupAssociation())
+
+	_, err = client.LoadState(context.Background(), &pb.StateRequest{Path: path})
+	require.NoError(t, err)
+	require.Equal(t, 3, activeSessions.Len())
+
+	for index, expected := range dumped {
+		sess, ok := activeSessions.Get(index)
+		require.True(t, ok)
+		require.Equal(t, expected.LocalSEID, sess.LocalSEID())
+		require.Equal(t, expected.PeerSEID, sess.PeerSEID())
+
+		record, ok := activeSessions.Record(index)
+		require.True(t, ok)
+		require.Equal(t, expected.sessionRecord, record)
+
+		teid, ok := activeSessions.TEID(index)
+		require.True(t, ok)
+		require.Equal(t, expected.UplinkTEID, teid)
+	}
+
+	// loading again would duplicate the sessions
+	_, err = client.LoadState(context.Background(), &pb.StateRequest{Path: path})
+	require.Error(t, err)
+
+	// loaded sessions can be modified and deleted
+	_, err = client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+		Count:        3,
+		BaseID:       1,
+		NodeBAddress: "198.18.0.11",
+		AppFilters:   []string{"ip:any:any:allow:100", "udp:10.0.0.0/8:80:allow:200"},
+	})
+	require.NoError(t, err)
+
+	_, err = client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{Count: 3, BaseID: 1})
+	require.NoError(t, err)
+	require.Zero(t, activeSessions.Len())
+
+	deletions := upf.Received(message.MsgTypeSessionDeletionRequest)
+	require.Len(t, deletions, 3)
+
+	for k, deletion := range deletions {
+		require.Equal(t, dumped[1+k*SessionStep].PeerSEID, deletion.SEID())
+	}
+
+	// new sessions do not reuse the local SEIDs of the loaded ones
+	res, err = client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+	require.Greater(t, res.Sessions[0].LocalSEID, dumped[21].LocalSEID)
+}
+
+func TestSetLogging(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	var buf bytes.Buffer
+
+	logger := log.StandardLogger()
+	out, level, formatter := logger.Out, logger.GetLevel(), logger.Formatter
+
+	log.SetOutput(&buf)
+	t.Cleanup(func() {
+		log.SetOutput(out)
+		log.SetLevel(level)
+		log.SetFormatter(formatter)
+	})
+
+	_, err := client.SetLogging(context.Background(), &pb.LoggingRequest{Level: "debug", Format: "json"})
+	require.NoError(t, err)
+	require.Equal(t, log.DebugLevel, log.GetLevel())
+
+	_, err = client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+	require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 1)
+
+	var debugLines int
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		entry := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), "not a JSON line: %v", line)
+
+		if entry["level"] == "debug" {
+			debugLines++
+		}
+	}
+
+	require.NotZero(t, debugLines)
+
+	// invalid values leave the logging unchanged
+	for _, request := range []*pb.LoggingRequest{{Level: "verbose"}, {Format: "xml"}, {Level: "info", Format: "xml"}} {
+		_, err = client.SetLogging(context.Background(), request)
+		require.Equal(t, codes.Aborted, status.Code(err))
+		require.Equal(t, log.DebugLevel, log.GetLevel())
+	}
+}
+
+func TestEchoGTPU(t *testing.T) {
+	client := startServer(t)
+
+	_, err := client.EchoGTPU(context.Background(), &pb.GTPUEchoRequest{})
+	require.Error(t, err)
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	responder, err := fakeupf.NewGTPUEchoResponder()
+	require.NoError(t, err)
+
+	// no association is needed
+	remotePeerAddress = "127.0.0.1"
+	upfN3Address = "127.0.0.1"
+	localN4Address = "127.0.0.1"
+	gtpuPort = responder.Port()
+
+	t.Cleanup(func() {
+		responder.Close()
+
+		remotePeerAddress = ""
+		upfN3Address = ""
+		localN4Address = ""
+		gtpuPort = pfcpsim.GTPUPort
+	})
+
+	res, err := client.EchoGTPU(context.Background(), &pb.GTPUEchoRequest{})
+	require.NoError(t, err)
+	require.True(t, res.Reachable)
+	require.Positive(t, res.RoundTripTime)
+
+	_, err = client.EchoGTPU(context.Background(), &pb.GTPUEchoRequest{Timeout: -1})
+	require.Error(t, err)
+
+	// a timeout is not an error
+	responder.SetSilent(true)
+
+	res, err = client.EchoGTPU(context.Background(), &pb.GTPUEchoRequest{Timeout: 100})
+	require.NoError(t, err)
+	require.False(t, res.Reachable)
+	require.Zero(t, res.RoundTripTime)
+	require.Equal(t, 2, responder.Received())
+}
+
+// requirePFCPCause asserts that err carries cause as a pb.PFCPCause detail.
+func requirePFCPCause(t *testing.T, err error, cause uint8) {
+	t.Helper()
+
+	require.Error(t, err)
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+
+	detail, ok := details[0].(*pb.PFCPCause)
+	require.True(t, ok)
+	require.Equal(t, uint32(cause), detail.Cause)
+	require.Equal(t, pfcpsim.CauseDescription(cause), detail.Description)
+}
+
+func TestSessionRejectionCause(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	createRequest := &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	}
+
+	// the session to modify and delete
+	_, err := client.CreateSession(context.Background(), createRequest)
+	require.NoError(t, err)
+
+	upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+		return message.NewSessionEstablishmentResponse(0, 0, 0, req.Sequence(), 0,
+			upf.NodeID(), ie.NewCause(ie.CauseRuleCreationModificationFailure))
+	})
+
+	createRequest.BaseID = 101
+	_, err = client.CreateSession(context.Background(), createRequest)
+	requirePFCPCause(t, err, ie.CauseRuleCreationModificationFailure)
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	// the concurrent creation reports the cause too
+	createRequest.Count, createRequest.Concurrency = 2, 2
+	_, err = client.CreateSession(context.Background(), createRequest)
+	requirePFCPCause(t, err, ie.CauseRuleCreationModificationFailure)
+
+	upf.HandleFunc(message.MsgTypeSessionModificationRequest, func(req message.Message) message.Message {
+		return message.NewSessionModificationResponse(0, 0, 0, req.Sequence(), 0, ie.NewCause(ie.CauseMandatoryIEIncorrect))
+	})
+
+	_, err = client.ModifySession(context.Background(), &pb.ModifySessionRequest{
+		Count:        1,
+		BaseID:       1,
+		NodeBAddress: "198.18.0.10",
+	})
+	requirePFCPCause(t, err, ie.CauseMandatoryIEIncorrect)
+
+	upf.HandleFunc(message.MsgTypeSessionDeletionRequest, func(req message.Message) message.Message {
+		return message.NewSessionDeletionResponse(0, 0, 0, req.Sequence(), 0, ie.NewCause(ie.CauseSessionContextNotFound))
+	})
+
+	_, err = client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{Count: 1, BaseID: 1})
+	requirePFCPCause(t, err, ie.CauseSessionContextNotFound)
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	t.Run("no cause on other errors", func(t *testing.T) {
+		_, err := client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{Count: 1, BaseID: 501})
+		require.Error(t, err)
+		require.Empty(t, status.Convert(err).Details())
+	})
+}
+
+func TestDeleteSessionPartialFailure(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	_, err := client.CreateSession(context.Background(), &pb.CreateSessionRequest{
+		Count:         3,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	require.NoError(t, err)
+
+	rejected, ok := activeSessions.Get(11)
+	require.True(t, ok)
+
+	rejectedSEID := rejected.PeerSEID()
+
+	upf.HandleFunc(message.MsgTypeSessionDeletionRequest, func(req message.Message) message.Message {
+		cause := ie.CauseRequestAccepted
+		if req.SEID() == rejectedSEID {
+			cause = ie.CauseSessionContextNotFound
+		}
+
+		return message.NewSessionDeletionResponse(0, 0, 0, req.Sequence(), 0, ie.NewCause(cause))
+	})
+
+	// the deletion carries on past the rejected session
+	res, err := client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{Count: 3, BaseID: 1})
+	require.NoError(t, err)
+	require.Equal(t, []int32{1, 21}, res.DeletedBaseIDs)
+	require.Len(t, res.Failures, 1)
+	require.Equal(t, int32(11), res.Failures[0].BaseID)
+	require.Equal(t, uint32(ie.CauseSessionContextNotFound), res.Failures[0].Cause)
+	require.NotEmpty(t, res.Failures[0].Error)
+	require.Equal(t, int32(1), res.ActiveSessions)
+	require.Len(t, upf.Received(message.MsgTypeSessionDeletionRequest), 3)
+
+	// the session not deleted is still active
+	_, ok = activeSessions.Get(11)
+	require.True(t, ok)
+
+	// the request fails if none of the sessions is deleted
+	_, err = client.DeleteSession(context.Background(), &pb.DeleteSessionRequest{Count: 1, BaseID: 11})
+	requirePFCPCause(t, err, ie.CauseSessionContextNotFound)
+	require.Equal(t, 1, activeSessions.Len())
+}
+
+func TestSessionFailedRules(t *testing.T) {
+	upf := setupAssociation(t)
+	client := startServer(t)
+
+	createRequest := &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	}
+
+	// the session is accepted, but one of its FARs is not
+	upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+		return message.NewSessionEstablishmentResponse(0, 0, 0, req.Sequence(), 0,
+			upf.NodeID(),
+			ie.NewCause(ie.CauseRequestAccepted),
+			ie.NewFSEID(1, net.IPv4(127, 0, 0, 1), nil),
+			ie.NewFailedRuleID(ie.RuleIDTypeFAR, 2),
+		)
+	})
+
+	res, err := client.CreateSession(context.Background(), createRequest)
+	require.NoError(t, err)
+	require.Len(t, res.Sessions, 1)
+	require.Len(t, res.Sessions[0].FailedRules, 1)
+	require.Equal(t, "FAR", res.Sessions[0].FailedRules[0].Type)
+	require.Equal(t, uint32(2), res.Sessions[0].FailedRules[0].Id)
+
+	upf.HandleFunc(message.MsgTypeSessionModificationRequest, func(req message.Message) message.Message {
+		return message.NewSessionModificationResponse(0, 0, 0, req.Sequence(), 0,
+			ie.NewCause(ie.CauseRequestAccepted),
+			ie.NewFailedRuleID(ie.RuleIDTypeFAR, 3),
+		)
+	})
+
+	modifyRequest := &pb.ModifySessionRequest{Count: 1, BaseID: 1, NodeBAddress: "198.18.0.10"}
+
+	modRes, err := client.ModifySession(context.Background(), modifyRequest)
+	require.NoError(t, err)
+	require.Len(t, modRes.FailedRules, 1)
+	require.Equal(t, "FAR", modRes.FailedRules[0].Type)
+	require.Equal(t, uint32(3), modRes.FailedRules[0].Id)
+
+	// the rule of the rejected modification is part of the error
+	upf.HandleFunc(message.MsgTypeSessionModificationRequest, func(req message.Message) message.Message {
+		return message.NewSessionModificationResponse(0, 0, 0, req.Sequence(), 0,
+			ie.NewCause(ie.CauseRuleCreationModificationFailure),
+			ie.NewFailedRuleID(ie.RuleIDTypeFAR, 4),
+			ie.NewOffendingIE(ie.UpdateFAR),
+		)
+	})
+
+	_, err = client.ModifySession(context.Background(), modifyRequest)
+	requirePFCPCause(t, err, ie.CauseRuleCreationModificationFailure)
+
+	detail := status.Convert(err).Details()[0].(*pb.PFCPCause)
+	require.Equal(t, "FAR", detail.FailedRule.Type)
+	require.Equal(t, uint32(4), detail.FailedRule.Id)
+	require.Equal(t, uint32(ie.UpdateFAR), detail.OffendingIE)
+}
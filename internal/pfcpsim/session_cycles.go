@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim/session"
+	log "github.com/sirupsen/logrus"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// cycleSessionQERID is the ID of the session QER of the sessions established by CreateSessionAndDelete
+const cycleSessionQERID = 100
+
+// sessionCycleStats counts the outcomes of the cycles run by runSessionCycles. It is updated atomically.
+type sessionCycleStats struct {
+	// cycles is the number of sessions both established and deleted
+	cycles int64
+	// establishmentErrors and deletionErrors count the sessions that could not be established or deleted
+	establishmentErrors int64
+	deletionErrors      int64
+}
+
+// newCycleSessionRules returns the rules of the session established by CreateSessionAndDelete with baseID:
+// a pair of uplink and downlink PDRs and FARs matching all the traffic, enforcing only the session QER.
+func newCycleSessionRules(baseID int, nodeBAddress string, ueAddress string) (sessionRules, error) {
+	sdfFilter, _, precedence, _, err := parseAppFilter(passThroughAppFilter)
+	if err != nil {
+		return sessionRules{}, err
+	}
+
+	uplinkID, downlinkID := uint16(baseID), uint16(baseID+1)
+
+	uplinkPDR, err := session.NewPDRBuilder().
+		WithID(uplinkID).
+		WithMethod(session.Create).
+		WithTEID(uint32(baseID)).
+		WithFARID(uint32(uplinkID)).
+		AddQERIDs(cycleSessionQERID).
+		WithN3Address(upfN3Address).
+		WithSDFFilter(sdfFilter, false).
+		WithPrecedence(precedence).
+		MarkAsUplink().
+		BuildE()
+	if err != nil {
+		return sessionRules{}, err
+	}
+
+	downlinkPDR, err := session.NewPDRBuilder().
+		WithID(downlinkID).
+		WithMethod(session.Create).
+		WithPrecedence(precedence).
+		WithUEAddress(ueAddress).
+		WithSDFFilter(sdfFilter, false).
+		AddQERIDs(cycleSessionQERID).
+		WithFARID(uint32(downlinkID)).
+		MarkAsDownlink().
+		BuildE()
+	if err != nil {
+		return sessionRules{}, err
+	}
+
+	uplinkFAR, err := session.NewFARBuilder().
+		WithID(uint32(uplinkID)).
+		WithAction(session.ActionForward).
+		WithDstInterface(ieLib.DstInterfaceCore).
+		WithMethod(session.Create).
+		WithUplinkIP("0.0.0.0").
+		BuildE()
+	if err != nil {
+		return sessionRules{}, err
+	}
+
+	downlinkFAR, err := session.NewFARBuilder().
+		WithID(uint32(downlinkID)).
+		WithAction(session.ActionForward).
+		WithMethod(session.Create).
+		WithDstInterface(ieLib.DstInterfaceAccess).
+		WithTEID(uint32(baseID)).
+		WithDownlinkIP(nodeBAddress).
+		BuildE()
+	if err != nil {
+		return sessionRules{}, err
+	}
+
+	sessQER, err := session.NewQERBuilder().
+		WithID(cycleSessionQERID).
+		WithMethod(session.Create).
+		BuildE()
+	if err != nil {
+		return sessionRules{}, err
+	}
+
+	return sessionRules{
+		pdrs: []*ieLib.IE{uplinkPDR, downlinkPDR},
+		fars: []*ieLib.IE{uplinkFAR, downlinkFAR},
+		qers: []*ieLib.IE{sessQER},
+	}, nil
+}
+
+// runSessionCycles establishes through client a session and deletes it right after, iterations times.
+// As many cycles as the base IDs run at the same time, the session of each cycle being established
+// with its own base ID and rules. Sessions are not recorded in activeSessions.
+// Stops early once ctx is done. The sessions established are deleted anyway.
+func runSessionCycles(ctx context.Context, client *pfcpsim.PFCPClient, iterations int, baseIDs []int, rules []sessionRules) *sessionCycleStats {
+	var (
+		stats   sessionCycleStats
+		started int64
+		wg      sync.WaitGroup
+	)
+
+	for k := range baseIDs {
+		wg.Add(1)
+
+		go func(baseID int, rules sessionRules) {
+			defer wg.Done()
+
+			for ctx.Err() == nil && atomic.AddInt64(&started, 1) <= int64(iterations) {
+				sess, err := establishSession(ctx, client, baseID, ieLib.PDNTypeIPv4, rules.pdrs, rules.fars, rules.qers)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+
+					log.Debugf("Could not establish session with baseID %v: %v", baseID, err)
+					atomic.AddInt64(&stats.establishmentErrors, 1)
+
+					continue
+				}
+
+				// the session is deleted even if ctx is done, not to leave it behind on the remote peer
+				if err := client.DeleteSessionWithContext(context.Background(), sess); err != nil {
+					log.Debugf("Could not delete session with baseID %v: %v", baseID, err)
+					atomic.AddInt64(&stats.deletionErrors, 1)
+
+					continue
+				}
+
+				atomic.AddInt64(&stats.cycles, 1)
+			}
+		}(baseIDs[k], rules[k])
+	}
+
+	wg.Wait()
+
+	return &stats
+}
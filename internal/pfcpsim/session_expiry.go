@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// expiryTimers keeps the timers deleting the sessions created with a TTL, indexed by base ID
+	expiryTimers     = make(map[int]*time.Timer)
+	lockExpiryTimers = new(sync.Mutex)
+)
+
+// scheduleSessionExpiry deletes the session identified by baseID once ttl elapses,
+// unless cancelSessionExpiry is invoked before.
+func scheduleSessionExpiry(baseID int, ttl time.Duration) {
+	lockExpiryTimers.Lock()
+	defer lockExpiryTimers.Unlock()
+
+	if previous, ok := expiryTimers[baseID]; ok {
+		previous.Stop()
+	}
+
+	var timer *time.Timer
+
+	timer = time.AfterFunc(ttl, func() {
+		if !claimSessionExpiry(baseID, timer) {
+			// cancelled or replaced while firing
+			return
+		}
+
+		expireSession(baseID)
+	})
+
+	expiryTimers[baseID] = timer
+}
+
+// claimSessionExpiry removes timer from expiryTimers. Returns false if timer is no longer scheduled for baseID.
+func claimSessionExpiry(baseID int, timer *time.Timer) bool {
+	lockExpiryTimers.Lock()
+	defer lockExpiryTimers.Unlock()
+
+	if expiryTimers[baseID] != timer {
+		return false
+	}
+
+	delete(expiryTimers, baseID)
+
+	return true
+}
+
+// cancelSessionExpiry stops the timer deleting the session identified by baseID, if any.
+// It must be invoked before deleting a session, to avoid deleting it twice.
+func cancelSessionExpiry(baseID int) {
+	lockExpiryTimers.Lock()
+	defer lockExpiryTimers.Unlock()
+
+	if timer, ok := expiryTimers[baseID]; ok {
+		timer.Stop()
+		delete(expiryTimers, baseID)
+	}
+}
+
+// expireSession deletes the session identified by baseID, both from the remote peer and locally.
+func expireSession(baseID int) {
+	sess, ok := activeSessions.Get(baseID)
+	if !ok {
+		return
+	}
+
+	peer := sessionPeer(baseID)
+	activeSessions.Delete(baseID)
+
+	if err := deleteRemoteSession(context.Background(), peer, sess); err != nil {
+		log.Errorf("Could not delete expired session with baseID %v: %v", baseID, err)
+		return
+	}
+
+	log.Infof("Session with baseID %v expired and was deleted", baseID)
+}
@@ -0,0 +1,263 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	pb "github.com/ardzoht/pfcpsim/api"
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// errTEIDsExhausted is returned when no TEID is available for allocation.
+var errTEIDsExhausted = errors.New("all the TEIDs are in use")
+
+// errSessionNotActive is returned when no active session exists with the requested base ID.
+var errSessionNotActive = errors.New("no active session")
+
+// errSessionsActive is returned when active sessions exist already with the requested base IDs.
+var errSessionsActive = errors.New("active sessions already exist")
+
+// errSessionRulesUnknown is returned when the rules a session was created with are not known.
+var errSessionRulesUnknown = errors.New("session rules are not known")
+
+// errSessionStale is returned when a session lost by the remote peer after restarting is to be modified.
+var errSessionStale = errors.New("session is stale: remote peer restarted")
+
+// sessionRecord describes the UE addresses and the rules of a session created by pfcpsim.
+type sessionRecord struct {
+	UeAddress     string   `json:"ueAddress,omitempty"`
+	UeIPv6Address string   `json:"ueIPv6Address,omitempty"`
+	PDRIDs        []uint16 `json:"pdrIDs,omitempty"`
+	FARIDs        []uint32 `json:"farIDs,omitempty"`
+	QERIDs        []uint32 `json:"qerIDs,omitempty"`
+	// AppRules are the IDs of the rules created for each app filter, in the order of the filters
+	AppRules []appRuleIDs `json:"appRules,omitempty"`
+	// SessionQERID is the ID of the QER enforcing the session AMBR, 0 if not created
+	SessionQERID uint32 `json:"sessionQERID,omitempty"`
+	// Peer is the address of the remote peer the session was established with, empty for remotePeerAddress
+	Peer string `json:"peer,omitempty"`
+	// UplinkDstInterface and DownlinkDstInterface are the destination interfaces of the FARs, as requested
+	UplinkDstInterface   pb.DestinationInterface `json:"uplinkDstInterface,omitempty"`
+	DownlinkDstInterface pb.DestinationInterface `json:"downlinkDstInterface,omitempty"`
+}
+
+// appRuleIDs are the IDs of the rules created for an app filter. The IDs of the direction not requested are 0.
+type appRuleIDs struct {
+	UplinkPDRID   uint16 `json:"uplinkPDRID,omitempty"`
+	DownlinkPDRID uint16 `json:"downlinkPDRID,omitempty"`
+	UplinkFARID   uint32 `json:"uplinkFARID,omitempty"`
+	DownlinkFARID uint32 `json:"downlinkFARID,omitempty"`
+	UplinkQERID   uint32 `json:"uplinkQERID,omitempty"`
+	DownlinkQERID uint32 `json:"downlinkQERID,omitempty"`
+}
+
+// sessionRules are the rules a session was established with, used to re-establish it.
+// They are not dumped: sessions restored by loadState can't be re-established.
+type sessionRules struct {
+	pdrs, fars, qers []*ieLib.IE
+}
+
+// sessionStore keeps the active sessions indexed by their base ID, and the uplink TEIDs they use.
+// It is safe for concurrent use.
+type sessionStore struct {
+	lock     sync.RWMutex
+	sessions map[int]*pfcpsim.PFCPSession
+	// records keeps the description of the sessions, indexed by base ID
+	records map[int]sessionRecord
+	// rules keeps the rules of the sessions, indexed by base ID
+	rules map[int]sessionRules
+
+	// teids keeps the uplink TEID of each session, indexed by base ID
+	teids map[int]uint32
+	// teidUsers counts the sessions using each TEID
+	teidUsers map[uint32]int
+	// nextTEID is the first TEID tried by the next allocation
+	nextTEID uint32
+}
+
+func newSessionStore() *sessionStore {
+	return &sessionStore{
+		sessions:  make(map[int]*pfcpsim.PFCPSession),
+		records:   make(map[int]sessionRecord),
+		rules:     make(map[int]sessionRules),
+		teids:     make(map[int]uint32),
+		teidUsers: make(map[uint32]int),
+		nextTEID:  1,
+	}
+}
+
+func (s *sessionStore) Insert(index int, session *pfcpsim.PFCPSession) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sessions[index] = session
+}
+
+func (s *sessionStore) Get(index int) (*pfcpsim.PFCPSession, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	session, ok := s.sessions[index]
+
+	return session, ok
+}
+
+func (s *sessionStore) Delete(index int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.sessions, index)
+	delete(s.records, index)
+	delete(s.rules, index)
+	s.releaseTEID(index)
+}
+
+// SetRecord stores the description of the session identified by index. It is forgotten by Delete.
+func (s *sessionStore) SetRecord(index int, record sessionRecord) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.records[index] = record
+}
+
+// Record returns the description of the session identified by index, if any.
+func (s *sessionStore) Record(index int) (sessionRecord, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	record, ok := s.records[index]
+
+	return record, ok
+}
+
+// SetRules stores the rules of the session identified by index. They are forgotten by Delete.
+func (s *sessionStore) SetRules(index int, rules sessionRules) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.rules[index] = rules
+}
+
+// Rules returns the rules of the session identified by index, if known.
+func (s *sessionStore) Rules(index int) (sessionRules, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	rules, ok := s.rules[index]
+
+	return rules, ok
+}
+
+// Clear forgets all the sessions, along with their descriptions, rules and TEIDs.
+func (s *sessionStore) Clear() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sessions = make(map[int]*pfcpsim.PFCPSession)
+	s.records = make(map[int]sessionRecord)
+	s.rules = make(map[int]sessionRules)
+	s.teids = make(map[int]uint32)
+	s.teidUsers = make(map[uint32]int)
+	s.nextTEID = 1
+}
+
+func (s *sessionStore) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.sessions)
+}
+
+// Range calls f for each session in the store, until f returns false.
+// f is invoked on a snapshot of the store, hence it can safely modify the store.
+func (s *sessionStore) Range(f func(index int, session *pfcpsim.PFCPSession) bool) {
+	s.lock.RLock()
+	snapshot := make(map[int]*pfcpsim.PFCPSession, len(s.sessions))
+
+	for index, session := range s.sessions {
+		snapshot[index] = session
+	}
+	s.lock.RUnlock()
+
+	for index, session := range snapshot {
+		if !f(index, session) {
+			return
+		}
+	}
+}
+
+// AllocateTEID assigns to the session identified by index the first TEID not used by any other session.
+// TEIDs are allocated sequentially and wrap around, skipping 0. The TEID is freed by Delete or ReleaseTEID.
+func (s *sessionStore) AllocateTEID(index int) (uint32, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.releaseTEID(index)
+
+	if uint64(len(s.teidUsers)) >= math.MaxUint32 {
+		return 0, errTEIDsExhausted
+	}
+
+	for s.teidUsers[s.nextTEID] > 0 || s.nextTEID == 0 {
+		s.nextTEID++
+	}
+
+	teid := s.nextTEID
+	s.nextTEID++
+	s.useTEID(index, teid)
+
+	return teid, nil
+}
+
+// ReserveTEID records that the session identified by index uses teid, which is chosen by the caller.
+// The TEID is then skipped by AllocateTEID, until freed by Delete or ReleaseTEID.
+func (s *sessionStore) ReserveTEID(index int, teid uint32) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.releaseTEID(index)
+	s.useTEID(index, teid)
+}
+
+// TEID returns the uplink TEID of the session identified by index, if allocated or reserved.
+func (s *sessionStore) TEID(index int) (uint32, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	teid, ok := s.teids[index]
+
+	return teid, ok
+}
+
+// ReleaseTEID frees the TEID of the session identified by index, if any.
+func (s *sessionStore) ReleaseTEID(index int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.releaseTEID(index)
+}
+
+// useTEID must be invoked with the lock held.
+func (s *sessionStore) useTEID(index int, teid uint32) {
+	s.teids[index] = teid
+	s.teidUsers[teid]++
+}
+
+// releaseTEID must be invoked with the lock held.
+func (s *sessionStore) releaseTEID(index int) {
+	teid, ok := s.teids[index]
+	if !ok {
+		return
+	}
+
+	delete(s.teids, index)
+
+	if s.teidUsers[teid]--; s.teidUsers[teid] <= 0 {
+		delete(s.teidUsers, teid)
+	}
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStore(t *testing.T) {
+	store := newSessionStore()
+
+	sess := &pfcpsim.PFCPSession{}
+	store.Insert(1, sess)
+	store.Insert(11, &pfcpsim.PFCPSession{})
+
+	got, ok := store.Get(1)
+	require.True(t, ok)
+	require.Same(t, sess, got)
+	require.Equal(t, 2, store.Len())
+
+	visited := 0
+
+	store.Range(func(index int, session *pfcpsim.PFCPSession) bool {
+		// the store can be modified while ranging over it
+		store.Delete(index)
+		visited++
+
+		return true
+	})
+
+	require.Equal(t, 2, visited)
+	require.Zero(t, store.Len())
+
+	_, ok = store.Get(1)
+	require.False(t, ok)
+}
+
+func TestSessionStoreTEIDs(t *testing.T) {
+	store := newSessionStore()
+
+	// TEIDs chosen by the caller are skipped by the allocation
+	store.ReserveTEID(1, 2)
+
+	teid, err := store.AllocateTEID(11)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), teid)
+
+	teid, err = store.AllocateTEID(21)
+	require.NoError(t, err)
+	require.Equal(t, uint32(3), teid)
+
+	got, ok := store.TEID(21)
+	require.True(t, ok)
+	require.Equal(t, uint32(3), got)
+
+	// deleting a session frees its TEID
+	store.Insert(21, &pfcpsim.PFCPSession{})
+	store.Delete(21)
+
+	_, ok = store.TEID(21)
+	require.False(t, ok)
+
+	store.ReleaseTEID(1)
+
+	// freed TEIDs are reused only once the allocation wraps around
+	teid, err = store.AllocateTEID(31)
+	require.NoError(t, err)
+	require.Equal(t, uint32(4), teid)
+
+	store.nextTEID = math.MaxUint32
+
+	teid, err = store.AllocateTEID(41)
+	require.NoError(t, err)
+	require.Equal(t, uint32(math.MaxUint32), teid)
+
+	teid, err = store.AllocateTEID(51)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), teid, "TEID 0 must be skipped and TEID 1 is still in use")
+}
+
+func TestSessionStoreConcurrentAccess(t *testing.T) {
+	const (
+		workers           = 8
+		sessionsPerWorker = 200
+	)
+
+	store := newSessionStore()
+	wg := sync.WaitGroup{}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < sessionsPerWorker; i++ {
+				index := w*sessionsPerWorker + i
+
+				store.Insert(index, &pfcpsim.PFCPSession{})
+				_, _ = store.Get(index)
+				_ = store.Len()
+
+				store.Range(func(int, *pfcpsim.PFCPSession) bool {
+					return false
+				})
+
+				if i%2 == 0 {
+					store.Delete(index)
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	require.Equal(t, workers*sessionsPerWorker/2, store.Len())
+}
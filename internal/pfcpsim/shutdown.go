@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+// Shutdown closes the connection to the remote peer, if any. If releaseAssociation is set, the association
+// is released first: sessions are left on the remote peer otherwise.
+// It must be called once the gRPC server stopped serving requests.
+func Shutdown(releaseAssociation bool) {
+	if !isRemotePeerConnected() {
+		return
+	}
+
+	if releaseAssociation && sim.IsAssociationAlive() {
+		if _, err := sim.ReleaseAssociation(); err != nil {
+			log.Warnf("Could not release the association on shutdown: %v", err)
+		} else {
+			log.Info("Association released")
+		}
+	}
+
+	sim.DisconnectN4()
+	disconnectAdditionalPeers(releaseAssociation)
+
+	setRemotePeerConnected(false)
+
+	log.Info("Connection to remote peer closed")
+}
@@ -5,39 +5,104 @@ package pfcpsim
 
 import (
 	"sync"
+	"time"
 
-	"github.com/omec-project/pfcpsim/pkg/pfcpsim"
+	pb "github.com/ardzoht/pfcpsim/api"
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	log "github.com/sirupsen/logrus"
 )
 
 var (
-	activeSessions     = make(map[int]*pfcpsim.PFCPSession, 0)
-	lockActiveSessions = new(sync.Mutex)
+	activeSessions = newSessionStore()
 
 	remotePeerAddress string
 	upfN3Address      string
 
+	// localN4Address is the source address of N4 messages. If empty, the address of interfaceName is used
+	localN4Address string
+	// pfcpPort is the PFCP port of the remote peer, used if remotePeerAddress does not include one
+	pfcpPort int
+	// capturePath is the pcap file PFCP messages are written to. Empty if capture is disabled
+	capturePath string
+	// cpFunctionFeatures are advertised to the remote peer during association setup
+	cpFunctionFeatures uint8
+
+	// nodeIDType and nodeID identify pfcpsim to the remote peers. If nodeID is empty, the local address is used instead
+	nodeIDType uint8
+	nodeID     string
+
+	// csid identifies the PDN connection set of the sessions established with the remote peers, 0 if none
+	csid uint16
+
+	// seidAllocation selects how the local SEIDs of the sessions are allocated
+	seidAllocation pb.SeidAllocation
+
+	// messagePriority is the priority set in the header of the session related requests, -1 if disabled
+	messagePriority = -1
+
+	// associationRetries is the number of times a failed association setup is retried
+	associationRetries int
+	// reestablishOnErrorIndication makes the sessions reported by an Error Indication Report established again
+	reestablishOnErrorIndication bool
+	// maxMissedHeartbeats is the number of consecutive unanswered heartbeats after which the N4 path is failed
+	maxMissedHeartbeats = pfcpsim.DefaultMaxMissedHeartbeats
+	// operationTimeouts are the response timeouts of the PFCP operations, if not the default one
+	operationTimeouts = make(map[pfcpsim.Operation]time.Duration)
+	// reassociationBackoff is the initial backoff of the automatic re-association once the N4 path fails, 0 if disabled
+	reassociationBackoff time.Duration
+
+	// defaultQFI, defaultUlAmbr and defaultDlAmbr are used by the CreateSession requests not specifying them
+	defaultQFI    int32
+	defaultUlAmbr int32
+	defaultDlAmbr int32
+
 	interfaceName string
 
+	// gtpuPort is the port GTP-U Echo Requests are sent to on upfN3Address
+	gtpuPort = pfcpsim.GTPUPort
+
+	// idleTimeout is the time after which a session without any usage report is deleted.
+	// Zero disables the idle teardown.
+	idleTimeout time.Duration
+
+	// reportSubscribers keeps a channel for each client subscribed to session reports
+	reportSubscribers     = make(map[chan *pb.SessionReport]struct{})
+	lockReportSubscribers = new(sync.Mutex)
+
 	// Emulates 5G SMF/ 4G SGW
-	sim                 *pfcpsim.PFCPClient
-	remotePeerConnected bool
+	sim *pfcpsim.PFCPClient
+	// remotePeerConnected is 1 while the N4 connection to the remote peer is open. It is accessed atomically,
+	// see isRemotePeerConnected and setRemotePeerConnected
+	remotePeerConnected int32
 )
 
-func insertSession(index int, session *pfcpsim.PFCPSession) {
-	lockActiveSessions.Lock()
-	defer lockActiveSessions.Unlock()
+func addReportSubscriber() chan *pb.SessionReport {
+	lockReportSubscribers.Lock()
+	defer lockReportSubscribers.Unlock()
+
+	ch := make(chan *pb.SessionReport, reportSubscriberBufferSize)
+	reportSubscribers[ch] = struct{}{}
 
-	activeSessions[index] = session
+	return ch
 }
 
-func getSession(index int) (*pfcpsim.PFCPSession, bool) {
-	element, ok := activeSessions[index]
-	return element, ok
+func removeReportSubscriber(ch chan *pb.SessionReport) {
+	lockReportSubscribers.Lock()
+	defer lockReportSubscribers.Unlock()
+
+	delete(reportSubscribers, ch)
 }
 
-func deleteSession(index int) {
-	lockActiveSessions.Lock()
-	defer lockActiveSessions.Unlock()
+// publishReport delivers report to every subscriber. Slow subscribers miss the report instead of blocking others.
+func publishReport(report *pb.SessionReport) {
+	lockReportSubscribers.Lock()
+	defer lockReportSubscribers.Unlock()
 
-	delete(activeSessions, index)
+	for ch := range reportSubscribers {
+		select {
+		case ch <- report:
+		default:
+			log.Warnf("Dropping session report for SEID %v: subscriber is too slow", report.Seid)
+		}
+	}
 }
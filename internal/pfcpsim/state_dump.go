@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// sessionState is the dump of an active session.
+type sessionState struct {
+	BaseID     int    `json:"baseID"`
+	LocalSEID  uint64 `json:"localSEID"`
+	PeerSEID   uint64 `json:"peerSEID"`
+	UplinkTEID uint32 `json:"uplinkTEID,omitempty"`
+	HasBAR     bool   `json:"hasBAR,omitempty"`
+	sessionRecord
+}
+
+// simulatorState is the dump of the active sessions, written by dumpState and read by loadState.
+type simulatorState struct {
+	Sessions []sessionState `json:"sessions"`
+}
+
+// newSessionRecord returns the description of a session created with the given UE addresses and rules.
+func newSessionRecord(ueAddress, ueIPv6Address string, pdrs, fars, qers []*ieLib.IE) sessionRecord {
+	record := sessionRecord{
+		UeAddress:     ueAddress,
+		UeIPv6Address: ueIPv6Address,
+	}
+
+	for _, pdr := range pdrs {
+		if id, err := pdr.PDRID(); err == nil {
+			record.PDRIDs = append(record.PDRIDs, id)
+		}
+	}
+
+	for _, far := range fars {
+		if id, err := far.FARID(); err == nil {
+			record.FARIDs = append(record.FARIDs, id)
+		}
+	}
+
+	for _, qer := range qers {
+		if id, err := qer.QERID(); err == nil {
+			record.QERIDs = append(record.QERIDs, id)
+		}
+	}
+
+	return record
+}
+
+// dumpState writes the active sessions to path as JSON, sorted by base ID.
+// Returns the number of sessions written.
+func dumpState(path string) (int, error) {
+	state := simulatorState{Sessions: []sessionState{}}
+
+	activeSessions.Range(func(index int, sess *pfcpsim.PFCPSession) bool {
+		dumped := sessionState{
+			BaseID:    index,
+			LocalSEID: sess.LocalSEID(),
+			PeerSEID:  sess.PeerSEID(),
+			HasBAR:    sess.HasBAR(),
+		}
+
+		dumped.UplinkTEID, _ = activeSessions.TEID(index)
+		dumped.sessionRecord, _ = activeSessions.Record(index)
+
+		state.Sessions = append(state.Sessions, dumped)
+
+		return true
+	})
+
+	sort.Slice(state.Sessions, func(i, j int) bool {
+		return state.Sessions[i].BaseID < state.Sessions[j].BaseID
+	})
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return 0, err
+	}
+
+	return len(state.Sessions), nil
+}
+
+// loadState reads the sessions dumped to path by dumpState and adds them to activeSessions,
+// without establishing them on the remote peer. No session is loaded if any of them is active already.
+// Returns the number of sessions loaded.
+func loadState(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var state simulatorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return 0, fmt.Errorf("invalid state file %v: %w", path, err)
+	}
+
+	for _, loaded := range state.Sessions {
+		if _, ok := activeSessions.Get(loaded.BaseID); ok {
+			return 0, fmt.Errorf("%w with baseID %v", errSessionsActive, loaded.BaseID)
+		}
+
+		if _, err := peerClient(loaded.Peer); err != nil {
+			return 0, fmt.Errorf("session with baseID %v: %w", loaded.BaseID, err)
+		}
+	}
+
+	for _, loaded := range state.Sessions {
+		client, _ := peerClient(loaded.Peer)
+		sess := client.RestoreSession(loaded.LocalSEID, loaded.PeerSEID, loaded.HasBAR)
+
+		activeSessions.Insert(loaded.BaseID, sess)
+		activeSessions.SetRecord(loaded.BaseID, loaded.sessionRecord)
+
+		if loaded.UplinkTEID != 0 {
+			activeSessions.ReserveTEID(loaded.BaseID, loaded.UplinkTEID)
+		}
+	}
+
+	return len(state.Sessions), nil
+}
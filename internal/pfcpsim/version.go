@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+// Version and BuildCommit identify the pfcpsim build. They are set at build time, e.g. with
+// -ldflags "-X github.com/ardzoht/pfcpsim/internal/pfcpsim.Version=$(cat VERSION)".
+var (
+	Version     = "dev"
+	BuildCommit = "unknown"
+)
+
+// pfcpFeatures are the PFCP procedures and options supported by pfcpsim, reported by the Info RPC.
+var pfcpFeatures = []string{
+	"association-setup",
+	"association-release",
+	"association-update",
+	"heartbeat",
+	"session-establishment",
+	"session-modification",
+	"session-deletion",
+	"session-set-deletion",
+	"session-report",
+	"node-report",
+	"pfd-management",
+	"upf-teid-allocation",
+	"ipv6-ue-addresses",
+	"fq-csid",
+}
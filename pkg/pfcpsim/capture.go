@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+// captureSnapLen is the maximum length of the packets written to a capture.
+const captureSnapLen = 65535
+
+// capture writes PFCP datagrams to a pcap file, adding IP and UDP headers.
+type capture struct {
+	lock   sync.Mutex
+	file   *os.File
+	writer *pcapgo.Writer
+}
+
+// newCapture creates the pcap file at path, truncating it if it exists already.
+func newCapture(path string) (*capture, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := pcapgo.NewWriter(file)
+	if err := writer.WriteFileHeader(captureSnapLen, layers.LinkTypeRaw); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &capture{
+		file:   file,
+		writer: writer,
+	}, nil
+}
+
+// write appends payload to the capture as a UDP datagram sent from src to dst.
+func (c *capture) write(payload []byte, src, dst *net.UDPAddr) error {
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(src.Port),
+		DstPort: layers.UDPPort(dst.Port),
+	}
+
+	var ip gopacket.NetworkLayer
+
+	if src.IP.To4() != nil {
+		ip = &layers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    src.IP.To4(),
+			DstIP:    dst.IP.To4(),
+		}
+	} else {
+		ip = &layers.IPv6{
+			Version:    6,
+			HopLimit:   64,
+			NextHeader: layers.IPProtocolUDP,
+			SrcIP:      src.IP,
+			DstIP:      dst.IP,
+		}
+	}
+
+	if err := udp.SetNetworkLayerForChecksum(ip); err != nil {
+		return err
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+
+	if err := gopacket.SerializeLayers(buf, opts, ip.(gopacket.SerializableLayer), udp, gopacket.Payload(payload)); err != nil {
+		return err
+	}
+
+	data := buf.Bytes()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.writer.WritePacket(gopacket.CaptureInfo{
+		Timestamp:     time.Now(),
+		CaptureLength: len(data),
+		Length:        len(data),
+	}, data)
+}
+
+func (c *capture) close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.file.Close()
+}
+
+// StartCapture writes all the PFCP messages sent and received by the client to a pcap file at path,
+// which is truncated if it exists already. It must be invoked after ConnectN4.
+// The capture is stopped by StopCapture or DisconnectN4.
+func (c *PFCPClient) StartCapture(path string) error {
+	capture, err := newCapture(path)
+	if err != nil {
+		return err
+	}
+
+	c.captureLock.Lock()
+	previous := c.capture
+	c.capture = capture
+	c.captureLock.Unlock()
+
+	if previous != nil {
+		return previous.close()
+	}
+
+	return nil
+}
+
+// StopCapture stops writing PFCP messages to the capture file, if any, and closes it.
+func (c *PFCPClient) StopCapture() error {
+	c.captureLock.Lock()
+	capture := c.capture
+	c.capture = nil
+	c.captureLock.Unlock()
+
+	if capture == nil {
+		return nil
+	}
+
+	return capture.close()
+}
+
+// capturePacket writes payload to the capture, if any. Capture errors don't affect the PFCP exchanges.
+func (c *PFCPClient) capturePacket(payload []byte, src, dst net.Addr) {
+	c.captureLock.RLock()
+	defer c.captureLock.RUnlock()
+
+	if c.capture == nil {
+		return
+	}
+
+	srcAddr, srcOk := src.(*net.UDPAddr)
+	dstAddr, dstOk := dst.(*net.UDPAddr)
+
+	if !srcOk || !dstOk {
+		return
+	}
+
+	_ = c.capture.write(payload, srcAddr, dstAddr)
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/stretchr/testify/require"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+func TestCapture(t *testing.T) {
+	client, _ := newConnectedClient(t)
+
+	path := filepath.Join(t.TempDir(), "pfcp.pcap")
+	require.NoError(t, client.StartCapture(path))
+
+	require.NoError(t, client.SetupAssociation())
+	require.NoError(t, client.StopCapture())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	reader, err := pcapgo.NewReader(file)
+	require.NoError(t, err)
+	require.Equal(t, layers.LinkTypeRaw, reader.LinkType())
+
+	var msgTypes []uint8
+
+	for {
+		data, _, err := reader.ReadPacketData()
+		if err != nil {
+			break
+		}
+
+		packet := gopacket.NewPacket(data, layers.LayerTypeIPv4, gopacket.Default)
+
+		udp, ok := packet.Layer(layers.LayerTypeUDP).(*layers.UDP)
+		require.True(t, ok)
+
+		msg, err := message.Parse(udp.Payload)
+		require.NoError(t, err)
+
+		msgTypes = append(msgTypes, msg.MessageType())
+	}
+
+	require.Equal(t, []uint8{message.MsgTypeAssociationSetupRequest, message.MsgTypeAssociationSetupResponse}, msgTypes)
+}
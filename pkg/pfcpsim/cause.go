@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"errors"
+	"fmt"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// causeDescriptions maps the values of the Cause IE to their descriptions, as in 3GPP TS 29.244.
+var causeDescriptions = map[uint8]string{
+	ieLib.CauseRequestAccepted:                 "Request accepted (success)",
+	2:                                          "More Usage Report to send",
+	3:                                          "Request partially accepted",
+	ieLib.CauseRequestRejected:                 "Request rejected (reason not specified)",
+	ieLib.CauseSessionContextNotFound:          "Session context not found",
+	ieLib.CauseMandatoryIEMissing:              "Mandatory IE missing",
+	ieLib.CauseConditionalIEMissing:            "Conditional IE missing",
+	ieLib.CauseInvalidLength:                   "Invalid length",
+	ieLib.CauseMandatoryIEIncorrect:            "Mandatory IE incorrect",
+	ieLib.CauseInvalidForwardingPolicy:         "Invalid Forwarding Policy",
+	ieLib.CauseInvalidFTEIDAllocationOption:    "Invalid F-TEID allocation option",
+	ieLib.CauseNoEstablishedPFCPAssociation:    "No established PFCP Association",
+	ieLib.CauseRuleCreationModificationFailure: "Rule creation/modification Failure",
+	ieLib.CausePFCPEntityInCongestion:          "PFCP entity in congestion",
+	ieLib.CauseNoResourcesAvailable:            "No resources available",
+	ieLib.CauseServiceNotSupported:             "Service not supported",
+	ieLib.CauseSystemFailure:                   "System failure",
+	ieLib.CauseRedirectionRequested:            "Redirection Requested",
+	79:                                         "All dynamic addresses are occupied",
+}
+
+// ruleTypeNames maps the rule types of the Failed Rule ID IE to their names.
+var ruleTypeNames = map[uint8]string{
+	ieLib.RuleIDTypePDR: "PDR",
+	ieLib.RuleIDTypeFAR: "FAR",
+	ieLib.RuleIDTypeQER: "QER",
+	ieLib.RuleIDTypeURR: "URR",
+	ieLib.RuleIDTypeBAR: "BAR",
+}
+
+// FailedRule identifies a rule the peer failed to create or modify, as reported by a Failed Rule ID IE.
+type FailedRule struct {
+	// Type is one of the ieLib.RuleIDType* values
+	Type uint8
+	ID   uint32
+}
+
+// TypeName returns the name of the rule type, e.g. "FAR".
+func (r FailedRule) TypeName() string {
+	if name, ok := ruleTypeNames[r.Type]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("rule type %v", r.Type)
+}
+
+func (r FailedRule) String() string {
+	return fmt.Sprintf("%v %v", r.TypeName(), r.ID)
+}
+
+// parseFailedRule decodes a Failed Rule ID IE. It returns nil if failedRuleID is nil or malformed.
+func parseFailedRule(failedRuleID *ieLib.IE) *FailedRule {
+	if failedRuleID == nil {
+		return nil
+	}
+
+	typ, err := failedRuleID.RuleIDType()
+	if err != nil {
+		return nil
+	}
+
+	id, err := failedRuleID.FailedRuleID()
+	if err != nil {
+		return nil
+	}
+
+	return &FailedRule{Type: typ, ID: id}
+}
+
+// CauseDescription returns the description of the Cause IE value cause.
+func CauseDescription(cause uint8) string {
+	if description, ok := causeDescriptions[cause]; ok {
+		return description
+	}
+
+	return fmt.Sprintf("Unknown cause %v", cause)
+}
+
+// RejectionCause returns the value of the Cause IE the peer rejected the request with, if err was returned
+// because of that. It returns false otherwise, e.g. if err is a timeout.
+func RejectionCause(err error) (uint8, bool) {
+	var simErr *pfcpSimError
+
+	if errors.As(err, &simErr) && simErr.cause != 0 {
+		return simErr.cause, true
+	}
+
+	return 0, false
+}
+
+// RejectedRule returns the rule reported by the Failed Rule ID IE of the response rejecting the request,
+// if err was returned because of that.
+func RejectedRule(err error) (FailedRule, bool) {
+	var simErr *pfcpSimError
+
+	if errors.As(err, &simErr) && simErr.failedRule != nil {
+		return *simErr.failedRule, true
+	}
+
+	return FailedRule{}, false
+}
+
+// OffendingIE returns the type of the IE reported by the Offending IE IE of the response rejecting the request,
+// if err was returned because of that.
+func OffendingIE(err error) (uint16, bool) {
+	var simErr *pfcpSimError
+
+	if errors.As(err, &simErr) && simErr.offendingIE != 0 {
+		return simErr.offendingIE, true
+	}
+
+	return 0, false
+}
+
+// checkCause returns nil if cause, the Cause IE of a response, accepts the request. If the peer rejected the request,
+// the returned error carries the cause value, which can be retrieved with RejectionCause, and the Failed Rule ID
+// and Offending IE IEs of the response, if not nil, which can be retrieved with RejectedRule and OffendingIE.
+func checkCause(cause *ieLib.IE, failedRuleID *ieLib.IE, offendingIE *ieLib.IE) error {
+	if cause == nil {
+		return NewInvalidCauseError()
+	}
+
+	value, err := cause.Cause()
+	if err != nil {
+		return NewInvalidCauseError(err)
+	}
+
+	if value != ieLib.CauseRequestAccepted {
+		rejectedErr := NewRejectedRequestError(value)
+		rejectedErr.failedRule = parseFailedRule(failedRuleID)
+
+		if offendingIE != nil {
+			rejectedErr.offendingIE, _ = offendingIE.OffendingIE()
+		}
+
+		return rejectedErr
+	}
+
+	return nil
+}
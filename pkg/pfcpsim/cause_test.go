@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+func TestCauseDescription(t *testing.T) {
+	tests := []struct {
+		cause    uint8
+		expected string
+	}{
+		{cause: ieLib.CauseRequestAccepted, expected: "Request accepted (success)"},
+		{cause: ieLib.CauseSessionContextNotFound, expected: "Session context not found"},
+		{cause: ieLib.CauseRuleCreationModificationFailure, expected: "Rule creation/modification Failure"},
+		{cause: ieLib.CauseNoResourcesAvailable, expected: "No resources available"},
+		{cause: 200, expected: "Unknown cause 200"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, CauseDescription(tt.cause))
+	}
+}
+
+func TestRejectionCause(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+
+	sess, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		msgType uint8
+		cause   uint8
+		handler func(req message.Message, cause *ieLib.IE) message.Message
+		call    func() error
+	}{
+		{
+			name:    "establishment",
+			msgType: message.MsgTypeSessionEstablishmentRequest,
+			cause:   ieLib.CauseRuleCreationModificationFailure,
+			handler: func(req message.Message, cause *ieLib.IE) message.Message {
+				return message.NewSessionEstablishmentResponse(0, 0, 0, req.Sequence(), 0, upf.NodeID(), cause)
+			},
+			call: func() error {
+				_, err := client.EstablishSession(nil, nil, nil)
+				return err
+			},
+		},
+		{
+			name:    "modification",
+			msgType: message.MsgTypeSessionModificationRequest,
+			cause:   ieLib.CauseMandatoryIEIncorrect,
+			handler: func(req message.Message, cause *ieLib.IE) message.Message {
+				return message.NewSessionModificationResponse(0, 0, 0, req.Sequence(), 0, cause)
+			},
+			call: func() error {
+				return client.ModifySession(sess, nil, nil, nil)
+			},
+		},
+		{
+			name:    "deletion",
+			msgType: message.MsgTypeSessionDeletionRequest,
+			cause:   ieLib.CauseSessionContextNotFound,
+			handler: func(req message.Message, cause *ieLib.IE) message.Message {
+				return message.NewSessionDeletionResponse(0, 0, 0, req.Sequence(), 0, cause)
+			},
+			call: func() error {
+				return client.DeleteSession(sess)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, causeIE := tt.handler, ieLib.NewCause(tt.cause)
+
+			upf.HandleFunc(tt.msgType, func(req message.Message) message.Message {
+				return handler(req, causeIE)
+			})
+
+			err := tt.call()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), CauseDescription(tt.cause))
+
+			cause, ok := RejectionCause(err)
+			require.True(t, ok)
+			require.Equal(t, tt.cause, cause)
+		})
+	}
+
+	t.Run("not a rejection", func(t *testing.T) {
+		_, ok := RejectionCause(NewTimeoutExpiredError())
+		require.False(t, ok)
+
+		_, ok = RejectionCause(net.ErrClosed)
+		require.False(t, ok)
+	})
+}
+
+func TestFailedRules(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+
+	upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+		return message.NewSessionEstablishmentResponse(0, 0, 0, req.Sequence(), 0,
+			upf.NodeID(),
+			ieLib.NewCause(ieLib.CauseRuleCreationModificationFailure),
+			ieLib.NewFailedRuleID(ieLib.RuleIDTypeFAR, 3),
+			ieLib.NewOffendingIE(ieLib.CreateFAR),
+		)
+	})
+
+	_, err := client.EstablishSession(nil, nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed rule: FAR 3")
+
+	rule, ok := RejectedRule(err)
+	require.True(t, ok)
+	require.Equal(t, FailedRule{Type: ieLib.RuleIDTypeFAR, ID: 3}, rule)
+
+	offendingIE, ok := OffendingIE(err)
+	require.True(t, ok)
+	require.Equal(t, ieLib.CreateFAR, offendingIE)
+
+	t.Run("accepted", func(t *testing.T) {
+		upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+			return message.NewSessionEstablishmentResponse(0, 0, 0, req.Sequence(), 0,
+				upf.NodeID(),
+				ieLib.NewCause(ieLib.CauseRequestAccepted),
+				ieLib.NewFSEID(1, net.IPv4(127, 0, 0, 1), nil),
+				ieLib.NewFailedRuleID(ieLib.RuleIDTypeQER, 5),
+			)
+		})
+		upf.HandleFunc(message.MsgTypeSessionModificationRequest, func(req message.Message) message.Message {
+			return message.NewSessionModificationResponse(0, 0, 0, req.Sequence(), 0,
+				ieLib.NewCause(ieLib.CauseRequestAccepted),
+				ieLib.NewFailedRuleID(ieLib.RuleIDTypePDR, 2),
+			)
+		})
+
+		sess, err := client.EstablishSession(nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, []FailedRule{{Type: ieLib.RuleIDTypeQER, ID: 5}}, sess.FailedRules())
+
+		require.NoError(t, client.ModifySession(sess, nil, nil, nil))
+		require.Equal(t, []FailedRule{
+			{Type: ieLib.RuleIDTypeQER, ID: 5},
+			{Type: ieLib.RuleIDTypePDR, ID: 2},
+		}, sess.FailedRules())
+	})
+}
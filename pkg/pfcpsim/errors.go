@@ -4,13 +4,23 @@
 package pfcpsim
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/wmnsk/go-pfcp/message"
 )
 
 type pfcpSimError struct {
 	message string
 	error   []error
+	// cause is the value of the Cause IE the peer rejected the request with, 0 if none
+	cause uint8
+	// failedRule and offendingIE are reported by the peer along with cause, if any
+	failedRule  *FailedRule
+	offendingIE uint16
+	// timeout is true if no response was received within the response timeout
+	timeout bool
 }
 
 func (e *pfcpSimError) unwrap() string {
@@ -25,7 +35,16 @@ func (e *pfcpSimError) unwrap() string {
 }
 
 func (e *pfcpSimError) Error() string {
-	return fmt.Sprintf("Message: %v. %v", e.message, e.unwrap())
+	message := e.message
+	if e.failedRule != nil {
+		message += fmt.Sprintf(", failed rule: %v", e.failedRule)
+	}
+
+	if e.offendingIE != 0 {
+		message += fmt.Sprintf(", offending IE type: %v", e.offendingIE)
+	}
+
+	return fmt.Sprintf("Message: %v. %v", message, e.unwrap())
 }
 
 func NewInvalidCauseError(err ...error) *pfcpSimError {
@@ -35,6 +54,14 @@ func NewInvalidCauseError(err ...error) *pfcpSimError {
 	}
 }
 
+// NewRejectedRequestError returns the error of a request the peer rejected with the given cause.
+func NewRejectedRequestError(cause uint8) *pfcpSimError {
+	return &pfcpSimError{
+		message: fmt.Sprintf("Request rejected with cause %v (%v)", cause, CauseDescription(cause)),
+		cause:   cause,
+	}
+}
+
 func NewNotEnoughSessionsError(err ...error) *pfcpSimError {
 	return &pfcpSimError{
 		message: "Not enough active sessions",
@@ -67,12 +94,42 @@ func NewTimeoutExpiredError(err ...error) *pfcpSimError {
 	return &pfcpSimError{
 		message: "Timeout has expired",
 		error:   err,
+		timeout: true,
 	}
 }
 
+// isTimeoutExpired returns true if err was returned because the peer did not answer within the response timeout.
+func isTimeoutExpired(err error) bool {
+	var simErr *pfcpSimError
+
+	return errors.As(err, &simErr) && simErr.timeout
+}
+
 func NewInvalidResponseError(err ...error) *pfcpSimError {
 	return &pfcpSimError{
 		message: "Invalid response received",
 		error:   err,
 	}
 }
+
+// NewUnexpectedResponseError returns the error of a response whose type doesn't match the request.
+func NewUnexpectedResponseError(resp message.Message) *pfcpSimError {
+	return &pfcpSimError{
+		message: fmt.Sprintf("Invalid response received: unexpected response type %v", resp.MessageTypeName()),
+	}
+}
+
+// NewUnsupportedVersionError returns the error of a response received with a PFCP version other than PFCPVersion.
+func NewUnsupportedVersionError(version uint8) *pfcpSimError {
+	return &pfcpSimError{
+		message: fmt.Sprintf("Unsupported PFCP version %v in response, expected %v", version, PFCPVersion),
+	}
+}
+
+// NewSEIDInUseError returns the error of a session that can't be established with seid as local SEID,
+// because it is used by another session or invalid.
+func NewSEIDInUseError(seid uint64) *pfcpSimError {
+	return &pfcpSimError{
+		message: fmt.Sprintf("Local SEID %v is not available", seid),
+	}
+}
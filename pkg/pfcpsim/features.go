@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// upFunctionFeatures maps the names of the UP function features, as in 3GPP TS 29.244, to their decoders.
+var upFunctionFeatures = []struct {
+	name string
+	has  func(*ieLib.IE) bool
+}{
+	{"BUCP", (*ieLib.IE).HasBUCP},
+	{"DDND", (*ieLib.IE).HasDDND},
+	{"DLBD", (*ieLib.IE).HasDLBD},
+	{"TRST", (*ieLib.IE).HasTRST},
+	{"FTUP", (*ieLib.IE).HasFTUP},
+	{"PFDM", (*ieLib.IE).HasPFDM},
+	{"HEEU", (*ieLib.IE).HasHEEU},
+	{"TREU", (*ieLib.IE).HasTREU},
+	{"EMPU", (*ieLib.IE).HasEMPU},
+	{"PDIU", (*ieLib.IE).HasPDIU},
+	{"UDBC", (*ieLib.IE).HasUDBC},
+	{"QUOAC", (*ieLib.IE).HasQUOAC},
+	{"TRACE", (*ieLib.IE).HasTRACE},
+	{"FRRT", (*ieLib.IE).HasFRRT},
+	{"PFDE", (*ieLib.IE).HasPFDE},
+	{"EPFAR", (*ieLib.IE).HasEPFAR},
+	{"DPDRA", (*ieLib.IE).HasDPDRA},
+	{"ADPDP", (*ieLib.IE).HasADPDP},
+	{"UEIP", (*ieLib.IE).HasUEIP},
+	{"SSET", (*ieLib.IE).HasSSET},
+	{"MNOP", (*ieLib.IE).HasMNOP},
+	{"MTE", (*ieLib.IE).HasMTE},
+	{"BUNDL", (*ieLib.IE).HasBUNDL},
+	{"GCOM", (*ieLib.IE).HasGCOM},
+	{"MPAS", (*ieLib.IE).HasMPAS},
+	{"RTTL", (*ieLib.IE).HasRTTL},
+	{"VTIME", (*ieLib.IE).HasVTIME},
+}
+
+// UPFunctionFeatureNames returns the names of the UP function features set in features,
+// the payload of an UP Function Features IE.
+func UPFunctionFeatureNames(features []byte) []string {
+	featuresIE := ieLib.New(ieLib.UPFunctionFeatures, features)
+
+	var names []string
+
+	for _, feature := range upFunctionFeatures {
+		if feature.has(featuresIE) {
+			names = append(names, feature.name)
+		}
+	}
+
+	return names
+}
+
+// SetCPFunctionFeatures sets the CP function features advertised in the next Association Setup Requests.
+// The CP Function Features IE is omitted if features is 0.
+func (c *PFCPClient) SetCPFunctionFeatures(features uint8) {
+	c.featuresLock.Lock()
+	defer c.featuresLock.Unlock()
+
+	c.cpFunctionFeatures = features
+}
+
+// CPFunctionFeatures returns the CP function features advertised by the client.
+func (c *PFCPClient) CPFunctionFeatures() uint8 {
+	c.featuresLock.Lock()
+	defer c.featuresLock.Unlock()
+
+	return c.cpFunctionFeatures
+}
+
+// PeerUPFunctionFeatures returns the UP function features advertised by the peer during the last association setup.
+// It returns nil if the peer did not advertise any.
+func (c *PFCPClient) PeerUPFunctionFeatures() []byte {
+	c.featuresLock.Lock()
+	defer c.featuresLock.Unlock()
+
+	if c.peerUPFunctionFeatures == nil {
+		return nil
+	}
+
+	return append([]byte(nil), c.peerUPFunctionFeatures...)
+}
+
+// updatePeerUPFunctionFeatures stores the UP function features received from the peer.
+// They are forgotten if featuresIE is nil or invalid.
+func (c *PFCPClient) updatePeerUPFunctionFeatures(featuresIE *ieLib.IE) {
+	var features []byte
+
+	if featuresIE != nil {
+		if payload, err := featuresIE.UPFunctionFeatures(); err == nil {
+			features = append([]byte(nil), payload...)
+		}
+	}
+
+	c.featuresLock.Lock()
+	defer c.featuresLock.Unlock()
+
+	c.peerUPFunctionFeatures = features
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// GTPUPort is the UDP port GTP-U peers listen on.
+	GTPUPort = 2152
+
+	gtpuEchoRequest  = 1
+	gtpuEchoResponse = 2
+)
+
+// gtpuSequenceNumber is the sequence number of the last GTP-U Echo Request sent.
+var gtpuSequenceNumber uint32
+
+// GTPUEcho sends a GTP-U Echo Request from localAddr to remoteAddr and waits for the Echo Response until ctx is done.
+// remoteAddr is in the host or host:port form: GTPUPort is used if no port is specified. If localAddr is empty,
+// the source address is chosen by the OS. Returns the round-trip time, or NewTimeoutExpiredError if no response
+// is received in time.
+func GTPUEcho(ctx context.Context, localAddr string, remoteAddr string) (time.Duration, error) {
+	if _, _, err := net.SplitHostPort(remoteAddr); err != nil {
+		remoteAddr = net.JoinHostPort(strings.Trim(remoteAddr, "[]"), strconv.Itoa(GTPUPort))
+	}
+
+	raddr, err := net.ResolveUDPAddr("udp", remoteAddr)
+	if err != nil {
+		return 0, err
+	}
+
+	laddr := &net.UDPAddr{}
+
+	if localAddr != "" {
+		laddr.IP = net.ParseIP(localAddr)
+		if laddr.IP == nil {
+			return 0, NewInvalidFormatError(fmt.Sprintf("local address %v", localAddr))
+		}
+	}
+
+	conn, err := net.ListenUDP("udp", laddr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	// unblock the read below once ctx is done
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	seq := uint16(atomic.AddUint32(&gtpuSequenceNumber, 1))
+
+	req, err := newGTPUEchoRequest(seq)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+
+	if _, err := conn.WriteToUDP(req, raddr); err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 1500)
+
+	for {
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, NewTimeoutExpiredError(ctx.Err())
+			}
+
+			return 0, err
+		}
+
+		// ignore anything but the response to our request
+		if isGTPUEchoResponse(buf[:n], seq) {
+			return time.Since(start), nil
+		}
+	}
+}
+
+func newGTPUEchoRequest(seq uint16) ([]byte, error) {
+	buf := gopacket.NewSerializeBuffer()
+
+	err := (&layers.GTPv1U{
+		Version:            1,
+		SequenceNumberFlag: true,
+		MessageType:        gtpuEchoRequest,
+		// the optional header fields carrying the sequence number
+		MessageLength:  4,
+		SequenceNumber: seq,
+	}).SerializeTo(buf, gopacket.SerializeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func isGTPUEchoResponse(data []byte, seq uint16) bool {
+	var gtpu layers.GTPv1U
+
+	if err := gtpu.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		return false
+	}
+
+	return gtpu.MessageType == gtpuEchoResponse && gtpu.SequenceNumberFlag && gtpu.SequenceNumber == seq
+}
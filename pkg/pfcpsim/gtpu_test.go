@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ardzoht/pfcpsim/internal/fakeupf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGTPUEcho(t *testing.T) {
+	responder, err := fakeupf.NewGTPUEchoResponder()
+	require.NoError(t, err)
+	t.Cleanup(responder.Close)
+
+	rtt, err := GTPUEcho(context.Background(), "127.0.0.1", responder.Addr())
+	require.NoError(t, err)
+	require.Positive(t, rtt)
+	require.Equal(t, 1, responder.Received())
+
+	t.Run("no response", func(t *testing.T) {
+		responder.SetSilent(true)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := GTPUEcho(ctx, "", responder.Addr())
+		require.Error(t, err)
+		require.Equal(t, 2, responder.Received())
+	})
+
+	t.Run("invalid local address", func(t *testing.T) {
+		_, err := GTPUEcho(context.Background(), "invalid", responder.Addr())
+		require.Error(t, err)
+	})
+}
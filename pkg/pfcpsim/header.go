@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// PFCPVersion is the version of the protocol set in the header of the messages sent by the client.
+// Responses carrying another version are rejected.
+const PFCPVersion = 1
+
+// MaxMessagePriority is the lowest priority the session related requests can be sent with, 0 being the highest.
+const MaxMessagePriority = 15
+
+const (
+	// headerVersionShift is the position of the version in the first octet of the header
+	headerVersionShift = 5
+	// headerFlagS is set in the first octet of the header if it carries a SEID, i.e. for session related messages
+	headerFlagS = 0x01
+	// headerFlagMP is set in the first octet of the header if it carries a Message Priority
+	headerFlagMP = 0x02
+	// messagePriorityOctet is the octet of the header of session related messages holding the Message Priority
+	messagePriorityOctet = 15
+)
+
+// SetMessagePriority makes the client send the Session Establishment, Modification and Deletion Requests
+// with the MP flag and priority set in the header. Node related messages and responses carry no priority.
+// Returns error if priority is greater than MaxMessagePriority.
+func (c *PFCPClient) SetMessagePriority(priority uint8) error {
+	if priority > MaxMessagePriority {
+		return NewInvalidFormatError(fmt.Sprintf("message priority %v. Please make sure it is a number between 0 and %v",
+			priority, MaxMessagePriority))
+	}
+
+	atomic.StoreInt32(&c.messagePriority, int32(priority))
+
+	return nil
+}
+
+// DisableMessagePriority makes the client send all the messages without Message Priority, which is the default.
+func (c *PFCPClient) DisableMessagePriority() {
+	atomic.StoreInt32(&c.messagePriority, -1)
+}
+
+// MessagePriority returns the priority the session related requests are sent with, and false if disabled.
+func (c *PFCPClient) MessagePriority() (uint8, bool) {
+	priority := atomic.LoadInt32(&c.messagePriority)
+
+	return uint8(priority), priority >= 0
+}
+
+// setHeader sets PFCPVersion and, for session related requests, the Message Priority in the header of b,
+// the marshaled msg.
+func (c *PFCPClient) setHeader(b []byte, msg message.Message) {
+	b[0] = b[0]&^(0x07<<headerVersionShift) | PFCPVersion<<headerVersionShift
+
+	priority, ok := c.MessagePriority()
+	if !ok || !isSessionRequest(msg) || b[0]&headerFlagS == 0 || len(b) <= messagePriorityOctet {
+		return
+	}
+
+	b[0] |= headerFlagMP
+	b[messagePriorityOctet] = priority << 4
+}
+
+// isSessionRequest reports whether msg is a request the Message Priority applies to.
+func isSessionRequest(msg message.Message) bool {
+	switch msg.(type) {
+	case *message.SessionEstablishmentRequest, *message.SessionModificationRequest, *message.SessionDeletionRequest:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseHeader returns the version and the sequence number in the header of the received message b.
+// Returns false if b is too short to hold a header.
+func parseHeader(b []byte) (version uint8, seq uint32, ok bool) {
+	if len(b) == 0 {
+		return 0, 0, false
+	}
+
+	offset := 4
+	if b[0]&headerFlagS != 0 {
+		// the SEID comes first
+		offset += 8
+	}
+
+	if len(b) < offset+4 {
+		return 0, 0, false
+	}
+
+	seq = uint32(b[offset])<<16 | uint32(b[offset+1])<<8 | uint32(b[offset+2])
+
+	return b[0] >> headerVersionShift, seq, true
+}
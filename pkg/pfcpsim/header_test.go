@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// newRawPeer returns a socket acting as the peer of a new client connected to it, to exchange raw datagrams.
+func newRawPeer(t *testing.T) (*PFCPClient, *net.UDPConn) {
+	peer, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+
+	client := NewPFCPClient("127.0.0.1")
+	require.NoError(t, client.ConnectN4(peer.LocalAddr().String()))
+
+	t.Cleanup(func() {
+		client.DisconnectN4()
+		peer.Close()
+	})
+
+	return client, peer
+}
+
+func TestPFCPVersion(t *testing.T) {
+	client, peer := newRawPeer(t)
+	client.SetPFCPResponseTimeout(time.Second)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- client.SetupAssociation()
+	}()
+
+	buf := make([]byte, 1500)
+
+	require.NoError(t, peer.SetReadDeadline(time.Now().Add(time.Second)))
+	n, addr, err := peer.ReadFromUDP(buf)
+	require.NoError(t, err)
+
+	version, seq, ok := parseHeader(buf[:n])
+	require.True(t, ok)
+	require.Equal(t, uint8(PFCPVersion), version)
+
+	// the response is rejected if it carries another version
+	resp := message.NewAssociationSetupResponse(seq,
+		ieLib.NewNodeID("127.0.0.1", "", ""),
+		ieLib.NewCause(ieLib.CauseRequestAccepted),
+		ieLib.NewRecoveryTimeStamp(time.Now()),
+	)
+
+	b, err := resp.Marshal()
+	require.NoError(t, err)
+
+	b[0] = b[0]&0x1f | 2<<headerVersionShift
+
+	_, err = peer.WriteToUDP(b, addr)
+	require.NoError(t, err)
+
+	err = <-done
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Unsupported PFCP version 2")
+	require.False(t, client.IsAssociationAlive())
+}
+
+func TestMessagePriority(t *testing.T) {
+	client := NewPFCPClient("127.0.0.1")
+
+	marshal := func(msg message.Message) []byte {
+		b := make([]byte, msg.MarshalLen())
+		require.NoError(t, msg.MarshalTo(b))
+
+		client.setHeader(b, msg)
+
+		return b
+	}
+
+	deletion := func() message.Message {
+		return message.NewSessionDeletionRequest(0, 0, 10, 1, 0)
+	}
+
+	// disabled by default
+	_, ok := client.MessagePriority()
+	require.False(t, ok)
+
+	b := marshal(deletion())
+	require.Zero(t, b[0]&headerFlagMP)
+
+	require.Error(t, client.SetMessagePriority(MaxMessagePriority+1))
+	require.NoError(t, client.SetMessagePriority(5))
+
+	priority, ok := client.MessagePriority()
+	require.True(t, ok)
+	require.Equal(t, uint8(5), priority)
+
+	b = marshal(deletion())
+	require.Equal(t, uint8(headerFlagMP), b[0]&headerFlagMP)
+	require.Equal(t, uint8(5), b[messagePriorityOctet]>>4)
+
+	version, seq, ok := parseHeader(b)
+	require.True(t, ok)
+	require.Equal(t, uint8(PFCPVersion), version)
+	require.Equal(t, uint32(1), seq)
+
+	// node related messages carry no priority
+	b = marshal(message.NewHeartbeatRequest(2, ieLib.NewRecoveryTimeStamp(time.Now()), nil))
+	require.Zero(t, b[0]&headerFlagMP)
+
+	client.DisableMessagePriority()
+
+	b = marshal(deletion())
+	require.Zero(t, b[0]&headerFlagMP)
+}
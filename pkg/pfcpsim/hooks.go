@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// MessageHook is invoked with a PFCP message sent or received by the client.
+type MessageHook func(msg message.Message)
+
+// RegisterSendHook registers hook to be invoked with every PFCP message, right before it is sent.
+// Hooks are invoked synchronously by the sending goroutine, hence they must be quick and must not block.
+func (c *PFCPClient) RegisterSendHook(hook MessageHook) {
+	c.hooksLock.Lock()
+	defer c.hooksLock.Unlock()
+
+	c.sendHooks = append(c.sendHooks, hook)
+}
+
+// RegisterRecvHook registers hook to be invoked with every PFCP message received, right after it is parsed.
+// Hooks are invoked synchronously by the goroutine reading from N4, hence they must be quick and
+// must not block: no other message is received until they return.
+func (c *PFCPClient) RegisterRecvHook(hook MessageHook) {
+	c.hooksLock.Lock()
+	defer c.hooksLock.Unlock()
+
+	c.recvHooks = append(c.recvHooks, hook)
+}
+
+func (c *PFCPClient) runSendHooks(msg message.Message) {
+	c.hooksLock.RLock()
+	hooks := c.sendHooks
+	c.hooksLock.RUnlock()
+
+	for _, hook := range hooks {
+		hook(msg)
+	}
+}
+
+func (c *PFCPClient) runRecvHooks(msg message.Message) {
+	c.hooksLock.RLock()
+	hooks := c.recvHooks
+	c.hooksLock.RUnlock()
+
+	for _, hook := range hooks {
+		hook(msg)
+	}
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "pfcpsim"
+
+	// PFCP exchanges, used as operation label
+	opAssociationSetup     = "association_setup"
+	opAssociationRelease   = "association_release"
+	opAssociationUpdate    = "association_update"
+	opSessionEstablishment = "session_establishment"
+	opSessionModification  = "session_modification"
+	opSessionDeletion      = "session_deletion"
+	opSessionSetDeletion   = "session_set_deletion"
+)
+
+var (
+	associationsSetup = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "associations_setup_total",
+		Help:      "Number of PFCP associations successfully set up",
+	})
+
+	sessionsEstablished = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "sessions_established_total",
+		Help:      "Number of PFCP sessions successfully established",
+	})
+
+	sessionsModified = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "sessions_modified_total",
+		Help:      "Number of PFCP sessions successfully modified",
+	})
+
+	sessionsDeleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "sessions_deleted_total",
+		Help:      "Number of PFCP sessions successfully deleted",
+	})
+
+	exchangeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "pfcp_exchange_duration_seconds",
+		Help:      "Time elapsed between sending a PFCP request and processing its response",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "result"})
+)
+
+// RegisterMetrics registers the metrics of the PFCP exchanges performed by all the clients with registerer,
+// e.g. prometheus.DefaultRegisterer. Returns error if any of them can't be registered.
+func RegisterMetrics(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{
+		associationsSetup,
+		sessionsEstablished,
+		sessionsModified,
+		sessionsDeleted,
+		exchangeDuration,
+	} {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// observeExchange records the duration of a PFCP exchange started at start, labelled by its outcome.
+func observeExchange(operation string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+
+	exchangeDuration.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// exchangeSampleCount scrapes gatherer and returns the number of observations of the
+// PFCP exchange duration histogram for the given operation and result.
+func exchangeSampleCount(t *testing.T, gatherer prometheus.Gatherer, operation, result string) uint64 {
+	families, err := gatherer.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "pfcpsim_pfcp_exchange_duration_seconds" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if labels["operation"] == operation && labels["result"] == result {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestSessionMetrics(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, RegisterMetrics(registry))
+
+	established := testutil.ToFloat64(sessionsEstablished)
+	deleted := testutil.ToFloat64(sessionsDeleted)
+	exchanges := exchangeSampleCount(t, registry, opSessionEstablishment, "success")
+
+	sess, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, established+1, testutil.ToFloat64(sessionsEstablished))
+	require.Equal(t, exchanges+1, exchangeSampleCount(t, registry, opSessionEstablishment, "success"))
+
+	require.NoError(t, client.DeleteSession(sess))
+	require.Equal(t, deleted+1, testutil.ToFloat64(sessionsDeleted))
+
+	// A rejected deletion is observed as failure, but it's not counted as deleted session
+	upf.HandleFunc(message.MsgTypeSessionDeletionRequest, func(req message.Message) message.Message {
+		return message.NewSessionDeletionResponse(0, 0, 0, req.Sequence(), 0,
+			ieLib.NewCause(ieLib.CauseSessionContextNotFound),
+		)
+	})
+
+	failures := exchangeSampleCount(t, registry, opSessionDeletion, "failure")
+
+	require.Error(t, client.DeleteSession(sess))
+	require.Equal(t, failures+1, exchangeSampleCount(t, registry, opSessionDeletion, "failure"))
+	require.Equal(t, deleted+1, testutil.ToFloat64(sessionsDeleted))
+}
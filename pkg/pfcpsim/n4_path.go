@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import "time"
+
+// N4PathEvent notifies a change of the status of the N4 path towards the peer, detected through heartbeats.
+type N4PathEvent struct {
+	// Up is false if the path failed, true if it recovered.
+	Up bool
+	// MissedHeartbeats is the number of consecutive Heartbeat Requests the peer did not answer.
+	MissedHeartbeats int
+	// Sessions are the sessions established with the peer, affected by the change.
+	Sessions []*PFCPSession
+	// Err is the error of the N4 socket the failure was detected through, nil if detected through heartbeats.
+	Err error
+}
+
+// SetHeartbeatPeriod sets the interval between two Heartbeat Requests. It must be invoked before the association setup.
+func (c *PFCPClient) SetHeartbeatPeriod(period time.Duration) {
+	c.heartbeatPeriod = period
+}
+
+// SetMaxMissedHeartbeats sets the number of consecutive Heartbeat Requests left unanswered
+// after which the N4 path is considered failed. Values lower than 1 are ignored.
+func (c *PFCPClient) SetMaxMissedHeartbeats(n int) {
+	if n < 1 {
+		return
+	}
+
+	c.n4PathLock.Lock()
+	defer c.n4PathLock.Unlock()
+
+	c.maxMissedHeartbeats = n
+}
+
+// IsN4PathUp returns false if the N4 path failed, i.e. the peer did not answer the last
+// heartbeats, and did not recover yet.
+func (c *PFCPClient) IsN4PathUp() bool {
+	c.n4PathLock.Lock()
+	defer c.n4PathLock.Unlock()
+
+	return !c.n4PathDown
+}
+
+// N4PathEvents returns a channel notifying the failures and the recoveries of the N4 path.
+// Notifications are dropped if the channel buffer is full.
+func (c *PFCPClient) N4PathEvents() <-chan N4PathEvent {
+	return c.n4PathEventsChan
+}
+
+// resetN4Path clears the N4 path state, e.g. when a new association is set up.
+func (c *PFCPClient) resetN4Path() {
+	c.n4PathLock.Lock()
+	defer c.n4PathLock.Unlock()
+
+	c.missedHeartbeats = 0
+	c.n4PathDown = false
+}
+
+// heartbeatMissed counts a Heartbeat Request left unanswered. Once maxMissedHeartbeats are missed
+// in a row, the association is marked inactive and the failure of the path is notified.
+func (c *PFCPClient) heartbeatMissed() {
+	c.n4PathLock.Lock()
+	defer c.n4PathLock.Unlock()
+
+	c.missedHeartbeats++
+
+	if c.n4PathDown || c.missedHeartbeats < c.maxMissedHeartbeats {
+		return
+	}
+
+	c.n4PathDown = true
+	c.setAssociationStatus(false)
+	c.notifyN4PathEvent(false, nil)
+	c.startReassociation()
+}
+
+// socketFailed marks the association inactive and notifies the failure of the N4 path because of err, an error
+// of the N4 socket received while the association is active, e.g. once the peer is not listening anymore.
+func (c *PFCPClient) socketFailed(err error) {
+	c.n4PathLock.Lock()
+	defer c.n4PathLock.Unlock()
+
+	if c.n4PathDown || !c.IsAssociationAlive() {
+		return
+	}
+
+	c.n4PathDown = true
+	c.setAssociationStatus(false)
+	c.notifyN4PathEvent(false, err)
+	c.startReassociation()
+}
+
+// heartbeatAnswered resets the missed heartbeats and, if the path had failed, notifies its recovery.
+func (c *PFCPClient) heartbeatAnswered() {
+	c.n4PathLock.Lock()
+	defer c.n4PathLock.Unlock()
+
+	if c.n4PathDown {
+		c.n4PathDown = false
+		c.notifyN4PathEvent(true, nil)
+	}
+
+	c.missedHeartbeats = 0
+}
+
+// notifyN4PathEvent must be invoked with n4PathLock held.
+func (c *PFCPClient) notifyN4PathEvent(up bool, err error) {
+	event := N4PathEvent{
+		Up:               up,
+		MissedHeartbeats: c.missedHeartbeats,
+		Sessions:         c.getSessions(),
+		Err:              err,
+	}
+
+	select {
+	case c.n4PathEventsChan <- event:
+	default:
+		// Nobody is consuming path events. Drop it rather than blocking the heartbeats.
+	}
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// IsValidHostname returns true if name is a syntactically valid hostname, as per RFC 1123.
+func IsValidHostname(name string) bool {
+	if name == "" || len(name) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// ValidateNodeID returns error if value is not a valid Node ID of type nodeIDType,
+// one of ieLib.NodeIDIPv4Address, ieLib.NodeIDIPv6Address or ieLib.NodeIDFQDN.
+func ValidateNodeID(nodeIDType uint8, value string) error {
+	ip := net.ParseIP(value)
+
+	switch nodeIDType {
+	case ieLib.NodeIDIPv4Address:
+		if ip == nil || ip.To4() == nil {
+			return NewInvalidFormatError(fmt.Sprintf("Node ID %v. Please make sure it is an IPv4 address", value))
+		}
+	case ieLib.NodeIDIPv6Address:
+		if ip == nil || ip.To4() != nil {
+			return NewInvalidFormatError(fmt.Sprintf("Node ID %v. Please make sure it is an IPv6 address", value))
+		}
+	case ieLib.NodeIDFQDN:
+		if ip != nil || !IsValidHostname(value) {
+			return NewInvalidFormatError(fmt.Sprintf("Node ID %v. Please make sure it is a FQDN", value))
+		}
+	default:
+		return NewInvalidFormatError(fmt.Sprintf("Node ID type %v", nodeIDType))
+	}
+
+	return nil
+}
+
+// SetNodeID sets the Node ID advertised to the peer, e.g. in Association Setup Requests, instead of the one derived
+// from the local address. nodeIDType is one of ieLib.NodeIDIPv4Address, ieLib.NodeIDIPv6Address or ieLib.NodeIDFQDN.
+// F-SEIDs keep carrying the local address, as they can't carry a FQDN. Returns error if value does not match nodeIDType.
+func (c *PFCPClient) SetNodeID(nodeIDType uint8, value string) error {
+	if err := ValidateNodeID(nodeIDType, value); err != nil {
+		return err
+	}
+
+	c.nodeIDLock.Lock()
+	defer c.nodeIDLock.Unlock()
+
+	c.nodeID = &nodeID{nodeIDType: nodeIDType, value: value}
+
+	return nil
+}
+
+// ResetNodeID makes the client advertise again the Node ID derived from the local address.
+func (c *PFCPClient) ResetNodeID() {
+	c.nodeIDLock.Lock()
+	defer c.nodeIDLock.Unlock()
+
+	c.nodeID = nil
+}
+
+// localNodeID returns the Node ID IE identifying the client. Unless set with SetNodeID,
+// it is of IPv4 or IPv6 type depending on localAddr.
+func (c *PFCPClient) localNodeID() *ieLib.IE {
+	c.nodeIDLock.Lock()
+	id := c.nodeID
+	c.nodeIDLock.Unlock()
+
+	if id != nil {
+		switch id.nodeIDType {
+		case ieLib.NodeIDIPv4Address:
+			return ieLib.NewNodeID(id.value, "", "")
+		case ieLib.NodeIDIPv6Address:
+			return ieLib.NewNodeID("", id.value, "")
+		default:
+			return ieLib.NewNodeID("", "", id.value)
+		}
+	}
+
+	if c.localIPv6() != nil {
+		return ieLib.NewNodeID("", c.localAddr, "")
+	}
+
+	return ieLib.NewNodeID(c.localAddr, "", "")
+}
+
+// nodeID is a Node ID set with SetNodeID.
+type nodeID struct {
+	nodeIDType uint8
+	value      string
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+func TestSetNodeID(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodeIDType uint8
+		value      string
+		expected   *ieLib.IE
+		wantErr    bool
+	}{
+		{name: "IPv4", nodeIDType: ieLib.NodeIDIPv4Address, value: "10.0.0.1", expected: ieLib.NewNodeID("10.0.0.1", "", "")},
+		{name: "IPv6", nodeIDType: ieLib.NodeIDIPv6Address, value: "2001:db8::1", expected: ieLib.NewNodeID("", "2001:db8::1", "")},
+		{name: "FQDN", nodeIDType: ieLib.NodeIDFQDN, value: "smf.5gc.local", expected: ieLib.NewNodeID("", "", "smf.5gc.local")},
+		{name: "IPv6 as IPv4", nodeIDType: ieLib.NodeIDIPv4Address, value: "2001:db8::1", wantErr: true},
+		{name: "IPv4 as IPv6", nodeIDType: ieLib.NodeIDIPv6Address, value: "10.0.0.1", wantErr: true},
+		{name: "IP as FQDN", nodeIDType: ieLib.NodeIDFQDN, value: "10.0.0.1", wantErr: true},
+		{name: "invalid FQDN", nodeIDType: ieLib.NodeIDFQDN, value: "smf_1.local", wantErr: true},
+		{name: "empty", nodeIDType: ieLib.NodeIDFQDN, value: "", wantErr: true},
+		{name: "unknown type", nodeIDType: 3, value: "10.0.0.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, upf := newConnectedClient(t)
+
+			err := client.SetNodeID(tt.nodeIDType, tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, client.SetupAssociation())
+
+			_, err = client.EstablishSession(nil, nil, nil)
+			require.NoError(t, err)
+
+			assocReq := upf.Received(message.MsgTypeAssociationSetupRequest)[0].(*message.AssociationSetupRequest)
+			require.Equal(t, tt.expected, assocReq.NodeID)
+
+			estReq := upf.Received(message.MsgTypeSessionEstablishmentRequest)[0].(*message.SessionEstablishmentRequest)
+			require.Equal(t, tt.expected, estReq.NodeID)
+
+			// the F-SEID keeps carrying the local address
+			fseid, err := estReq.CPFSEID.FSEID()
+			require.NoError(t, err)
+			require.Equal(t, "127.0.0.1", fseid.IPv4Address.String())
+		})
+	}
+
+	t.Run("reset", func(t *testing.T) {
+		client, upf := newConnectedClient(t)
+
+		require.NoError(t, client.SetNodeID(ieLib.NodeIDFQDN, "smf.5gc.local"))
+		client.ResetNodeID()
+		require.NoError(t, client.SetupAssociation())
+
+		assocReq := upf.Received(message.MsgTypeAssociationSetupRequest)[0].(*message.AssociationSetupRequest)
+		require.Equal(t, ieLib.NewNodeID("127.0.0.1", "", ""), assocReq.NodeID)
+	})
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"time"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// PathFailure describes a user plane path failure notified by a peer through a Node Report Request.
+type PathFailure struct {
+	// NodeID is the Node ID of the peer sending the report
+	NodeID string
+	// RemotePeers are the addresses of the remote GTP-U peers towards which the path failed
+	RemotePeers []string
+	ReportedAt  time.Time
+}
+
+// handleNodeReport records the user plane path failures carried by a Node Report Request
+// and acknowledges it towards the peer.
+func (c *PFCPClient) handleNodeReport(req *message.NodeReportRequest) {
+	if req.NodeID == nil {
+		_ = c.sendMsg(message.NewNodeReportResponse(req.Sequence(),
+			c.localNodeID(),
+			ieLib.NewCause(ieLib.CauseMandatoryIEMissing),
+			ieLib.NewOffendingIE(ieLib.NodeID),
+		))
+
+		return
+	}
+
+	nodeID, err := req.NodeID.NodeID()
+	if err != nil {
+		_ = c.sendMsg(message.NewNodeReportResponse(req.Sequence(),
+			c.localNodeID(),
+			ieLib.NewCause(ieLib.CauseMandatoryIEIncorrect),
+			ieLib.NewOffendingIE(ieLib.NodeID),
+		))
+
+		return
+	}
+
+	if req.UserPlanePathFailureReport != nil {
+		c.recordPathFailure(nodeID, req.UserPlanePathFailureReport)
+	}
+
+	_ = c.sendMsg(message.NewNodeReportResponse(req.Sequence(),
+		c.localNodeID(),
+		ieLib.NewCause(ieLib.CauseRequestAccepted),
+		nil,
+	))
+}
+
+func (c *PFCPClient) recordPathFailure(nodeID string, report *ieLib.IE) {
+	failure := PathFailure{
+		NodeID:     nodeID,
+		ReportedAt: time.Now(),
+	}
+
+	ies, err := report.UserPlanePathFailureReport()
+	if err != nil {
+		return
+	}
+
+	for _, x := range ies {
+		if x.Type != ieLib.RemoteGTPUPeer {
+			continue
+		}
+
+		peer, err := x.RemoteGTPUPeer()
+		if err != nil {
+			continue
+		}
+
+		if peer.IPv4Address != nil {
+			failure.RemotePeers = append(failure.RemotePeers, peer.IPv4Address.String())
+		}
+
+		if peer.IPv6Address != nil {
+			failure.RemotePeers = append(failure.RemotePeers, peer.IPv6Address.String())
+		}
+	}
+
+	c.pathFailuresLock.Lock()
+	defer c.pathFailuresLock.Unlock()
+
+	c.pathFailures = append(c.pathFailures, failure)
+}
+
+// UserPlanePathFailures returns the user plane path failures reported by peers so far.
+func (c *PFCPClient) UserPlanePathFailures() []PathFailure {
+	c.pathFailuresLock.Lock()
+	defer c.pathFailuresLock.Unlock()
+
+	failures := make([]PathFailure, len(c.pathFailures))
+	copy(failures, c.pathFailures)
+
+	return failures
+}
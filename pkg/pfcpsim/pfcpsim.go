@@ -5,11 +5,17 @@ package pfcpsim
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"net"
+	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ardzoht/pfcpsim/pkg/pfcpsim/session"
 	ieLib "github.com/wmnsk/go-pfcp/ie"
 	"github.com/wmnsk/go-pfcp/message"
 )
@@ -18,6 +24,25 @@ const (
 	PFCPStandardPort       = 8805
 	DefaultHeartbeatPeriod = 5
 	DefaultResponseTimeout = 5 * time.Second
+
+	// sessionReportsBufferSize is the number of Session Report Requests kept while no one is consuming them.
+	sessionReportsBufferSize = 128
+	// peerRestartsBufferSize is the number of peer restart notifications kept while no one is consuming them.
+	peerRestartsBufferSize = 8
+	// heartbeatResponsesBufferSize is the number of Heartbeat Responses, not answering a heartbeat sent by
+	// SendAndRecvHeartbeat, kept while no one is consuming them through PeekNextHeartbeatResponse.
+	heartbeatResponsesBufferSize = 8
+	// n4PathEventsBufferSize is the number of N4 path events kept while no one is consuming them.
+	n4PathEventsBufferSize = 8
+	// reassociationsBufferSize is the number of re-association attempts kept while no one is consuming them.
+	reassociationsBufferSize = 8
+	// receivedMessagesBufferSize is the number of messages, not answering an exchange, kept while
+	// no one is consuming them through PeekNextResponse.
+	receivedMessagesBufferSize = 128
+
+	// DefaultMaxMissedHeartbeats is the number of consecutive unanswered Heartbeat Requests
+	// after which the N4 path is considered failed.
+	DefaultMaxMissedHeartbeats = 1
 )
 
 // PFCPClient enables to simulate a client sending PFCP messages towards the UPF.
@@ -29,36 +54,151 @@ type PFCPClient struct {
 	// keeps the current number of active PFCP sessions
 	// it is also used as F-SEID
 	lastFSEID uint64
+	// seidAllocation selects how local SEIDs are allocated. It is accessed atomically.
+	seidAllocation SEIDAllocation
+	// seidRand generates the random SEIDs. It is guarded by sessionsLock
+	seidRand *rand.Rand
 
 	aliveLock           sync.Mutex
 	isAssociationActive bool
+	// associatedAt is the time the last association was set up, zero if none
+	associatedAt time.Time
+	// peerNodeID is the Node ID the peer advertised while setting up the last association
+	peerNodeID string
 
 	ctx              context.Context
 	cancelHeartbeats context.CancelFunc
-
-	heartbeatsChan chan *message.HeartbeatResponse
-	recvChan       chan message.Message
-
-	sequenceNumber uint32
-	seqNumLock     sync.Mutex
+	heartbeatPeriod  time.Duration
+
+	// reassociationBackoff is the initial backoff of the automatic re-association, 0 if disabled.
+	// It is accessed atomically, as well as reassociating, which is 1 while a re-association is in progress.
+	reassociationBackoff int64
+	reassociating        int32
+	// cancelReassociation stops the re-association in progress. It is guarded by aliveLock, as cancelHeartbeats
+	cancelReassociation context.CancelFunc
+
+	// missedHeartbeats is the number of consecutive Heartbeat Requests the peer did not answer.
+	// The N4 path is down once it reaches maxMissedHeartbeats, until a Heartbeat Response is received.
+	missedHeartbeats    int
+	maxMissedHeartbeats int
+	n4PathDown          bool
+	n4PathLock          sync.Mutex
+
+	heartbeatsChan     chan *message.HeartbeatResponse
+	n4PathEventsChan   chan N4PathEvent
+	reassociationsChan chan ReassociationAttempt
+	recvChan           chan message.Message
+	reportsChan        chan *message.SessionReportRequest
+	restartsChan       chan PeerRestart
+
+	sequenceNumbers sequenceNumberAllocator
+
+	// pending keeps the requests waiting for a response, indexed by sequence number
+	pending map[uint32]chan exchangeResult
+	// answered keeps the sequence numbers of the last exchanges completed, to recognize duplicate responses
+	answered    *sequenceNumberSet
+	pendingLock sync.Mutex
+
+	// retransmissions (N1) is the number of times a request is resent if no response is received
+	// within retransmissionTimeout (T1). If 0, requests are sent once and responseTimeout applies.
+	retransmissions       int
+	retransmissionTimeout time.Duration
+
+	// sessions keeps the established sessions indexed by local SEID.
+	// It is used to match incoming Session Report Requests with a session.
+	sessions     map[uint64]*PFCPSession
+	sessionsLock sync.Mutex
+
+	// pathFailures keeps the user plane path failures notified through Node Report Requests
+	pathFailures     []PathFailure
+	pathFailuresLock sync.Mutex
+
+	// recoveryTimeStamp is the time the client started, unless overridden with SetRecoveryTimeStamp.
+	// It is advertised to the peer.
+	recoveryTimeStamp time.Time
+
+	// peerRecoveryTimeStamp is the last Recovery Time Stamp received from the peer.
+	// A change of this value means that the peer restarted.
+	peerRecoveryTimeStamp time.Time
+	recoveryLock          sync.Mutex
+
+	// cpFunctionFeatures are advertised to the peer in Association Setup Requests
+	cpFunctionFeatures uint8
+	// peerUPFunctionFeatures are the UP function features received from the peer during association setup
+	peerUPFunctionFeatures []byte
+	featuresLock           sync.Mutex
+
+	// nodeID, if not nil, is advertised instead of the Node ID derived from localAddr
+	nodeID     *nodeID
+	nodeIDLock sync.Mutex
+
+	// csid identifies the PDN connection set the sessions established by the client belong to, 0 if none.
+	// It is accessed atomically.
+	csid uint32
+
+	// messagePriority is the priority of the session related requests, -1 if disabled. It is accessed atomically.
+	messagePriority int32
 
 	localAddr string
-	conn      *net.UDPConn
+	// bindAddr is the address the N4 socket is bound to. If nil, the source address is chosen by the OS.
+	bindAddr *net.UDPAddr
+	conn     *net.UDPConn
+	// remoteAddr is the address of the peer if conn is not connected to it, i.e. if conn was provided
+	// to ConnectN4WithConn without a remote address. It is nil otherwise.
+	remoteAddr *net.UDPAddr
+
+	// sendHooks and recvHooks are invoked with the PFCP messages sent and received
+	sendHooks []MessageHook
+	recvHooks []MessageHook
+	hooksLock sync.RWMutex
+
+	// capture, if not nil, receives all the PFCP messages sent and received
+	capture     *capture
+	captureLock sync.RWMutex
 
 	// responseTimeout timeout to wait for PFCP response (default: 5 seconds)
 	responseTimeout time.Duration
+	// operationTimeouts override responseTimeout for the operations they are set for
+	operationTimeouts map[Operation]time.Duration
+	timeoutsLock      sync.RWMutex
 }
 
+// Operation identifies a PFCP procedure, to set its response timeout with SetOperationTimeout.
+type Operation int
+
+const (
+	// OperationAssociation covers Association Setup and Release
+	OperationAssociation Operation = iota
+	OperationEstablishment
+	// OperationModification covers Session Modification
+	OperationModification
+	// OperationDeletion covers Session Deletion and Session Set Deletion
+	OperationDeletion
+	OperationHeartbeat
+	OperationPFDManagement
+)
+
 func NewPFCPClient(localAddr string) *PFCPClient {
 	client := &PFCPClient{
-		sequenceNumber:  0,
-		localAddr:       localAddr,
-		responseTimeout: DefaultResponseTimeout,
+		localAddr:           localAddr,
+		responseTimeout:     DefaultResponseTimeout,
+		operationTimeouts:   make(map[Operation]time.Duration),
+		heartbeatPeriod:     DefaultHeartbeatPeriod * time.Second,
+		maxMissedHeartbeats: DefaultMaxMissedHeartbeats,
+		sessions:            make(map[uint64]*PFCPSession),
+		pending:             make(map[uint32]chan exchangeResult),
+		answered:            newSequenceNumberSet(answeredExchangesSize),
+		recoveryTimeStamp:   time.Now(),
+		messagePriority:     -1,
 	}
 
 	client.ctx = context.Background()
-	client.heartbeatsChan = make(chan *message.HeartbeatResponse)
-	client.recvChan = make(chan message.Message)
+	client.heartbeatsChan = make(chan *message.HeartbeatResponse, heartbeatResponsesBufferSize)
+	client.recvChan = make(chan message.Message, receivedMessagesBufferSize)
+	client.reportsChan = make(chan *message.SessionReportRequest, sessionReportsBufferSize)
+	client.restartsChan = make(chan PeerRestart, peerRestartsBufferSize)
+	client.n4PathEventsChan = make(chan N4PathEvent, n4PathEventsBufferSize)
+	client.reassociationsChan = make(chan ReassociationAttempt, reassociationsBufferSize)
 
 	return client
 }
@@ -67,25 +207,83 @@ func (c *PFCPClient) SetPFCPResponseTimeout(timeout time.Duration) {
 	c.responseTimeout = timeout
 }
 
+// SetOperationTimeout sets the time to wait for the response to the requests of op, instead of the
+// response timeout. A timeout of 0 restores the response timeout. If the context of the operation has an
+// earlier deadline, the deadline wins. It has no effect if retransmissions are enabled, see SetRetransmission.
+func (c *PFCPClient) SetOperationTimeout(op Operation, timeout time.Duration) {
+	c.timeoutsLock.Lock()
+	defer c.timeoutsLock.Unlock()
+
+	if timeout == 0 {
+		delete(c.operationTimeouts, op)
+		return
+	}
+
+	c.operationTimeouts[op] = timeout
+}
+
+// OperationTimeout returns the time to wait for the response to the requests of op.
+func (c *PFCPClient) OperationTimeout(op Operation) time.Duration {
+	c.timeoutsLock.RLock()
+	defer c.timeoutsLock.RUnlock()
+
+	if timeout, ok := c.operationTimeouts[op]; ok {
+		return timeout
+	}
+
+	return c.responseTimeout
+}
+
 func (c *PFCPClient) getNextSequenceNumber() uint32 {
-	c.seqNumLock.Lock()
-	defer c.seqNumLock.Unlock()
+	return c.sequenceNumbers.next()
+}
 
-	c.sequenceNumber++
+// SetLocalN4Address makes the client use address both as source of the N4 messages and as local address
+// advertised in Node IDs and F-SEIDs. It must be invoked before ConnectN4.
+// Returns error if address is not a valid IP address.
+func (c *PFCPClient) SetLocalN4Address(address string) error {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return NewInvalidFormatError(fmt.Sprintf("local N4 address %v", address))
+	}
+
+	c.localAddr = address
+	c.bindAddr = &net.UDPAddr{IP: ip}
+
+	return nil
+}
 
-	return c.sequenceNumber
+// LocalAddr returns the local address used by the client, advertised in Node IDs and F-SEIDs.
+func (c *PFCPClient) LocalAddr() string {
+	return c.localAddr
+}
+
+// localIPv4 returns localAddr if it is an IPv4 address, nil otherwise.
+func (c *PFCPClient) localIPv4() net.IP {
+	return net.ParseIP(c.localAddr).To4()
+}
+
+// localIPv6 returns localAddr if it is an IPv6 address, nil otherwise.
+func (c *PFCPClient) localIPv6() net.IP {
+	ip := net.ParseIP(c.localAddr)
+	if ip == nil || ip.To4() != nil {
+		return nil
+	}
+
+	return ip
 }
 
 func (c *PFCPClient) getNextFSEID() uint64 {
-	c.lastFSEID++
-	return c.lastFSEID
+	return atomic.AddUint64(&c.lastFSEID, 1)
 }
 
 func (c *PFCPClient) resetSequenceNumber() {
-	c.seqNumLock.Lock()
-	defer c.seqNumLock.Unlock()
+	c.sequenceNumbers.reset()
 
-	c.sequenceNumber = 0
+	// sequence numbers are going to be reused: responses to them are no longer duplicates
+	c.pendingLock.Lock()
+	c.answered = newSequenceNumberSet(answeredExchangesSize)
+	c.pendingLock.Unlock()
 }
 
 func (c *PFCPClient) setAssociationStatus(status bool) {
@@ -95,16 +293,72 @@ func (c *PFCPClient) setAssociationStatus(status bool) {
 	c.isAssociationActive = status
 }
 
+func (c *PFCPClient) insertSession(sess *PFCPSession) {
+	c.sessionsLock.Lock()
+	defer c.sessionsLock.Unlock()
+
+	c.sessions[sess.localSEID] = sess
+}
+
+func (c *PFCPClient) getSession(localSEID uint64) (*PFCPSession, bool) {
+	c.sessionsLock.Lock()
+	defer c.sessionsLock.Unlock()
+
+	sess, ok := c.sessions[localSEID]
+
+	return sess, ok
+}
+
+// getSessions returns the established sessions, sorted by local SEID.
+func (c *PFCPClient) getSessions() []*PFCPSession {
+	c.sessionsLock.Lock()
+	defer c.sessionsLock.Unlock()
+
+	sessions := make([]*PFCPSession, 0, len(c.sessions))
+	for _, sess := range c.sessions {
+		sessions = append(sessions, sess)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].localSEID < sessions[j].localSEID
+	})
+
+	return sessions
+}
+
+func (c *PFCPClient) removeSession(localSEID uint64) {
+	c.sessionsLock.Lock()
+	defer c.sessionsLock.Unlock()
+
+	delete(c.sessions, localSEID)
+}
+
 func (c *PFCPClient) sendMsg(msg message.Message) error {
+	c.runSendHooks(msg)
+
 	b := make([]byte, msg.MarshalLen())
 	if err := msg.MarshalTo(b); err != nil {
 		return err
 	}
 
+	c.setHeader(b, msg)
+
+	if c.remoteAddr != nil {
+		if _, err := c.conn.WriteToUDP(b, c.remoteAddr); err != nil {
+			return err
+		}
+
+		c.capturePacket(b, c.conn.LocalAddr(), c.remoteAddr)
+
+		return nil
+	}
+
 	if _, err := c.conn.Write(b); err != nil {
 		return err
 	}
 
+	c.capturePacket(b, c.conn.LocalAddr(), c.conn.RemoteAddr())
+
 	return nil
 }
 
@@ -112,59 +366,183 @@ func (c *PFCPClient) receiveFromN4() {
 	buf := make([]byte, 1500)
 
 	for {
-		n, _, err := c.conn.ReadFrom(buf)
+		n, addr, err := c.conn.ReadFrom(buf)
+		if errors.Is(err, net.ErrClosed) {
+			// connection closed by DisconnectN4
+			return
+		}
+
 		if err != nil {
+			// e.g. the peer is not listening anymore
+			c.socketFailed(err)
 			continue
 		}
 
-		msg, err := message.Parse(buf[:n])
+		// a shared socket may receive datagrams from other peers too
+		if c.remoteAddr != nil && addr.String() != c.remoteAddr.String() {
+			continue
+		}
+
+		c.capturePacket(buf[:n], addr, c.conn.LocalAddr())
+
+		if version, seq, ok := parseHeader(buf[:n]); ok && version != PFCPVersion {
+			// the message can't be trusted to have the expected format: fail the exchange waiting for it, if any
+			c.deliverError(seq, NewUnsupportedVersionError(version))
+			continue
+		}
+
+		// Parsed IEs reference the underlying buffer, which is reused for the next read.
+		// Copy it, as messages may be consumed asynchronously (e.g. session reports).
+		msg, err := message.Parse(append([]byte(nil), buf[:n]...))
 		if err != nil {
 			continue
 		}
 
+		c.runRecvHooks(msg)
+
 		switch msg := msg.(type) {
+		case *message.HeartbeatRequest:
+			c.handleHeartbeatRequest(msg)
+
 		case *message.HeartbeatResponse:
-			c.heartbeatsChan <- msg
+			if c.deliverResponse(msg) {
+				continue
+			}
+
+			select {
+			case c.heartbeatsChan <- msg:
+			default:
+				// Nobody is consuming heartbeats sent through SendHeartbeatRequest. Drop it.
+			}
 
 		case *message.SessionReportRequest:
-			// Ignore message
+			c.handleSessionReport(msg)
+
+		case *message.NodeReportRequest:
+			c.handleNodeReport(msg)
 		default:
-			c.recvChan <- msg
+			if c.deliverResponse(msg) {
+				continue
+			}
+
+			select {
+			case c.recvChan <- msg:
+			default:
+				// Nobody is consuming unsolicited messages, e.g. while running high-level operations only.
+				// Drop it rather than blocking the receive loop.
+			}
 		}
 	}
 }
 
-func (c *PFCPClient) ConnectN4(remoteAddr string) error {
-	addr := fmt.Sprintf("%s:%d", remoteAddr, PFCPStandardPort)
+// handleHeartbeatRequest answers a Heartbeat Request received from the peer, so that the peer
+// does not consider the N4 path failed.
+func (c *PFCPClient) handleHeartbeatRequest(req *message.HeartbeatRequest) {
+	_ = c.sendMsg(message.NewHeartbeatResponse(req.Sequence(),
+		ieLib.NewRecoveryTimeStamp(c.RecoveryTimeStamp()),
+	))
+}
 
-	if host, port, err := net.SplitHostPort(remoteAddr); err == nil {
-		// remoteAddr contains also a port. Use provided port instead of PFCPStandardPort
-		addr = fmt.Sprintf("%s:%s", host, port)
+// handleSessionReport marks the reported session as active if the report carries usage, and acknowledges the
+// Session Report Request towards the peer.
+func (c *PFCPClient) handleSessionReport(req *message.SessionReportRequest) {
+	sess, ok := c.getSession(req.SEID())
+	if !ok {
+		_ = c.sendMsg(message.NewSessionReportResponse(0, 0, 0, req.Sequence(), 0,
+			ieLib.NewCause(ieLib.CauseSessionContextNotFound),
+		))
+
+		return
 	}
 
-	raddr, err := net.ResolveUDPAddr("udp", addr)
+	if (req.ReportType != nil && req.ReportType.HasUSAR()) || len(req.UsageReport) > 0 {
+		sess.markActive()
+	}
+
+	_ = c.sendMsg(message.NewSessionReportResponse(0, 0, sess.peerSEID, req.Sequence(), 0,
+		ieLib.NewCause(ieLib.CauseRequestAccepted),
+	))
+
+	select {
+	case c.reportsChan <- req:
+	default:
+		// Nobody is consuming reports fast enough. Drop it rather than blocking the receive loop.
+	}
+}
+
+// SessionReports returns a channel delivering the Session Report Requests received from the peer
+// for known sessions. Reports are dropped if the channel buffer is full.
+func (c *PFCPClient) SessionReports() <-chan *message.SessionReportRequest {
+	return c.reportsChan
+}
+
+func (c *PFCPClient) ConnectN4(remoteAddr string) error {
+	raddr, err := resolvePeerAddress(remoteAddr)
 	if err != nil {
 		return err
 	}
 
-	conn, err := net.DialUDP("udp", nil, raddr)
+	conn, err := net.DialUDP("udp", c.bindAddr, raddr)
 	if err != nil {
 		return err
 	}
 
 	c.conn = conn
+	c.remoteAddr = nil
 
 	go c.receiveFromN4()
 
 	return nil
 }
 
-func (c *PFCPClient) DisconnectN4() {
-	if c.cancelHeartbeats != nil {
-		c.cancelHeartbeats()
+// ConnectN4WithConn makes the client exchange PFCP messages over conn, e.g. a socket shared with other tools
+// or with custom options, instead of creating one like ConnectN4. If conn is connected, remoteAddr is ignored.
+// Otherwise messages are sent to remoteAddr, in the same format of ConnectN4, and the datagrams received from
+// other addresses are ignored. The client owns conn from then on: DisconnectN4 closes it.
+// LocalAddr is still the address advertised in Node IDs and F-SEIDs.
+func (c *PFCPClient) ConnectN4WithConn(conn *net.UDPConn, remoteAddr string) error {
+	var raddr *net.UDPAddr
+
+	if conn.RemoteAddr() == nil {
+		if remoteAddr == "" {
+			return NewInvalidFormatError("empty remote address. Please provide it for connections not connected to the peer")
+		}
+
+		var err error
+
+		if raddr, err = resolvePeerAddress(remoteAddr); err != nil {
+			return err
+		}
 	}
 
+	c.conn = conn
+	c.remoteAddr = raddr
+
+	go c.receiveFromN4()
+
+	return nil
+}
+
+// resolvePeerAddress resolves remoteAddr, which may include a port. PFCPStandardPort is used otherwise.
+func resolvePeerAddress(remoteAddr string) (*net.UDPAddr, error) {
+	addr := net.JoinHostPort(remoteAddr, strconv.Itoa(PFCPStandardPort))
+
+	if host, port, err := net.SplitHostPort(remoteAddr); err == nil {
+		// remoteAddr contains also a port. Use provided port instead of PFCPStandardPort
+		addr = net.JoinHostPort(host, port)
+	}
+
+	return net.ResolveUDPAddr("udp", addr)
+}
+
+func (c *PFCPClient) DisconnectN4() {
+	c.stopHeartbeats()
+	c.stopReassociation()
+
 	c.conn.Close()
+
+	// the capture is complete: nothing else is going to be sent or received
+	_ = c.StopCapture()
 }
 
 func (c *PFCPClient) PeekNextHeartbeatResponse() (*message.HeartbeatResponse, error) {
@@ -180,73 +558,193 @@ func (c *PFCPClient) PeekNextHeartbeatResponse() (*message.HeartbeatResponse, er
 // It's a blocking operation, which is timed out after c.responseTimeout period (5 seconds by default).
 // Use SetPFCPResponseTimeout() to configure a custom timeout.
 func (c *PFCPClient) PeekNextResponse() (message.Message, error) {
+	return c.peekNextResponseWithContext(context.Background())
+}
+
+// peekNextResponseWithContext behaves like PeekNextResponse, but returns early with ctx.Err() if ctx is done.
+func (c *PFCPClient) peekNextResponseWithContext(ctx context.Context) (message.Message, error) {
 	select {
 	case msg := <-c.recvChan:
 		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	case <-time.After(c.responseTimeout):
 		return nil, NewTimeoutExpiredError()
 	}
 }
 
+// exchange sends req and waits for the response carrying the same sequence number.
+// Unlike PeekNextResponse, it can be safely used by concurrent callers.
+// If retransmissions are enabled, req is resent every retransmissionTimeout until a response is received.
+// Returns ctx.Err() if ctx is done before the response is received, or an error if the timeout of op expires.
+func (c *PFCPClient) exchange(ctx context.Context, op Operation, req message.Message) (message.Message, error) {
+	respChan := make(chan exchangeResult, 1)
+
+	c.pendingLock.Lock()
+	c.pending[req.Sequence()] = respChan
+	c.pendingLock.Unlock()
+
+	defer func() {
+		c.pendingLock.Lock()
+		delete(c.pending, req.Sequence())
+		c.answered.add(req.Sequence())
+		c.pendingLock.Unlock()
+	}()
+
+	attempts, timeout := 1, c.OperationTimeout(op)
+	if c.retransmissions > 0 {
+		attempts, timeout = c.retransmissions+1, c.retransmissionTimeout
+	}
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err := c.sendMsg(req); err != nil {
+			return nil, err
+		}
+
+		select {
+		case result := <-respChan:
+			return result.msg, result.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(timeout):
+		}
+	}
+
+	return nil, NewTimeoutExpiredError()
+}
+
+// exchangeResult is what an exchange waits for: the response to its request, or the error the response was
+// received with.
+type exchangeResult struct {
+	msg message.Message
+	err error
+}
+
+// deliverResponse routes msg to the exchange waiting for it. Returns false if msg is not the response
+// to an exchange, meaning that it should be delivered through PeekNextResponse.
+// Duplicate responses, caused by retransmissions, are dropped.
+func (c *PFCPClient) deliverResponse(msg message.Message) bool {
+	return c.deliver(msg.Sequence(), exchangeResult{msg: msg})
+}
+
+// deliverError fails with err the exchange waiting for the response with sequence number seq, if any.
+func (c *PFCPClient) deliverError(seq uint32, err error) {
+	c.deliver(seq, exchangeResult{err: err})
+}
+
+// deliver routes result to the exchange waiting for the response with sequence number seq.
+// Returns false if no exchange is waiting for it, and it does not answer a completed one.
+func (c *PFCPClient) deliver(seq uint32, result exchangeResult) bool {
+	c.pendingLock.Lock()
+	defer c.pendingLock.Unlock()
+
+	respChan, ok := c.pending[seq]
+	if !ok {
+		return c.answered.contains(seq)
+	}
+
+	select {
+	case respChan <- result:
+	default:
+		// respChan is buffered and already holds the first response received
+	}
+
+	return true
+}
+
+// SetRetransmission enables the retransmission of the requests sent by high-level operations:
+// a request is resent up to n1 times, waiting t1 for a response after each attempt.
+// Setting n1 to 0 disables retransmissions, which is the default.
+func (c *PFCPClient) SetRetransmission(n1 int, t1 time.Duration) {
+	c.retransmissions = n1
+	c.retransmissionTimeout = t1
+}
+
 func (c *PFCPClient) SendAssociationSetupRequest(ie ...*ieLib.IE) error {
+	return c.sendMsg(c.newAssociationSetupRequest(ie...))
+}
+
+func (c *PFCPClient) newAssociationSetupRequest(ie ...*ieLib.IE) *message.AssociationSetupRequest {
 	c.resetSequenceNumber()
 
 	assocReq := message.NewAssociationSetupRequest(
 		c.getNextSequenceNumber(),
-		ieLib.NewRecoveryTimeStamp(time.Now()),
-		ieLib.NewNodeID(c.localAddr, "", ""),
+		ieLib.NewRecoveryTimeStamp(c.RecoveryTimeStamp()),
+		c.localNodeID(),
 	)
 
+	if features := c.CPFunctionFeatures(); features != 0 {
+		assocReq.CPFunctionFeatures = ieLib.NewCPFunctionFeatures(features)
+	}
+
 	assocReq.IEs = append(assocReq.IEs, ie...)
 
-	return c.sendMsg(assocReq)
+	return assocReq
 }
 
-// SendAssociationTeardownRequest sends PFCP Teardown Request towards a peer.
+// SendAssociationTeardownRequest sends PFCP Association Release Request towards a peer.
 // A caller should make sure that the PFCP connection is established before invoking this function.
 func (c *PFCPClient) SendAssociationTeardownRequest(ie ...*ieLib.IE) error {
-	teardownReq := message.NewAssociationReleaseRequest(0,
-		ieLib.NewNodeID(c.conn.RemoteAddr().String(), "", ""),
+	return c.sendMsg(c.newAssociationReleaseRequest(ie...))
+}
+
+func (c *PFCPClient) newAssociationReleaseRequest(ie ...*ieLib.IE) *message.AssociationReleaseRequest {
+	teardownReq := message.NewAssociationReleaseRequest(c.getNextSequenceNumber(),
+		c.localNodeID(),
 	)
 
 	teardownReq.IEs = append(teardownReq.IEs, ie...)
 
-	return c.sendMsg(teardownReq)
+	return teardownReq
 }
 
 func (c *PFCPClient) SendHeartbeatRequest() error {
-	hbReq := message.NewHeartbeatRequest(
+	return c.sendMsg(c.newHeartbeatRequest())
+}
+
+func (c *PFCPClient) newHeartbeatRequest() *message.HeartbeatRequest {
+	return message.NewHeartbeatRequest(
 		c.getNextSequenceNumber(),
-		ieLib.NewRecoveryTimeStamp(time.Now()),
-		ieLib.NewSourceIPAddress(net.ParseIP(c.localAddr), nil, 0),
+		ieLib.NewRecoveryTimeStamp(c.RecoveryTimeStamp()),
+		ieLib.NewSourceIPAddress(c.localIPv4(), c.localIPv6(), 0),
 	)
-
-	return c.sendMsg(hbReq)
 }
 
 func (c *PFCPClient) SendSessionEstablishmentRequest(pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE) error {
+	return c.sendMsg(c.newSessionEstablishmentRequest(c.getNextFSEID(), c.CSID(), ieLib.PDNTypeIPv4, pdrs, fars, qers))
+}
+
+func (c *PFCPClient) newSessionEstablishmentRequest(localSEID uint64, csid uint16, pdnType uint8, pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE) *message.SessionEstablishmentRequest {
 	estReq := message.NewSessionEstablishmentRequest(
 		0,
 		0,
 		0,
 		c.getNextSequenceNumber(),
 		0,
-		ieLib.NewNodeID(c.localAddr, "", ""),
-		ieLib.NewFSEID(c.getNextFSEID(), net.ParseIP(c.localAddr), nil),
-		ieLib.NewPDNType(ieLib.PDNTypeIPv4),
+		c.localNodeID(),
+		ieLib.NewFSEID(localSEID, c.localIPv4(), c.localIPv6()),
+		ieLib.NewPDNType(pdnType),
 	)
 	estReq.CreatePDR = append(estReq.CreatePDR, pdrs...)
 	estReq.CreateFAR = append(estReq.CreateFAR, fars...)
 	estReq.CreateQER = append(estReq.CreateQER, qers...)
 
-	return c.sendMsg(estReq)
+	if csid != 0 {
+		estReq.FQCSID = c.newFQCSID(csid)
+	}
+
+	return estReq
 }
 
 func (c *PFCPClient) SendSessionModificationRequest(PeerSEID uint64, pdrs []*ieLib.IE, qers []*ieLib.IE, fars []*ieLib.IE) error {
+	return c.sendMsg(c.newSessionModificationRequest(PeerSEID, pdrs, fars, qers, nil))
+}
+
+func (c *PFCPClient) newSessionModificationRequest(peerSEID uint64, pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE, bar *ieLib.IE) *message.SessionModificationRequest {
 	modifyReq := message.NewSessionModificationRequest(
 		0,
 		0,
-		PeerSEID,
+		peerSEID,
 		c.getNextSequenceNumber(),
 		0,
 	)
@@ -255,71 +753,150 @@ func (c *PFCPClient) SendSessionModificationRequest(PeerSEID uint64, pdrs []*ieL
 	modifyReq.UpdateFAR = append(modifyReq.UpdateFAR, fars...)
 	modifyReq.UpdateQER = append(modifyReq.UpdateQER, qers...)
 
-	return c.sendMsg(modifyReq)
+	if bar != nil {
+		switch bar.Type {
+		case ieLib.CreateBAR:
+			modifyReq.CreateBAR = bar
+		case ieLib.RemoveBAR:
+			modifyReq.RemoveBAR = bar
+		default:
+			modifyReq.UpdateBAR = bar
+		}
+	}
+
+	return modifyReq
 }
 
 func (c *PFCPClient) SendSessionDeletionRequest(localSEID uint64, remoteSEID uint64) error {
-	delReq := message.NewSessionDeletionRequest(
+	return c.sendMsg(c.newSessionDeletionRequest(localSEID, remoteSEID))
+}
+
+func (c *PFCPClient) newSessionDeletionRequest(localSEID uint64, remoteSEID uint64) *message.SessionDeletionRequest {
+	return message.NewSessionDeletionRequest(
 		0,
 		0,
 		remoteSEID,
 		c.getNextSequenceNumber(),
 		0,
-		ieLib.NewFSEID(localSEID, net.ParseIP(c.localAddr), nil),
+		ieLib.NewFSEID(localSEID, c.localIPv4(), c.localIPv6()),
 	)
+}
 
-	return c.sendMsg(delReq)
+// SendPFDManagementRequest sends PFD Management Request carrying the given Application ID's PFDs IEs.
+func (c *PFCPClient) SendPFDManagementRequest(appPFDs ...*ieLib.IE) error {
+	return c.sendMsg(message.NewPFDManagementRequest(c.getNextSequenceNumber(), appPFDs...))
 }
 
+// StartHeartbeats sends a Heartbeat Request every heartbeat period until stopCtx is done.
+// Unanswered heartbeats do not stop it, so that the recovery of the N4 path can be detected.
+// It stops if a request can't be sent or if the peer restarted.
 func (c *PFCPClient) StartHeartbeats(stopCtx context.Context) {
-	ticker := time.NewTicker(DefaultHeartbeatPeriod * time.Second)
+	ticker := time.NewTicker(c.heartbeatPeriod)
+	defer ticker.Stop()
 
 	for {
 		select {
 		case <-stopCtx.Done():
 			return
 		case <-ticker.C:
-			err := c.SendAndRecvHeartbeat()
-			if err != nil {
+			missed, err := c.sendAndRecvHeartbeat()
+			if err != nil && !missed {
 				return
 			}
 		}
 	}
 }
 
+// SendAndRecvHeartbeat sends a Heartbeat Request and waits for the Heartbeat Response.
+// Returns error if the peer does not answer, see SetMaxMissedHeartbeats, or if it restarted.
 func (c *PFCPClient) SendAndRecvHeartbeat() error {
-	err := c.SendHeartbeatRequest()
-	if err != nil {
-		return err
+	_, err := c.sendAndRecvHeartbeat()
+
+	return err
+}
+
+// sendAndRecvHeartbeat implements SendAndRecvHeartbeat. missed is true if the peer did not answer.
+// The response is matched by sequence number: late responses to previous heartbeats are dropped.
+func (c *PFCPClient) sendAndRecvHeartbeat() (missed bool, err error) {
+	resp, err := c.exchange(context.Background(), OperationHeartbeat, c.newHeartbeatRequest())
+	if isTimeoutExpired(err) {
+		c.heartbeatMissed()
+		return true, err
 	}
 
-	_, err = c.PeekNextHeartbeatResponse()
 	if err != nil {
-		c.setAssociationStatus(false)
-		return err
+		return false, err
+	}
+
+	hbResp, ok := resp.(*message.HeartbeatResponse)
+	if !ok {
+		return false, NewUnexpectedResponseError(resp)
+	}
+
+	if c.updatePeerRecoveryTimeStamp(hbResp.RecoveryTimeStamp) {
+		// The association is lost along with the peer state; do not mark it alive again.
+		return false, NewAssociationInactiveError()
 	}
 
 	c.setAssociationStatus(true)
+	c.heartbeatAnswered()
 
-	return nil
+	return false, nil
 }
 
 // SetupAssociation sends PFCP Association Setup Request and waits for PFCP Association Setup Response.
 // Returns error if the process fails at any stage.
 func (c *PFCPClient) SetupAssociation() error {
-	err := c.SendAssociationSetupRequest()
-	if err != nil {
-		return err
+	return c.setupAssociation(context.Background())
+}
+
+// SetupAssociationWithRetry tries to set up the association up to attempts times. Between two consecutive
+// attempts it waits for a backoff period, starting from initialBackoff and doubling after every failure.
+// Returns the error of the last attempt if all of them fail, or ctx.Err() as soon as ctx is done.
+func (c *PFCPClient) SetupAssociationWithRetry(ctx context.Context, attempts int, initialBackoff time.Duration) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	backoff := initialBackoff
+
+	var err error
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = c.setupAssociation(ctx)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		if attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
 	}
 
-	resp, err := c.PeekNextResponse()
+	return err
+}
+
+func (c *PFCPClient) setupAssociation(ctx context.Context) (err error) {
+	defer func(start time.Time) {
+		observeExchange(opAssociationSetup, start, err)
+	}(time.Now())
+
+	resp, err := c.exchange(ctx, OperationAssociation, c.newAssociationSetupRequest())
 	if err != nil {
 		return err
 	}
 
 	assocResp, ok := resp.(*message.AssociationSetupResponse)
 	if !ok {
-		return NewInvalidResponseError()
+		return NewUnexpectedResponseError(resp)
 	}
 
 	cause, err := assocResp.Cause.Cause()
@@ -331,16 +908,37 @@ func (c *PFCPClient) SetupAssociation() error {
 		return NewInvalidResponseError()
 	}
 
+	c.updatePeerRecoveryTimeStamp(assocResp.RecoveryTimeStamp)
+	c.updatePeerUPFunctionFeatures(assocResp.UPFunctionFeatures)
+
+	// the heartbeats of the previous association, if any, are replaced
+	c.stopHeartbeats()
+
 	ctx, cancelFunc := context.WithCancel(c.ctx)
+
+	c.aliveLock.Lock()
 	c.cancelHeartbeats = cancelFunc
+	c.aliveLock.Unlock()
 
-	c.setAssociationStatus(true)
+	c.resetN4Path()
+	c.setAssociated(assocResp.NodeID)
+	associationsSetup.Inc()
 
 	go c.StartHeartbeats(ctx)
 
 	return nil
 }
 
+// stopHeartbeats stops the heartbeats started by the last association setup, if any.
+func (c *PFCPClient) stopHeartbeats() {
+	c.aliveLock.Lock()
+	defer c.aliveLock.Unlock()
+
+	if c.cancelHeartbeats != nil {
+		c.cancelHeartbeats()
+	}
+}
+
 func (c *PFCPClient) IsAssociationAlive() bool {
 	c.aliveLock.Lock()
 	defer c.aliveLock.Unlock()
@@ -348,32 +946,175 @@ func (c *PFCPClient) IsAssociationAlive() bool {
 	return c.isAssociationActive
 }
 
+// AssociatedAt returns the time the last association was set up.
+// The zero time is returned if no association was ever set up.
+func (c *PFCPClient) AssociatedAt() time.Time {
+	c.aliveLock.Lock()
+	defer c.aliveLock.Unlock()
+
+	return c.associatedAt
+}
+
+// PeerNodeID returns the Node ID advertised by the peer while setting up the last association,
+// e.g. an IP address or a FQDN. It is empty if no association was ever set up.
+func (c *PFCPClient) PeerNodeID() string {
+	c.aliveLock.Lock()
+	defer c.aliveLock.Unlock()
+
+	return c.peerNodeID
+}
+
+// setAssociated marks the association as active and records when it was set up, along with the Node ID of the peer.
+func (c *PFCPClient) setAssociated(peerNodeID *ieLib.IE) {
+	c.aliveLock.Lock()
+	defer c.aliveLock.Unlock()
+
+	c.isAssociationActive = true
+	c.associatedAt = time.Now()
+	c.peerNodeID = ""
+
+	if peerNodeID != nil {
+		c.peerNodeID, _ = peerNodeID.NodeID()
+	}
+}
+
 // TeardownAssociation tears down an already established association.
-// If called while no association is established, an error is returned
+// If called while no association is established, an error is returned.
+// See ReleaseAssociation for the details of the procedure.
 func (c *PFCPClient) TeardownAssociation() error {
+	_, err := c.ReleaseAssociation()
+
+	return err
+}
+
+// ReleaseAssociation sends PFCP Association Release Request and waits for PFCP Association Release Response.
+// Returns the cause received from the peer. If the peer accepts the request, or if it does not answer
+// within the response timeout (e.g. it does not support the procedure), the association is released locally.
+// In the latter case the returned cause is 0. If the peer rejects the request, the association is kept
+// and an error is returned along with the cause. Other failures, e.g. if the request can't be sent,
+// keep the association too.
+func (c *PFCPClient) ReleaseAssociation() (cause uint8, err error) {
+	if !c.IsAssociationAlive() {
+		return 0, NewAssociationInactiveError()
+	}
+
+	defer func(start time.Time) {
+		observeExchange(opAssociationRelease, start, err)
+	}(time.Now())
+
+	resp, err := c.exchange(context.Background(), OperationAssociation, c.newAssociationReleaseRequest())
+	if err != nil && !isTimeoutExpired(err) {
+		return 0, err
+	}
+
+	if err == nil {
+		releaseResp, ok := resp.(*message.AssociationReleaseResponse)
+		if !ok {
+			return 0, NewUnexpectedResponseError(resp)
+		}
+
+		cause, err = releaseResp.Cause.Cause()
+		if err != nil {
+			return 0, NewInvalidResponseError(err)
+		}
+
+		if cause != ieLib.CauseRequestAccepted {
+			return cause, NewRejectedRequestError(cause)
+		}
+	}
+
+	c.stopHeartbeats()
+	c.stopReassociation()
+
+	c.setAssociationStatus(false)
+
+	return cause, nil
+}
+
+// UpdateAssociation sends PFCP Association Update Request and waits for PFCP Association Update Response,
+// see UpdateAssociationWithContext.
+func (c *PFCPClient) UpdateAssociation(cpFunctionFeatures uint8, gracefulReleasePeriod time.Duration) error {
+	return c.UpdateAssociationWithContext(context.Background(), cpFunctionFeatures, gracefulReleasePeriod)
+}
+
+// UpdateAssociationWithContext advertises cpFunctionFeatures to the peer with PFCP Association Update Request,
+// along with gracefulReleasePeriod if not 0, and waits for PFCP Association Update Response until ctx is done.
+// If the peer accepts the request, cpFunctionFeatures are advertised in the next Association Setup Requests as well,
+// and the UP function features are replaced by the ones in the response, if any.
+// Returns error if the association is not active, if the process fails at any stage or if the peer rejects the request.
+func (c *PFCPClient) UpdateAssociationWithContext(ctx context.Context, cpFunctionFeatures uint8, gracefulReleasePeriod time.Duration) (err error) {
 	if !c.IsAssociationAlive() {
 		return NewAssociationInactiveError()
 	}
 
-	err := c.SendAssociationTeardownRequest()
+	defer func(start time.Time) {
+		observeExchange(opAssociationUpdate, start, err)
+	}(time.Now())
+
+	resp, err := c.exchange(ctx, OperationAssociation, c.newAssociationUpdateRequest(cpFunctionFeatures, gracefulReleasePeriod))
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.PeekNextResponse()
+	updateResp, ok := resp.(*message.AssociationUpdateResponse)
+	if !ok {
+		return NewUnexpectedResponseError(resp)
+	}
+
+	cause, err := updateResp.Cause.Cause()
+	if err != nil {
+		return NewInvalidResponseError(err)
+	}
+
+	if cause != ieLib.CauseRequestAccepted {
+		return NewRejectedRequestError(cause)
+	}
+
+	c.SetCPFunctionFeatures(cpFunctionFeatures)
+
+	if updateResp.UPFunctionFeatures != nil {
+		c.updatePeerUPFunctionFeatures(updateResp.UPFunctionFeatures)
+	}
+
+	return nil
+}
+
+func (c *PFCPClient) newAssociationUpdateRequest(cpFunctionFeatures uint8, gracefulReleasePeriod time.Duration) *message.AssociationUpdateRequest {
+	ies := []*ieLib.IE{c.localNodeID(), ieLib.NewCPFunctionFeatures(cpFunctionFeatures)}
+
+	if gracefulReleasePeriod != 0 {
+		ies = append(ies, ieLib.NewGracefulReleasePeriod(gracefulReleasePeriod))
+	}
+
+	return message.NewAssociationUpdateRequest(c.getNextSequenceNumber(), ies...)
+}
+
+// SendPFDManagement sends PFD Management Request and waits for PFD Management Response.
+// PFDs are provisioned at node level, hence an active association is required.
+// Returns error if the process fails at any stage or if the peer does not accept the request.
+func (c *PFCPClient) SendPFDManagement(appPFDs ...*ieLib.IE) error {
+	if !c.IsAssociationAlive() {
+		return NewAssociationInactiveError()
+	}
+
+	resp, err := c.exchange(context.Background(), OperationPFDManagement, message.NewPFDManagementRequest(c.getNextSequenceNumber(), appPFDs...))
 	if err != nil {
 		return err
 	}
 
-	if _, ok := resp.(*message.AssociationReleaseResponse); !ok {
-		return NewInvalidResponseError()
+	pfdResp, ok := resp.(*message.PFDManagementResponse)
+	if !ok {
+		return NewUnexpectedResponseError(resp)
 	}
 
-	if c.cancelHeartbeats != nil {
-		c.cancelHeartbeats()
+	cause, err := pfdResp.Cause.Cause()
+	if err != nil {
+		return NewInvalidResponseError(err)
 	}
 
-	c.setAssociationStatus(false)
+	if cause != ieLib.CauseRequestAccepted {
+		return NewRejectedRequestError(cause)
+	}
 
 	return nil
 }
@@ -381,27 +1122,50 @@ func (c *PFCPClient) TeardownAssociation() error {
 // EstablishSession sends PFCP Session Establishment Request and waits for PFCP Session Establishment Response.
 // Returns a pointer to a new PFCPSession. Returns error if the process fails at any stage.
 func (c *PFCPClient) EstablishSession(pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE) (*PFCPSession, error) {
-	if !c.isAssociationActive {
+	return c.EstablishSessionWithContext(context.Background(), pdrs, fars, qers)
+}
+
+// EstablishSessionWithContext establishes a session like EstablishSession, but stops waiting for
+// the response once ctx is done. In that case the peer may have established the session anyway.
+func (c *PFCPClient) EstablishSessionWithContext(ctx context.Context, pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE) (*PFCPSession, error) {
+	return c.EstablishSessionWithPDNType(ctx, ieLib.PDNTypeIPv4, pdrs, fars, qers)
+}
+
+// EstablishSessionWithPDNType establishes a session like EstablishSessionWithContext, advertising pdnType,
+// one of the ieLib.PDNType* values, instead of IPv4. It must match the IP versions of the UE addresses.
+func (c *PFCPClient) EstablishSessionWithPDNType(ctx context.Context, pdnType uint8, pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE) (*PFCPSession, error) {
+	return c.EstablishSessionWithSEID(ctx, c.allocateSEID(), pdnType, pdrs, fars, qers)
+}
+
+// EstablishSessionWithSEID establishes a session like EstablishSessionWithPDNType, using localSEID as local SEID
+// instead of allocating one. Returns error if localSEID is 0 or used by another active session.
+func (c *PFCPClient) EstablishSessionWithSEID(ctx context.Context, localSEID uint64, pdnType uint8, pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE) (_ *PFCPSession, err error) {
+	if !c.IsAssociationAlive() {
 		return nil, NewAssociationInactiveError()
 	}
 
-	err := c.SendSessionEstablishmentRequest(pdrs, fars, qers)
-	if err != nil {
-		return nil, err
+	if localSEID == 0 || c.isSEIDInUse(localSEID) {
+		return nil, NewSEIDInUseError(localSEID)
 	}
 
-	resp, err := c.PeekNextResponse()
+	defer func(start time.Time) {
+		observeExchange(opSessionEstablishment, start, err)
+	}(time.Now())
+
+	csid := c.CSID()
+
+	resp, err := c.exchange(ctx, OperationEstablishment, c.newSessionEstablishmentRequest(localSEID, csid, pdnType, pdrs, fars, qers))
 	if err != nil {
-		return nil, NewTimeoutExpiredError(err)
+		return nil, err
 	}
 
 	estResp, ok := resp.(*message.SessionEstablishmentResponse)
 	if !ok {
-		return nil, NewInvalidResponseError(err)
+		return nil, NewUnexpectedResponseError(resp)
 	}
 
-	if cause, err := estResp.Cause.Cause(); err != nil || cause != ieLib.CauseRequestAccepted {
-		return nil, NewInvalidCauseError(err)
+	if err := checkCause(estResp.Cause, estResp.FailedRuleID, estResp.OffendingIE); err != nil {
+		return nil, err
 	}
 
 	remoteSEID, err := estResp.UPFSEID.FSEID()
@@ -409,62 +1173,171 @@ func (c *PFCPClient) EstablishSession(pdrs []*ieLib.IE, fars []*ieLib.IE, qers [
 		return nil, err
 	}
 
-	sess := &PFCPSession{
-		localSEID: c.lastFSEID,
-		peerSEID:  remoteSEID.SEID,
-	}
+	sess := newPFCPSession(localSEID, remoteSEID.SEID)
+	sess.allocatedFTEIDs = parseCreatedPDRs(estResp.CreatedPDR)
+	sess.csid = csid
+	sess.pdnType = pdnType
+	sess.addFailedRule(parseFailedRule(estResp.FailedRuleID))
+	c.insertSession(sess)
+	sessionsEstablished.Inc()
 
 	return sess, nil
 }
 
+// BuildSessionEstablishmentRequest returns the PFCP Session Establishment Request EstablishSessionWithSEID
+// would send, without sending it nor recording any session, e.g. to inspect the IEs built for a session.
+// It doesn't require an active association.
+func (c *PFCPClient) BuildSessionEstablishmentRequest(localSEID uint64, pdnType uint8, pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE) *message.SessionEstablishmentRequest {
+	return c.newSessionEstablishmentRequest(localSEID, c.CSID(), pdnType, pdrs, fars, qers)
+}
+
+// parseCreatedPDRs returns the F-TEIDs carried by the Created PDR IEs, indexed by PDR ID.
+// Malformed IEs are ignored.
+func parseCreatedPDRs(createdPDRs []*ieLib.IE) map[uint16]*ieLib.FTEIDFields {
+	fteids := make(map[uint16]*ieLib.FTEIDFields, len(createdPDRs))
+
+	for _, created := range createdPDRs {
+		id, err := created.PDRID()
+		if err != nil {
+			continue
+		}
+
+		fteid, err := created.FTEID()
+		if err != nil {
+			continue
+		}
+
+		fteids[id] = fteid
+	}
+
+	return fteids
+}
+
 func (c *PFCPClient) ModifySession(sess *PFCPSession, pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE) error {
-	if !c.isAssociationActive {
+	return c.ModifySessionWithBAR(sess, pdrs, fars, qers, nil)
+}
+
+// ModifySessionWithBAR modifies the session like ModifySession, also creating, updating or removing
+// the session BAR according to the type of bar, if not nil. Use PFCPSession.HasBAR to know whether
+// the session BAR must be created or updated.
+func (c *PFCPClient) ModifySessionWithBAR(sess *PFCPSession, pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE, bar *ieLib.IE) error {
+	return c.ModifySessionWithContext(context.Background(), sess, pdrs, fars, qers, bar)
+}
+
+// ModifySessionWithContext modifies the session like ModifySessionWithBAR, but stops waiting for
+// the response once ctx is done. bar can be nil.
+func (c *PFCPClient) ModifySessionWithContext(ctx context.Context, sess *PFCPSession, pdrs []*ieLib.IE, fars []*ieLib.IE, qers []*ieLib.IE, bar *ieLib.IE) error {
+	if !c.IsAssociationAlive() {
 		return NewAssociationInactiveError()
 	}
 
-	err := c.SendSessionModificationRequest(sess.peerSEID, pdrs, fars, qers)
+	_, err := c.modifySession(ctx, sess, c.newSessionModificationRequest(sess.peerSEID, pdrs, fars, qers, bar))
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.PeekNextResponse()
+	if bar != nil {
+		switch bar.Type {
+		case ieLib.CreateBAR:
+			sess.markBARCreated()
+		case ieLib.RemoveBAR:
+			sess.markBARRemoved()
+		}
+	}
+
+	return nil
+}
+
+// QueryURRs sends a Session Modification Request carrying a Query URR IE for each of urrIDs, asking the peer for
+// the usage measured so far by these URRs of sess without waiting for their reporting triggers.
+// Returns the Usage Reports of the Session Modification Response, in order.
+// Stops waiting for the response once ctx is done.
+func (c *PFCPClient) QueryURRs(ctx context.Context, sess *PFCPSession, urrIDs ...uint32) ([]session.UsageReport, error) {
+	if !c.IsAssociationAlive() {
+		return nil, NewAssociationInactiveError()
+	}
+
+	modifyReq := c.newSessionModificationRequest(sess.peerSEID, nil, nil, nil, nil)
+
+	for _, id := range urrIDs {
+		modifyReq.QueryURR = append(modifyReq.QueryURR, ieLib.NewQueryURR(ieLib.NewURRID(id)))
+	}
+
+	modRes, err := c.modifySession(ctx, sess, modifyReq)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]session.UsageReport, 0, len(modRes.UsageReport))
+
+	for _, usageReport := range modRes.UsageReport {
+		report, err := session.ParseUsageReport(usageReport)
+		if err != nil {
+			return nil, NewInvalidResponseError(err)
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// modifySession sends modifyReq, a Session Modification Request for sess, and returns the response
+// if the peer accepted the modification. The rules the peer reported as failed are recorded in sess.
+func (c *PFCPClient) modifySession(ctx context.Context, sess *PFCPSession, modifyReq *message.SessionModificationRequest) (_ *message.SessionModificationResponse, err error) {
+	defer func(start time.Time) {
+		observeExchange(opSessionModification, start, err)
+	}(time.Now())
+
+	resp, err := c.exchange(ctx, OperationModification, modifyReq)
 	if err != nil {
-		return NewTimeoutExpiredError(err)
+		return nil, err
 	}
 
 	modRes, ok := resp.(*message.SessionModificationResponse)
 	if !ok {
-		return NewInvalidResponseError(err)
+		return nil, NewUnexpectedResponseError(resp)
 	}
 
-	if cause, err := modRes.Cause.Cause(); err != nil || cause != ieLib.CauseRequestAccepted {
-		return NewInvalidCauseError(err)
+	if err := checkCause(modRes.Cause, modRes.FailedRuleID, modRes.OffendingIE); err != nil {
+		return nil, err
 	}
 
-	return nil
+	sess.addFailedRule(parseFailedRule(modRes.FailedRuleID))
+	sessionsModified.Inc()
+
+	return modRes, nil
 }
 
 // DeleteSession sends Session Deletion Request for each session and awaits for PFCP Session Deletion Response.
 // Returns error if the process fails at any stage.
 func (c *PFCPClient) DeleteSession(sess *PFCPSession) error {
-	err := c.SendSessionDeletionRequest(sess.localSEID, sess.peerSEID)
-	if err != nil {
-		return err
-	}
+	return c.DeleteSessionWithContext(context.Background(), sess)
+}
+
+// DeleteSessionWithContext deletes the session like DeleteSession, but stops waiting for
+// the response once ctx is done.
+func (c *PFCPClient) DeleteSessionWithContext(ctx context.Context, sess *PFCPSession) (err error) {
+	defer func(start time.Time) {
+		observeExchange(opSessionDeletion, start, err)
+	}(time.Now())
 
-	resp, err := c.PeekNextResponse()
+	resp, err := c.exchange(ctx, OperationDeletion, c.newSessionDeletionRequest(sess.localSEID, sess.peerSEID))
 	if err != nil {
 		return err
 	}
 
 	delResp, ok := resp.(*message.SessionDeletionResponse)
 	if !ok {
-		return NewInvalidResponseError()
+		return NewUnexpectedResponseError(resp)
 	}
 
-	if cause, err := delResp.Cause.Cause(); err != nil || cause != ieLib.CauseRequestAccepted {
-		return NewInvalidCauseError(err)
+	if err := checkCause(delResp.Cause, nil, delResp.OffendingIE); err != nil {
+		return err
 	}
 
+	c.removeSession(sess.localSEID)
+	sessionsDeleted.Inc()
+
 	return nil
 }
@@ -0,0 +1,844 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ardzoht/pfcpsim/internal/fakeupf"
+	"github.com/stretchr/testify/require"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// newAssociatedClient returns a PFCPClient associated with a new FakeUPF.
+func newAssociatedClient(t *testing.T) (*PFCPClient, *fakeupf.FakeUPF) {
+	client, upf := newConnectedClient(t)
+	require.NoError(t, client.SetupAssociation())
+
+	return client, upf
+}
+
+// newConnectedClient returns a PFCPClient connected, but not associated, to a new FakeUPF.
+func newConnectedClient(t *testing.T) (*PFCPClient, *fakeupf.FakeUPF) {
+	upf, err := fakeupf.New()
+	require.NoError(t, err)
+
+	client := NewPFCPClient("127.0.0.1")
+	require.NoError(t, client.ConnectN4(upf.Addr()))
+
+	t.Cleanup(func() {
+		client.DisconnectN4()
+		upf.Close()
+	})
+
+	return client, upf
+}
+
+// acceptAssociationAfter makes upf ignore the first n Association Setup Requests.
+func acceptAssociationAfter(upf *fakeupf.FakeUPF, n int32) {
+	var attempts int32
+
+	upf.HandleFunc(message.MsgTypeAssociationSetupRequest, func(req message.Message) message.Message {
+		if atomic.AddInt32(&attempts, 1) <= n {
+			return nil
+		}
+
+		return message.NewAssociationSetupResponse(req.Sequence(),
+			upf.NodeID(),
+			ieLib.NewCause(ieLib.CauseRequestAccepted),
+			ieLib.NewRecoveryTimeStamp(time.Now()),
+		)
+	})
+}
+
+func TestSetupAssociationWithRetry(t *testing.T) {
+	t.Run("accepted on the third attempt", func(t *testing.T) {
+		client, upf := newConnectedClient(t)
+		client.SetPFCPResponseTimeout(100 * time.Millisecond)
+		acceptAssociationAfter(upf, 2)
+
+		require.NoError(t, client.SetupAssociationWithRetry(context.Background(), 3, 10*time.Millisecond))
+		require.True(t, client.IsAssociationAlive())
+		require.Len(t, upf.Received(message.MsgTypeAssociationSetupRequest), 3)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		client, upf := newConnectedClient(t)
+		client.SetPFCPResponseTimeout(100 * time.Millisecond)
+		acceptAssociationAfter(upf, 2)
+
+		require.Error(t, client.SetupAssociationWithRetry(context.Background(), 2, 10*time.Millisecond))
+		require.False(t, client.IsAssociationAlive())
+		require.Len(t, upf.Received(message.MsgTypeAssociationSetupRequest), 2)
+	})
+
+	t.Run("aborted by context cancellation", func(t *testing.T) {
+		client, upf := newConnectedClient(t)
+		acceptAssociationAfter(upf, 100)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := client.SetupAssociationWithRetry(ctx, 10, time.Second)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), time.Second)
+		require.Len(t, upf.Received(message.MsgTypeAssociationSetupRequest), 1)
+	})
+}
+
+func TestHandleNodeReport(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+
+	require.NoError(t, upf.Send(message.NewNodeReportRequest(1,
+		upf.NodeID(),
+		ieLib.NewNodeReportType(0x01),
+		ieLib.NewUserPlanePathFailureReport(
+			ieLib.NewRemoteGTPUPeer(0x02, "198.18.0.10", "", 0, ""),
+		),
+	)))
+
+	require.Eventually(t, func() bool {
+		return len(upf.Received(message.MsgTypeNodeReportResponse)) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	resp := upf.Received(message.MsgTypeNodeReportResponse)[0].(*message.NodeReportResponse)
+	cause, err := resp.Cause.Cause()
+	require.NoError(t, err)
+	require.Equal(t, ieLib.CauseRequestAccepted, cause)
+
+	failures := client.UserPlanePathFailures()
+	require.Len(t, failures, 1)
+	require.Equal(t, "127.0.0.1", failures[0].NodeID)
+	require.Equal(t, []string{"198.18.0.10"}, failures[0].RemotePeers)
+
+	// A Node Report Request without Node ID must be rejected and not recorded
+	require.NoError(t, upf.Send(message.NewNodeReportRequest(2,
+		ieLib.NewNodeReportType(0x01),
+	)))
+
+	require.Eventually(t, func() bool {
+		return len(upf.Received(message.MsgTypeNodeReportResponse)) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	resp = upf.Received(message.MsgTypeNodeReportResponse)[1].(*message.NodeReportResponse)
+	cause, err = resp.Cause.Cause()
+	require.NoError(t, err)
+	require.Equal(t, ieLib.CauseMandatoryIEMissing, cause)
+	require.Len(t, client.UserPlanePathFailures(), 1)
+}
+
+func TestUnsolicitedMessages(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+
+	require.NoError(t, upf.Send(message.NewHeartbeatRequest(0x100000, ieLib.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()), nil)))
+
+	require.Eventually(t, func() bool {
+		return len(upf.Received(message.MsgTypeHeartbeatResponse)) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	hbResp := upf.Received(message.MsgTypeHeartbeatResponse)[0].(*message.HeartbeatResponse)
+	require.Equal(t, uint32(0x100000), hbResp.Sequence())
+
+	// Nobody consumes the unsolicited messages: they must not block the responses to the next requests
+	for i := 0; i < receivedMessagesBufferSize+8; i++ {
+		require.NoError(t, upf.Send(message.NewAssociationReleaseRequest(uint32(0x100001+i), upf.NodeID())))
+	}
+
+	_, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+}
+
+func TestPeerRestartDetection(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+	require.Equal(t, upf.RecoveryTimeStamp().Unix(), client.PeerRecoveryTimeStamp().Unix())
+
+	sess, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+
+	// Same recovery timestamp: nothing changes
+	require.NoError(t, client.SendAndRecvHeartbeat())
+	require.True(t, client.IsAssociationAlive())
+	require.False(t, sess.IsStale())
+
+	// The client must advertise the same recovery timestamp in every request
+	hbReq := upf.Received(message.MsgTypeHeartbeatRequest)[0].(*message.HeartbeatRequest)
+	ts, err := hbReq.RecoveryTimeStamp.RecoveryTimeStamp()
+	require.NoError(t, err)
+	require.Equal(t, client.RecoveryTimeStamp().Unix(), ts.Unix())
+
+	restartedAt := upf.RecoveryTimeStamp().Add(time.Minute)
+	upf.SetRecoveryTimeStamp(restartedAt)
+
+	require.Error(t, client.SendAndRecvHeartbeat())
+	require.False(t, client.IsAssociationAlive())
+	require.True(t, sess.IsStale())
+
+	select {
+	case restart := <-client.PeerRestarts():
+		require.Equal(t, restartedAt.Unix(), restart.RecoveryTimeStamp.Unix())
+		require.Equal(t, []*PFCPSession{sess}, restart.StaleSessions)
+	default:
+		require.Fail(t, "peer restart not notified")
+	}
+
+	// Re-associating with the restarted peer must not be reported as a further restart
+	require.NoError(t, client.SetupAssociation())
+	require.True(t, client.IsAssociationAlive())
+	require.Empty(t, client.PeerRestarts())
+}
+
+func TestN4PathFailureDetection(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+	client.SetPFCPResponseTimeout(50 * time.Millisecond)
+	client.SetMaxMissedHeartbeats(2)
+
+	sess, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+
+	var unreachable int32 = 1
+
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message {
+		if atomic.LoadInt32(&unreachable) == 1 {
+			return nil
+		}
+
+		return message.NewHeartbeatResponse(req.Sequence(), ieLib.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()))
+	})
+
+	// a single missed heartbeat is tolerated
+	require.Error(t, client.SendAndRecvHeartbeat())
+	require.True(t, client.IsAssociationAlive())
+	require.True(t, client.IsN4PathUp())
+	require.Empty(t, client.N4PathEvents())
+
+	require.Error(t, client.SendAndRecvHeartbeat())
+	require.False(t, client.IsAssociationAlive())
+	require.False(t, client.IsN4PathUp())
+
+	event := <-client.N4PathEvents()
+	require.False(t, event.Up)
+	require.Equal(t, 2, event.MissedHeartbeats)
+	require.Equal(t, []*PFCPSession{sess}, event.Sessions)
+
+	// further misses are not notified again
+	require.Error(t, client.SendAndRecvHeartbeat())
+	require.Empty(t, client.N4PathEvents())
+
+	atomic.StoreInt32(&unreachable, 0)
+
+	require.NoError(t, client.SendAndRecvHeartbeat())
+	require.True(t, client.IsAssociationAlive())
+	require.True(t, client.IsN4PathUp())
+	require.False(t, sess.IsStale())
+
+	event = <-client.N4PathEvents()
+	require.True(t, event.Up)
+	require.Equal(t, []*PFCPSession{sess}, event.Sessions)
+}
+
+func TestLateHeartbeatResponse(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+	client.SetPFCPResponseTimeout(50 * time.Millisecond)
+	client.SetMaxMissedHeartbeats(2)
+
+	var answered int32
+
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message {
+		if atomic.AddInt32(&answered, 1) > 1 {
+			return message.NewHeartbeatResponse(req.Sequence(), ieLib.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()))
+		}
+
+		// The first response arrives after the timeout, advertising a restart if taken as the next response.
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+
+			_ = upf.Send(message.NewHeartbeatResponse(req.Sequence(),
+				ieLib.NewRecoveryTimeStamp(upf.RecoveryTimeStamp().Add(time.Minute))))
+		}()
+
+		return nil
+	})
+
+	require.Error(t, client.SendAndRecvHeartbeat())
+	time.Sleep(200 * time.Millisecond)
+
+	require.NoError(t, client.SendAndRecvHeartbeat())
+	require.True(t, client.IsAssociationAlive())
+	require.Empty(t, client.PeerRestarts())
+}
+
+func TestFunctionFeatures(t *testing.T) {
+	client, upf := newConnectedClient(t)
+
+	// BUCP, DDND and FTUP in the 5th octet, EMPU in the 6th octet, RTTL in the 8th octet
+	upFeatures := []byte{0x13, 0x01, 0x00, 0x02}
+
+	upf.HandleFunc(message.MsgTypeAssociationSetupRequest, func(req message.Message) message.Message {
+		return message.NewAssociationSetupResponse(req.Sequence(),
+			upf.NodeID(),
+			ieLib.NewCause(ieLib.CauseRequestAccepted),
+			ieLib.NewRecoveryTimeStamp(time.Now()),
+			ieLib.NewUPFunctionFeatures(upFeatures...),
+		)
+	})
+
+	require.Nil(t, client.PeerUPFunctionFeatures())
+
+	client.SetCPFunctionFeatures(0x01) // LOAD
+	require.NoError(t, client.SetupAssociation())
+
+	require.Equal(t, upFeatures, client.PeerUPFunctionFeatures())
+	require.Equal(t, []string{"BUCP", "DDND", "FTUP", "EMPU", "RTTL"}, UPFunctionFeatureNames(client.PeerUPFunctionFeatures()))
+
+	assocReq := upf.Received(message.MsgTypeAssociationSetupRequest)[0].(*message.AssociationSetupRequest)
+	require.NotNil(t, assocReq.CPFunctionFeatures)
+	require.True(t, assocReq.CPFunctionFeatures.HasLOAD())
+
+	// features are forgotten if the peer no longer advertises them
+	acceptAssociationAfter(upf, 0)
+	client.SetCPFunctionFeatures(0)
+	require.NoError(t, client.SetupAssociation())
+
+	require.Nil(t, client.PeerUPFunctionFeatures())
+	require.Nil(t, upf.Received(message.MsgTypeAssociationSetupRequest)[1].(*message.AssociationSetupRequest).CPFunctionFeatures)
+}
+
+func TestUpdateAssociation(t *testing.T) {
+	client, upf := newConnectedClient(t)
+
+	require.Error(t, client.UpdateAssociation(0x01, 0))
+	require.Empty(t, upf.Received(message.MsgTypeAssociationUpdateRequest))
+
+	require.NoError(t, client.SetupAssociation())
+	require.Nil(t, client.PeerUPFunctionFeatures())
+
+	// BUCP and DDND in the 5th octet
+	upFeatures := []byte{0x03, 0x00}
+
+	upf.HandleFunc(message.MsgTypeAssociationUpdateRequest, func(req message.Message) message.Message {
+		return message.NewAssociationUpdateResponse(req.Sequence(),
+			upf.NodeID(),
+			ieLib.NewCause(ieLib.CauseRequestAccepted),
+			ieLib.NewUPFunctionFeatures(upFeatures...),
+		)
+	})
+
+	require.NoError(t, client.UpdateAssociation(0x01, 30*time.Second)) // LOAD
+
+	require.Equal(t, uint8(0x01), client.CPFunctionFeatures())
+	require.Equal(t, upFeatures, client.PeerUPFunctionFeatures())
+
+	updateReq := upf.Received(message.MsgTypeAssociationUpdateRequest)[0].(*message.AssociationUpdateRequest)
+	require.True(t, updateReq.CPFunctionFeatures.HasLOAD())
+
+	period, err := updateReq.GracefulReleasePeriod.GracefulReleasePeriod()
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, period)
+
+	// the stored features are kept if the peer rejects the update
+	upf.HandleFunc(message.MsgTypeAssociationUpdateRequest, func(req message.Message) message.Message {
+		return message.NewAssociationUpdateResponse(req.Sequence(),
+			upf.NodeID(),
+			ieLib.NewCause(ieLib.CauseRequestRejected),
+			ieLib.NewUPFunctionFeatures(0xff),
+		)
+	})
+
+	err = client.UpdateAssociation(0x02, 0)
+	require.Error(t, err)
+
+	rejectionCause, ok := RejectionCause(err)
+	require.True(t, ok)
+	require.Equal(t, ieLib.CauseRequestRejected, rejectionCause)
+
+	require.Equal(t, uint8(0x01), client.CPFunctionFeatures())
+	require.Equal(t, upFeatures, client.PeerUPFunctionFeatures())
+	require.Nil(t, upf.Received(message.MsgTypeAssociationUpdateRequest)[1].(*message.AssociationUpdateRequest).GracefulReleasePeriod)
+}
+
+func TestAssociationInfo(t *testing.T) {
+	client, upf := newConnectedClient(t)
+
+	require.Zero(t, client.AssociatedAt())
+	require.Empty(t, client.PeerNodeID())
+
+	upf.HandleFunc(message.MsgTypeAssociationSetupRequest, func(req message.Message) message.Message {
+		return message.NewAssociationSetupResponse(req.Sequence(),
+			ieLib.NewNodeID("", "", "upf.5gc.local"),
+			ieLib.NewCause(ieLib.CauseRequestAccepted),
+			ieLib.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()),
+		)
+	})
+
+	before := time.Now()
+	require.NoError(t, client.SetupAssociation())
+
+	require.Equal(t, "upf.5gc.local", client.PeerNodeID())
+	require.False(t, client.AssociatedAt().Before(before))
+	require.False(t, client.AssociatedAt().After(time.Now()))
+}
+
+func TestConnectN4WithConn(t *testing.T) {
+	upf, err := fakeupf.New()
+	require.NoError(t, err)
+	t.Cleanup(upf.Close)
+
+	upfAddr, err := net.ResolveUDPAddr("udp", upf.Addr())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		dial       func() (*net.UDPConn, error)
+		remoteAddr string
+	}{
+		{
+			name:       "unconnected socket",
+			dial:       func() (*net.UDPConn, error) { return net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}) },
+			remoteAddr: upf.Addr(),
+		},
+		{
+			name: "connected socket",
+			dial: func() (*net.UDPConn, error) { return net.DialUDP("udp", nil, upfAddr) },
+		},
+	}
+
+	for _, tt := range tests {
+		dial, remoteAddr := tt.dial, tt.remoteAddr
+
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := dial()
+			require.NoError(t, err)
+
+			client := NewPFCPClient("127.0.0.1")
+			require.NoError(t, client.ConnectN4WithConn(conn, remoteAddr))
+			t.Cleanup(client.DisconnectN4)
+
+			require.NoError(t, client.SetupAssociation())
+			require.True(t, client.IsAssociationAlive())
+
+			// messages are exchanged over the injected connection
+			require.Equal(t, conn.LocalAddr().String(), upf.PeerAddr())
+		})
+	}
+
+	t.Run("missing remote address", func(t *testing.T) {
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+
+		require.Error(t, NewPFCPClient("127.0.0.1").ConnectN4WithConn(conn, ""))
+	})
+}
+
+func TestReleaseAssociation(t *testing.T) {
+	t.Run("release handshake", func(t *testing.T) {
+		client, upf := newAssociatedClient(t)
+
+		cause, err := client.ReleaseAssociation()
+		require.NoError(t, err)
+		require.Equal(t, ieLib.CauseRequestAccepted, cause)
+		require.False(t, client.IsAssociationAlive())
+
+		reqs := upf.Received(message.MsgTypeAssociationReleaseRequest)
+		require.Len(t, reqs, 1)
+
+		nodeID, err := reqs[0].(*message.AssociationReleaseRequest).NodeID.NodeID()
+		require.NoError(t, err)
+		require.Equal(t, "127.0.0.1", nodeID)
+	})
+
+	t.Run("rejected by peer", func(t *testing.T) {
+		client, upf := newAssociatedClient(t)
+		upf.HandleFunc(message.MsgTypeAssociationReleaseRequest, func(req message.Message) message.Message {
+			return message.NewAssociationReleaseResponse(req.Sequence(), upf.NodeID(), ieLib.NewCause(ieLib.CauseRequestRejected))
+		})
+
+		cause, err := client.ReleaseAssociation()
+		require.Error(t, err)
+		require.Equal(t, ieLib.CauseRequestRejected, cause)
+		require.True(t, client.IsAssociationAlive())
+	})
+
+	t.Run("fallback when peer does not answer", func(t *testing.T) {
+		client, upf := newAssociatedClient(t)
+		client.SetPFCPResponseTimeout(100 * time.Millisecond)
+		upf.HandleFunc(message.MsgTypeAssociationReleaseRequest, func(req message.Message) message.Message {
+			return nil
+		})
+
+		cause, err := client.ReleaseAssociation()
+		require.NoError(t, err)
+		require.Zero(t, cause)
+		require.False(t, client.IsAssociationAlive())
+	})
+
+	t.Run("request not sent", func(t *testing.T) {
+		client, _ := newAssociatedClient(t)
+		require.NoError(t, client.conn.Close())
+
+		_, err := client.ReleaseAssociation()
+		require.ErrorIs(t, err, net.ErrClosed)
+		require.True(t, client.IsAssociationAlive())
+	})
+
+	t.Run("no active association", func(t *testing.T) {
+		client, _ := newConnectedClient(t)
+
+		_, err := client.ReleaseAssociation()
+		require.Error(t, err)
+	})
+}
+
+func TestIPv6LocalAddress(t *testing.T) {
+	upf, err := fakeupf.New()
+	require.NoError(t, err)
+
+	client := NewPFCPClient("2001:db8::10")
+	require.NoError(t, client.ConnectN4(upf.Addr()))
+
+	t.Cleanup(func() {
+		client.DisconnectN4()
+		upf.Close()
+	})
+
+	require.NoError(t, client.SetupAssociation())
+
+	_, err = client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+
+	assocReq := upf.Received(message.MsgTypeAssociationSetupRequest)[0].(*message.AssociationSetupRequest)
+	require.Equal(t, uint8(ieLib.NodeIDIPv6Address), assocReq.NodeID.Payload[0])
+
+	nodeID, err := assocReq.NodeID.NodeID()
+	require.NoError(t, err)
+	require.Equal(t, "2001:db8::10", nodeID)
+
+	estReq := upf.Received(message.MsgTypeSessionEstablishmentRequest)[0].(*message.SessionEstablishmentRequest)
+	fseid, err := estReq.CPFSEID.FSEID()
+	require.NoError(t, err)
+	require.True(t, fseid.HasIPv6())
+	require.False(t, fseid.HasIPv4())
+}
+
+func TestRetransmission(t *testing.T) {
+	t.Run("first attempt dropped", func(t *testing.T) {
+		client, upf := newConnectedClient(t)
+		client.SetRetransmission(2, 100*time.Millisecond)
+		acceptAssociationAfter(upf, 1)
+
+		require.NoError(t, client.SetupAssociation())
+
+		received := upf.Received(message.MsgTypeAssociationSetupRequest)
+		require.Len(t, received, 2)
+		// retransmissions carry the same sequence number
+		require.Equal(t, received[0].Sequence(), received[1].Sequence())
+	})
+
+	t.Run("retransmissions exhausted", func(t *testing.T) {
+		client, upf := newConnectedClient(t)
+		client.SetRetransmission(2, 50*time.Millisecond)
+		acceptAssociationAfter(upf, 3)
+
+		require.Error(t, client.SetupAssociation())
+		require.Len(t, upf.Received(message.MsgTypeAssociationSetupRequest), 3)
+	})
+
+	t.Run("duplicate responses ignored", func(t *testing.T) {
+		client, upf := newAssociatedClient(t)
+		client.SetRetransmission(1, 100*time.Millisecond)
+
+		var attempts int32
+
+		// the response to the first attempt is late, hence both attempts are answered
+		upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				time.Sleep(150 * time.Millisecond)
+			}
+
+			return message.NewSessionEstablishmentResponse(0, 0, req.SEID(), req.Sequence(), 0,
+				upf.NodeID(),
+				ieLib.NewCause(ieLib.CauseRequestAccepted),
+				ieLib.NewFSEID(uint64(req.Sequence()), net.ParseIP("127.0.0.1"), nil),
+			)
+		})
+
+		_, err := client.EstablishSession(nil, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, upf.Received(message.MsgTypeSessionEstablishmentRequest), 2)
+
+		// the duplicate response must not hold up the following exchanges
+		client.SetRetransmission(0, 0)
+		client.SetPFCPResponseTimeout(time.Second)
+
+		_, err = client.EstablishSession(nil, nil, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestOperationTimeout(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+
+	// establishment responses are late, the other ones are not
+	upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+		time.Sleep(200 * time.Millisecond)
+
+		return message.NewSessionEstablishmentResponse(0, 0, req.SEID(), req.Sequence(), 0,
+			upf.NodeID(),
+			ieLib.NewCause(ieLib.CauseRequestAccepted),
+			ieLib.NewFSEID(uint64(req.Sequence()), net.ParseIP("127.0.0.1"), nil),
+		)
+	})
+
+	t.Run("short timeout", func(t *testing.T) {
+		client.SetOperationTimeout(OperationEstablishment, 50*time.Millisecond)
+
+		start := time.Now()
+		_, err := client.EstablishSession(nil, nil, nil)
+		require.Error(t, err)
+		require.True(t, isTimeoutExpired(err))
+		require.Less(t, int64(time.Since(start)), int64(200*time.Millisecond))
+	})
+
+	t.Run("long timeout", func(t *testing.T) {
+		client.SetOperationTimeout(OperationEstablishment, time.Second)
+
+		_, err := client.EstablishSession(nil, nil, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("earlier context deadline", func(t *testing.T) {
+		client.SetOperationTimeout(OperationEstablishment, time.Second)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := client.EstablishSessionWithContext(ctx, nil, nil, nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("other operations unaffected", func(t *testing.T) {
+		client.SetOperationTimeout(OperationEstablishment, 50*time.Millisecond)
+		require.Equal(t, 50*time.Millisecond, client.OperationTimeout(OperationEstablishment))
+		require.Equal(t, DefaultResponseTimeout, client.OperationTimeout(OperationDeletion))
+
+		client.SetOperationTimeout(OperationEstablishment, 0)
+		require.Equal(t, DefaultResponseTimeout, client.OperationTimeout(OperationEstablishment))
+	})
+}
+
+func TestUnexpectedResponseType(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+
+	upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+		return message.NewSessionModificationResponse(0, 0, req.SEID(), req.Sequence(), 0,
+			ieLib.NewCause(ieLib.CauseRequestAccepted),
+		)
+	})
+
+	_, err := client.EstablishSession(nil, nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected response type Session Modification Response")
+}
+
+func TestAllocatedFTEID(t *testing.T) {
+	client, _ := newAssociatedClient(t)
+
+	pdrs := []*ieLib.IE{
+		// the peer is asked to allocate the F-TEID
+		ieLib.NewCreatePDR(
+			ieLib.NewPDRID(1),
+			ieLib.NewPDI(ieLib.NewSourceInterface(ieLib.SrcInterfaceAccess), ieLib.NewFTEID(0x05, 0, nil, nil, 0)),
+		),
+		ieLib.NewCreatePDR(
+			ieLib.NewPDRID(2),
+			ieLib.NewPDI(ieLib.NewSourceInterface(ieLib.SrcInterfaceAccess), ieLib.NewFTEID(0x01, 100, net.ParseIP("10.0.0.1"), nil, 0)),
+		),
+	}
+
+	sess, err := client.EstablishSession(pdrs, nil, nil)
+	require.NoError(t, err)
+
+	fteid, ok := sess.AllocatedFTEID(1)
+	require.True(t, ok)
+	require.NotZero(t, fteid.TEID)
+	require.True(t, fteid.HasIPv4())
+
+	_, ok = sess.AllocatedFTEID(2)
+	require.False(t, ok)
+}
+
+func TestOverlappingRequests(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+
+	const count = 10
+
+	var (
+		lock    sync.Mutex
+		pending []message.Message
+	)
+
+	// hold the requests, then answer all of them in reverse order
+	upf.HandleFunc(message.MsgTypeSessionEstablishmentRequest, func(req message.Message) message.Message {
+		lock.Lock()
+		defer lock.Unlock()
+
+		pending = append(pending, req)
+		if len(pending) < count {
+			return nil
+		}
+
+		for i := len(pending) - 1; i > 0; i-- {
+			require.NoError(t, upf.Send(newEstablishmentResponse(t, upf, pending[i])))
+		}
+
+		return newEstablishmentResponse(t, upf, pending[0])
+	})
+
+	sessions := make(chan *PFCPSession, count)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sess, err := client.EstablishSession(nil, nil, nil)
+			require.NoError(t, err)
+
+			sessions <- sess
+		}()
+	}
+
+	wg.Wait()
+	close(sessions)
+
+	require.Len(t, sessions, count)
+
+	// each request got its own response
+	for sess := range sessions {
+		require.Equal(t, sess.LocalSEID()*100, sess.PeerSEID())
+	}
+}
+
+// newEstablishmentResponse accepts req, allocating as UP F-SEID the CP F-SEID of req multiplied by 100.
+func newEstablishmentResponse(t *testing.T, upf *fakeupf.FakeUPF, req message.Message) message.Message {
+	fseid, err := req.(*message.SessionEstablishmentRequest).CPFSEID.FSEID()
+	require.NoError(t, err)
+
+	return message.NewSessionEstablishmentResponse(0, 0, fseid.SEID, req.Sequence(), 0,
+		upf.NodeID(),
+		ieLib.NewCause(ieLib.CauseRequestAccepted),
+		ieLib.NewFSEID(fseid.SEID*100, net.ParseIP("127.0.0.1"), nil),
+	)
+}
+
+func TestMessageHooks(t *testing.T) {
+	client, upf := newConnectedClient(t)
+
+	var (
+		lock     sync.Mutex
+		sent     []message.Message
+		received []message.Message
+	)
+
+	client.RegisterSendHook(func(msg message.Message) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		sent = append(sent, msg)
+	})
+	client.RegisterRecvHook(func(msg message.Message) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		received = append(received, msg)
+	})
+
+	require.NoError(t, client.SetupAssociation())
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	require.Len(t, sent, 1)
+	require.Len(t, received, 1)
+
+	req, ok := sent[0].(*message.AssociationSetupRequest)
+	require.True(t, ok)
+
+	nodeID, err := req.NodeID.NodeID()
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1", nodeID)
+
+	resp, ok := received[0].(*message.AssociationSetupResponse)
+	require.True(t, ok)
+	require.Equal(t, req.Sequence(), resp.Sequence())
+
+	expectedNodeID, err := upf.NodeID().NodeID()
+	require.NoError(t, err)
+
+	nodeID, err = resp.NodeID.NodeID()
+	require.NoError(t, err)
+	require.Equal(t, expectedNodeID, nodeID)
+}
+
+func TestDeleteSessionSet(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+
+	client.SetCSID(1)
+
+	first, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint16(1), first.CSID())
+
+	client.SetCSID(2)
+
+	other, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+
+	client.SetCSID(1)
+
+	second, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+
+	received := upf.Received(message.MsgTypeSessionEstablishmentRequest)
+	require.Len(t, received, 3)
+
+	csids, err := received[0].(*message.SessionEstablishmentRequest).FQCSID.CSIDs()
+	require.NoError(t, err)
+	require.Equal(t, []uint16{1}, csids)
+
+	deleted, err := client.DeleteSessionSet(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []*PFCPSession{first, second}, deleted)
+	require.Equal(t, []*PFCPSession{other}, client.getSessions())
+
+	requests := upf.Received(message.MsgTypeSessionSetDeletionRequest)
+	require.Len(t, requests, 1)
+
+	csids, err = requests[0].(*message.SessionSetDeletionRequest).FQCSID.CSIDs()
+	require.NoError(t, err)
+	require.Equal(t, []uint16{1}, csids)
+
+	t.Run("rejected", func(t *testing.T) {
+		upf.HandleFunc(message.MsgTypeSessionSetDeletionRequest, func(req message.Message) message.Message {
+			return message.NewSessionSetDeletionResponse(req.Sequence(), upf.NodeID(), ieLib.NewCause(ieLib.CauseRequestRejected), nil)
+		})
+
+		_, err := client.DeleteSessionSet(context.Background(), 2)
+		require.Error(t, err)
+
+		// the sessions are kept, to be deleted one by one
+		require.Equal(t, []*PFCPSession{other}, client.getSessions())
+	})
+}
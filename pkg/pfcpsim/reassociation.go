@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// MaxReassociationBackoff caps the time waited between two automatic re-association attempts.
+const MaxReassociationBackoff = time.Minute
+
+// ReassociationAttempt notifies an attempt to set up the association again after the N4 path failed.
+type ReassociationAttempt struct {
+	// Attempt is the number of the attempt since the N4 path failed, starting from 1.
+	Attempt int
+	// Err is the reason the attempt failed, nil if the association was set up again.
+	Err error
+	// Backoff is the time waited before the next attempt, 0 if the association was set up again.
+	Backoff time.Duration
+}
+
+// SetAutoReassociation makes the client set up the association again once the N4 path fails, either because
+// the peer does not answer the heartbeats or because of an error of the N4 socket. The first attempt is made after
+// initialBackoff, which doubles after every failure up to MaxReassociationBackoff. Attempts go on until the
+// association is set up, the N4 path recovers, the association is released or the client is disconnected.
+// An initialBackoff of 0 disables the automatic re-association, which is the default.
+func (c *PFCPClient) SetAutoReassociation(initialBackoff time.Duration) {
+	atomic.StoreInt64(&c.reassociationBackoff, int64(initialBackoff))
+}
+
+// ReassociationAttempts returns a channel notifying the automatic re-association attempts.
+// Notifications are dropped if the channel buffer is full.
+func (c *PFCPClient) ReassociationAttempts() <-chan ReassociationAttempt {
+	return c.reassociationsChan
+}
+
+// startReassociation starts setting up the association again in background, if enabled and not in progress already.
+func (c *PFCPClient) startReassociation() {
+	backoff := time.Duration(atomic.LoadInt64(&c.reassociationBackoff))
+	if backoff == 0 || !atomic.CompareAndSwapInt32(&c.reassociating, 0, 1) {
+		return
+	}
+
+	ctx, cancelFunc := context.WithCancel(c.ctx)
+
+	c.aliveLock.Lock()
+	c.cancelReassociation = cancelFunc
+	c.aliveLock.Unlock()
+
+	go c.reassociate(ctx, backoff)
+}
+
+// stopReassociation stops the automatic re-association in progress, if any.
+func (c *PFCPClient) stopReassociation() {
+	c.aliveLock.Lock()
+	defer c.aliveLock.Unlock()
+
+	if c.cancelReassociation != nil {
+		c.cancelReassociation()
+	}
+}
+
+// reassociate tries to set up the association every backoff, doubling it after every failure,
+// until it succeeds, the association is alive again or ctx is done.
+func (c *PFCPClient) reassociate(ctx context.Context, backoff time.Duration) {
+	defer atomic.StoreInt32(&c.reassociating, 0)
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		// the N4 path recovered meanwhile
+		if c.IsAssociationAlive() {
+			return
+		}
+
+		err := c.setupAssociation(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if backoff *= 2; backoff > MaxReassociationBackoff {
+			backoff = MaxReassociationBackoff
+		}
+
+		event := ReassociationAttempt{Attempt: attempt, Err: err, Backoff: backoff}
+		if err == nil {
+			event.Backoff = 0
+		}
+
+		select {
+		case c.reassociationsChan <- event:
+		default:
+			// Nobody is consuming re-association attempts. Drop it rather than delaying the next attempt.
+		}
+
+		if err == nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+func TestAutoReassociation(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+	client.SetPFCPResponseTimeout(50 * time.Millisecond)
+	client.SetAutoReassociation(10 * time.Millisecond)
+
+	// the peer drops out: it answers neither heartbeats nor the first re-association attempt
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message {
+		return nil
+	})
+
+	var setups int32
+
+	upf.HandleFunc(message.MsgTypeAssociationSetupRequest, func(req message.Message) message.Message {
+		if atomic.AddInt32(&setups, 1) == 1 {
+			return nil
+		}
+
+		return message.NewAssociationSetupResponse(req.Sequence(),
+			upf.NodeID(),
+			ieLib.NewCause(ieLib.CauseRequestAccepted),
+			ieLib.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()),
+		)
+	})
+
+	require.Error(t, client.SendAndRecvHeartbeat())
+	require.False(t, client.IsN4PathUp())
+
+	var attempt ReassociationAttempt
+
+	select {
+	case attempt = <-client.ReassociationAttempts():
+	case <-time.After(time.Second):
+		require.Fail(t, "first re-association attempt not notified")
+	}
+
+	require.Equal(t, 1, attempt.Attempt)
+	require.Error(t, attempt.Err)
+	require.Equal(t, 20*time.Millisecond, attempt.Backoff)
+	require.False(t, client.IsAssociationAlive())
+
+	select {
+	case attempt = <-client.ReassociationAttempts():
+	case <-time.After(time.Second):
+		require.Fail(t, "second re-association attempt not notified")
+	}
+
+	require.Equal(t, 2, attempt.Attempt)
+	require.NoError(t, attempt.Err)
+	require.Zero(t, attempt.Backoff)
+	require.True(t, client.IsAssociationAlive())
+	require.True(t, client.IsN4PathUp())
+
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message {
+		return message.NewHeartbeatResponse(req.Sequence(), ieLib.NewRecoveryTimeStamp(upf.RecoveryTimeStamp()))
+	})
+	require.NoError(t, client.SendAndRecvHeartbeat())
+	require.Len(t, upf.Received(message.MsgTypeAssociationSetupRequest), 3)
+	require.Empty(t, client.PeerRestarts())
+}
+
+func TestAutoReassociationDisabled(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+	client.SetPFCPResponseTimeout(50 * time.Millisecond)
+
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message {
+		return nil
+	})
+
+	require.Error(t, client.SendAndRecvHeartbeat())
+	require.False(t, client.IsN4PathUp())
+
+	time.Sleep(100 * time.Millisecond)
+
+	require.Empty(t, client.ReassociationAttempts())
+	require.Len(t, upf.Received(message.MsgTypeAssociationSetupRequest), 1)
+}
+
+func TestReassociationStopsOnDisconnect(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+	client.SetPFCPResponseTimeout(50 * time.Millisecond)
+	client.SetAutoReassociation(100 * time.Millisecond)
+
+	upf.HandleFunc(message.MsgTypeHeartbeatRequest, func(req message.Message) message.Message {
+		return nil
+	})
+
+	require.Error(t, client.SendAndRecvHeartbeat())
+
+	// the re-association in progress is stopped before its first attempt
+	client.DisconnectN4()
+
+	time.Sleep(200 * time.Millisecond)
+
+	require.Empty(t, client.ReassociationAttempts())
+	require.Len(t, upf.Received(message.MsgTypeAssociationSetupRequest), 1)
+}
+
+func TestN4SocketFailure(t *testing.T) {
+	client, upf := newAssociatedClient(t)
+	client.SetPFCPResponseTimeout(50 * time.Millisecond)
+	client.SetMaxMissedHeartbeats(10)
+
+	sess, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+
+	// the peer is not listening anymore: the requests are answered with ICMP port unreachable
+	upf.Close()
+
+	require.Eventually(t, func() bool {
+		_ = client.SendAndRecvHeartbeat()
+		return !client.IsN4PathUp()
+	}, time.Second, 10*time.Millisecond)
+
+	require.False(t, client.IsAssociationAlive())
+
+	event := <-client.N4PathEvents()
+	require.False(t, event.Up)
+	require.Error(t, event.Err)
+	require.Equal(t, []*PFCPSession{sess}, event.Sessions)
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"time"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// PeerRestart describes a restart of the peer, detected through an increase of its Recovery Time Stamp.
+type PeerRestart struct {
+	PreviousRecoveryTimeStamp time.Time
+	RecoveryTimeStamp         time.Time
+	// StaleSessions are the sessions established before the restart, which the peer no longer knows.
+	StaleSessions []*PFCPSession
+}
+
+// RecoveryTimeStamp returns the Recovery Time Stamp advertised by the client.
+func (c *PFCPClient) RecoveryTimeStamp() time.Time {
+	c.recoveryLock.Lock()
+	defer c.recoveryLock.Unlock()
+
+	return c.recoveryTimeStamp
+}
+
+// SetRecoveryTimeStamp overrides the Recovery Time Stamp advertised by the client, e.g. to simulate a restart.
+// It is sent from the next Association Setup Request or Heartbeat on: a peer receiving a later value than
+// the previous one considers that the client restarted, and that the sessions established so far are lost.
+// The Recovery Time Stamp has a resolution of one second.
+func (c *PFCPClient) SetRecoveryTimeStamp(ts time.Time) {
+	c.recoveryLock.Lock()
+	defer c.recoveryLock.Unlock()
+
+	c.recoveryTimeStamp = ts
+}
+
+// PeerRecoveryTimeStamp returns the last Recovery Time Stamp received from the peer.
+// The zero time is returned if the peer has not advertised one yet.
+func (c *PFCPClient) PeerRecoveryTimeStamp() time.Time {
+	c.recoveryLock.Lock()
+	defer c.recoveryLock.Unlock()
+
+	return c.peerRecoveryTimeStamp
+}
+
+// PeerRestarts returns a channel notifying the restarts of the peer.
+// Notifications are dropped if the channel buffer is full.
+func (c *PFCPClient) PeerRestarts() <-chan PeerRestart {
+	return c.restartsChan
+}
+
+// updatePeerRecoveryTimeStamp stores the Recovery Time Stamp received from the peer.
+// If it is later than the one previously stored, the peer restarted: the association is
+// marked inactive, all the known sessions are marked stale and a PeerRestart is notified.
+// Returns true if a restart was detected.
+func (c *PFCPClient) updatePeerRecoveryTimeStamp(tsIE *ieLib.IE) bool {
+	if tsIE == nil {
+		return false
+	}
+
+	ts, err := tsIE.RecoveryTimeStamp()
+	if err != nil {
+		return false
+	}
+
+	c.recoveryLock.Lock()
+	previous := c.peerRecoveryTimeStamp
+	c.peerRecoveryTimeStamp = ts
+	c.recoveryLock.Unlock()
+
+	if previous.IsZero() || !ts.After(previous) {
+		return false
+	}
+
+	c.setAssociationStatus(false)
+
+	restart := PeerRestart{
+		PreviousRecoveryTimeStamp: previous,
+		RecoveryTimeStamp:         ts,
+		StaleSessions:             c.invalidateSessions(),
+	}
+
+	select {
+	case c.restartsChan <- restart:
+	default:
+		// Nobody is consuming restart notifications. Drop it rather than blocking the caller.
+	}
+
+	return true
+}
+
+// invalidateSessions marks all the known sessions as stale and forgets them.
+func (c *PFCPClient) invalidateSessions() []*PFCPSession {
+	c.sessionsLock.Lock()
+	defer c.sessionsLock.Unlock()
+
+	stale := make([]*PFCPSession, 0, len(c.sessions))
+
+	for seid, sess := range c.sessions {
+		sess.markStale()
+		stale = append(stale, sess)
+
+		delete(c.sessions, seid)
+	}
+
+	return stale
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// SEIDAllocation selects how the local SEIDs of the sessions established by EstablishSession are allocated.
+type SEIDAllocation int32
+
+const (
+	// SEIDAllocationSequential allocates increasing SEIDs starting from 1. It is the default
+	SEIDAllocationSequential SEIDAllocation = iota
+	// SEIDAllocationRandom allocates random SEIDs
+	SEIDAllocationRandom
+)
+
+// SetSEIDAllocation sets how the local SEIDs of the sessions are allocated from now on.
+// SEIDs used by active sessions are never allocated again, whatever the allocation.
+func (c *PFCPClient) SetSEIDAllocation(allocation SEIDAllocation) {
+	atomic.StoreInt32((*int32)(&c.seidAllocation), int32(allocation))
+}
+
+// allocateSEID returns a local SEID not used by any active session, according to the SEID allocation.
+func (c *PFCPClient) allocateSEID() uint64 {
+	random := SEIDAllocation(atomic.LoadInt32((*int32)(&c.seidAllocation))) == SEIDAllocationRandom
+
+	c.sessionsLock.Lock()
+	defer c.sessionsLock.Unlock()
+
+	for {
+		var seid uint64
+
+		if random {
+			if c.seidRand == nil {
+				c.seidRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+			}
+
+			seid = c.seidRand.Uint64()
+		} else {
+			seid = c.getNextFSEID()
+		}
+
+		if _, used := c.sessions[seid]; seid != 0 && !used {
+			return seid
+		}
+	}
+}
+
+// isSEIDInUse returns true if an active session uses seid as local SEID.
+func (c *PFCPClient) isSEIDInUse(seid uint64) bool {
+	_, ok := c.getSession(seid)
+
+	return ok
+}
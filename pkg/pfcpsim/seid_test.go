@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+func TestSEIDAllocation(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		allocation SEIDAllocation
+	}{
+		{name: "sequential", allocation: SEIDAllocationSequential},
+		{name: "random", allocation: SEIDAllocationRandom},
+	} {
+		allocation := tt.allocation
+
+		t.Run(tt.name, func(t *testing.T) {
+			client, upf := newAssociatedClient(t)
+			client.SetSEIDAllocation(allocation)
+
+			seids := make(map[uint64]struct{})
+
+			for i := 0; i < 10; i++ {
+				sess, err := client.EstablishSession(nil, nil, nil)
+				require.NoError(t, err)
+				require.NotZero(t, sess.LocalSEID())
+
+				seids[sess.LocalSEID()] = struct{}{}
+			}
+
+			require.Len(t, seids, 10)
+
+			// the peer was told the same SEIDs
+			for _, req := range upf.Received(message.MsgTypeSessionEstablishmentRequest) {
+				fseid, err := req.(*message.SessionEstablishmentRequest).CPFSEID.FSEID()
+				require.NoError(t, err)
+				require.Contains(t, seids, fseid.SEID)
+			}
+		})
+	}
+}
+
+func TestEstablishSessionWithSEID(t *testing.T) {
+	client, _ := newAssociatedClient(t)
+
+	sess, err := client.EstablishSessionWithSEID(context.Background(), 2, ieLib.PDNTypeIPv4, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), sess.LocalSEID())
+
+	_, err = client.EstablishSessionWithSEID(context.Background(), 2, ieLib.PDNTypeIPv4, nil, nil, nil)
+	require.Error(t, err)
+
+	_, err = client.EstablishSessionWithSEID(context.Background(), 0, ieLib.PDNTypeIPv4, nil, nil, nil)
+	require.Error(t, err)
+
+	// sequential allocation skips the SEIDs in use
+	first, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), first.LocalSEID())
+
+	second, err := client.EstablishSession(nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), second.LocalSEID())
+}
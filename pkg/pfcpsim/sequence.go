@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import "sync/atomic"
+
+// maxSequenceNumber is the highest PFCP sequence number, which is 24 bits long.
+const maxSequenceNumber = 1<<24 - 1
+
+// answeredExchangesSize is the number of completed exchanges whose duplicate responses are recognized.
+const answeredExchangesSize = 1024
+
+// sequenceNumberAllocator hands out PFCP sequence numbers in increasing order,
+// wrapping around to 0 after maxSequenceNumber. It is safe for concurrent use.
+type sequenceNumberAllocator struct {
+	last uint32
+}
+
+// next returns the sequence number following the last one allocated.
+func (a *sequenceNumberAllocator) next() uint32 {
+	for {
+		last := atomic.LoadUint32(&a.last)
+		next := (last + 1) & maxSequenceNumber
+
+		if atomic.CompareAndSwapUint32(&a.last, last, next) {
+			return next
+		}
+	}
+}
+
+// reset makes the allocator start again from 1.
+func (a *sequenceNumberAllocator) reset() {
+	atomic.StoreUint32(&a.last, 0)
+}
+
+// sequenceNumberSet keeps the last sequence numbers added to it, up to a maximum size.
+// It is not safe for concurrent use.
+type sequenceNumberSet struct {
+	members map[uint32]struct{}
+	// order keeps the members in insertion order, as a ring buffer
+	order []uint32
+	next  int
+}
+
+func newSequenceNumberSet(size int) *sequenceNumberSet {
+	return &sequenceNumberSet{
+		members: make(map[uint32]struct{}, size),
+		order:   make([]uint32, 0, size),
+	}
+}
+
+// add inserts seq, evicting the oldest member if the set is full.
+func (s *sequenceNumberSet) add(seq uint32) {
+	if _, ok := s.members[seq]; ok {
+		return
+	}
+
+	if len(s.order) < cap(s.order) {
+		s.order = append(s.order, seq)
+	} else {
+		delete(s.members, s.order[s.next])
+		s.order[s.next] = seq
+		s.next = (s.next + 1) % len(s.order)
+	}
+
+	s.members[seq] = struct{}{}
+}
+
+func (s *sequenceNumberSet) contains(seq uint32) bool {
+	_, ok := s.members[seq]
+	return ok
+}
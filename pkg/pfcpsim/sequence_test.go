@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceNumberAllocator(t *testing.T) {
+	t.Run("wraps at 2^24", func(t *testing.T) {
+		allocator := &sequenceNumberAllocator{last: maxSequenceNumber - 1}
+
+		require.Equal(t, uint32(maxSequenceNumber), allocator.next())
+		require.Equal(t, uint32(0), allocator.next())
+		require.Equal(t, uint32(1), allocator.next())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		allocator := &sequenceNumberAllocator{}
+		allocator.next()
+		allocator.next()
+
+		allocator.reset()
+		require.Equal(t, uint32(1), allocator.next())
+	})
+
+	t.Run("unique across concurrent callers", func(t *testing.T) {
+		const (
+			callers          = 8
+			numbersPerCaller = 1000
+		)
+
+		allocator := &sequenceNumberAllocator{}
+		allocated := make(chan uint32, callers*numbersPerCaller)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < numbersPerCaller; j++ {
+					allocated <- allocator.next()
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(allocated)
+
+		seen := make(map[uint32]struct{})
+		for seq := range allocated {
+			seen[seq] = struct{}{}
+		}
+
+		require.Len(t, seen, callers*numbersPerCaller)
+	})
+}
+
+func TestSequenceNumberSet(t *testing.T) {
+	set := newSequenceNumberSet(2)
+
+	set.add(1)
+	set.add(2)
+	require.True(t, set.contains(1))
+	require.True(t, set.contains(2))
+
+	// the oldest member is evicted
+	set.add(3)
+	require.False(t, set.contains(1))
+	require.True(t, set.contains(2))
+	require.True(t, set.contains(3))
+}
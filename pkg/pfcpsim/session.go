@@ -3,7 +3,150 @@
 
 package pfcpsim
 
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
 type PFCPSession struct {
 	localSEID uint64
 	peerSEID  uint64
+
+	// lastActivity holds the time (in Unix nanoseconds) of session establishment
+	// or of the last Session Report Request received for this session.
+	lastActivity int64
+
+	// stale is set to 1 once the peer restarted after the session was established,
+	// meaning that the session context no longer exists on the peer.
+	stale int32
+
+	// hasBAR is set to 1 once a BAR was created for this session.
+	hasBAR int32
+
+	// allocatedFTEIDs keeps the F-TEIDs allocated by the peer, indexed by PDR ID.
+	// It is set at establishment and never modified afterwards.
+	allocatedFTEIDs map[uint16]*ieLib.FTEIDFields
+
+	// csid identifies the PDN connection set the session belongs to, 0 if none.
+	csid uint16
+
+	// pdnType is the value of the PDN Type IE the session was established with.
+	pdnType uint8
+
+	// failedRules keeps the rules reported as failed by the responses accepting the establishment
+	// or the modifications of the session.
+	failedRules     []FailedRule
+	failedRulesLock sync.Mutex
+}
+
+func newPFCPSession(localSEID, peerSEID uint64) *PFCPSession {
+	return &PFCPSession{
+		localSEID:    localSEID,
+		peerSEID:     peerSEID,
+		lastActivity: time.Now().UnixNano(),
+		pdnType:      ieLib.PDNTypeIPv4,
+	}
+}
+
+// RestoreSession makes the client aware of a session established on the peer by a previous client instance,
+// identified by localSEID and peerSEID, without sending any message. hasBAR reports whether a BAR was created
+// for the session. SEIDs allocated afterwards are greater than localSEID.
+func (c *PFCPClient) RestoreSession(localSEID, peerSEID uint64, hasBAR bool) *PFCPSession {
+	for {
+		last := atomic.LoadUint64(&c.lastFSEID)
+		if last >= localSEID || atomic.CompareAndSwapUint64(&c.lastFSEID, last, localSEID) {
+			break
+		}
+	}
+
+	sess := newPFCPSession(localSEID, peerSEID)
+	if hasBAR {
+		sess.markBARCreated()
+	}
+
+	c.insertSession(sess)
+
+	return sess
+}
+
+func (s *PFCPSession) LocalSEID() uint64 {
+	return s.localSEID
+}
+
+func (s *PFCPSession) PeerSEID() uint64 {
+	return s.peerSEID
+}
+
+// PDNType returns the value of the PDN Type IE the session was established with, e.g. ieLib.PDNTypeIPv6.
+func (s *PFCPSession) PDNType() uint8 {
+	return s.pdnType
+}
+
+// AllocatedFTEID returns the F-TEID the peer allocated at establishment for the PDR identified by pdrID,
+// if the PDR asked for it with the CH flag.
+func (s *PFCPSession) AllocatedFTEID(pdrID uint16) (*ieLib.FTEIDFields, bool) {
+	fteid, ok := s.allocatedFTEIDs[pdrID]
+
+	return fteid, ok
+}
+
+// CSID returns the PDN connection set the session was established in, 0 if none.
+func (s *PFCPSession) CSID() uint16 {
+	return s.csid
+}
+
+// FailedRules returns the rules the peer reported as failed, with a Failed Rule ID IE, while accepting the establishment
+// or the modifications of the session. The rules of rejected requests are reported by their errors instead, see RejectedRule.
+func (s *PFCPSession) FailedRules() []FailedRule {
+	s.failedRulesLock.Lock()
+	defer s.failedRulesLock.Unlock()
+
+	return append([]FailedRule(nil), s.failedRules...)
+}
+
+func (s *PFCPSession) addFailedRule(rule *FailedRule) {
+	if rule == nil {
+		return
+	}
+
+	s.failedRulesLock.Lock()
+	defer s.failedRulesLock.Unlock()
+
+	s.failedRules = append(s.failedRules, *rule)
+}
+
+// LastActivity returns the time the session was established or its usage last reported by the peer.
+// Downlink data and error indication reports are not activity.
+func (s *PFCPSession) LastActivity() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&s.lastActivity))
+}
+
+func (s *PFCPSession) markActive() {
+	atomic.StoreInt64(&s.lastActivity, time.Now().UnixNano())
+}
+
+// IsStale reports whether the peer restarted after the session was established.
+// A stale session is no longer known by the peer.
+func (s *PFCPSession) IsStale() bool {
+	return atomic.LoadInt32(&s.stale) == 1
+}
+
+func (s *PFCPSession) markStale() {
+	atomic.StoreInt32(&s.stale, 1)
+}
+
+// HasBAR reports whether a BAR was created for the session, which then must be updated rather than created.
+func (s *PFCPSession) HasBAR() bool {
+	return atomic.LoadInt32(&s.hasBAR) == 1
+}
+
+func (s *PFCPSession) markBARCreated() {
+	atomic.StoreInt32(&s.hasBAR, 1)
+}
+
+func (s *PFCPSession) markBARRemoved() {
+	atomic.StoreInt32(&s.hasBAR, 0)
 }
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"time"
+
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+type barBuilder struct {
+	method IEMethod
+	barID  uint8
+
+	// dlDataNotificationDelay is the delay the peer waits for before notifying the CP of buffered downlink data
+	dlDataNotificationDelay time.Duration
+	// suggestedBufferingPackets is the number of packets the peer is suggested to buffer
+	suggestedBufferingPackets uint8
+
+	isIDSet                      bool
+	isNotificationDelaySet       bool
+	isSuggestedBufferingCountSet bool
+}
+
+// NewBARBuilder returns a barBuilder.
+func NewBARBuilder() *barBuilder {
+	return &barBuilder{}
+}
+
+func (b *barBuilder) WithID(id uint8) *barBuilder {
+	// Used to avoid using 0 as default value. It makes sure that WithID was invoked.
+	b.isIDSet = true
+	b.barID = id
+
+	return b
+}
+
+func (b *barBuilder) WithMethod(method IEMethod) *barBuilder {
+	b.method = method
+	return b
+}
+
+func (b *barBuilder) WithDownlinkDataNotificationDelay(delay time.Duration) *barBuilder {
+	b.isNotificationDelaySet = true
+	b.dlDataNotificationDelay = delay
+
+	return b
+}
+
+func (b *barBuilder) WithSuggestedBufferingPacketsCount(count uint8) *barBuilder {
+	b.isSuggestedBufferingCountSet = true
+	b.suggestedBufferingPackets = count
+
+	return b
+}
+
+func (b *barBuilder) validate() {
+	if !b.isIDSet {
+		panic("Tried to build a BAR without setting the BAR ID")
+	}
+}
+
+// Build returns a Create BAR IE by default, an Update BAR IE (as carried by
+// Session Modification Requests) if the Update method was set, or a Remove BAR IE
+// if the Delete method was set.
+func (b *barBuilder) Build() *ie.IE {
+	b.validate()
+
+	if b.method == Delete {
+		return ie.NewRemoveBAR(ie.NewBARID(b.barID))
+	}
+
+	createFunc := ie.NewCreateBAR
+	if b.method == Update {
+		createFunc = ie.NewUpdateBARWithinSessionModificationRequest
+	}
+
+	bar := createFunc(ie.NewBARID(b.barID))
+
+	if b.isNotificationDelaySet {
+		bar.Add(ie.NewDownlinkDataNotificationDelay(b.dlDataNotificationDelay))
+	}
+
+	if b.isSuggestedBufferingCountSet {
+		bar.Add(ie.NewSuggestedBufferingPacketsCount(b.suggestedBufferingPackets))
+	}
+
+	return bar
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+func TestBARBuilderShouldPanic(t *testing.T) {
+	assert.Panics(t, func() {
+		NewBARBuilder().
+			WithMethod(Create).
+			WithSuggestedBufferingPacketsCount(10).
+			Build()
+	})
+}
+
+func TestBARBuilder(t *testing.T) {
+	type testCase struct {
+		input       *barBuilder
+		expected    *ie.IE
+		description string
+	}
+
+	for _, scenario := range []testCase{
+		{
+			input: NewBARBuilder().
+				WithID(1),
+			expected: ie.NewCreateBAR(
+				ie.NewBARID(1),
+			),
+			description: "Valid Create BAR",
+		},
+		{
+			input: NewBARBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithDownlinkDataNotificationDelay(100 * time.Millisecond).
+				WithSuggestedBufferingPacketsCount(10),
+			expected: ie.NewCreateBAR(
+				ie.NewBARID(1),
+				ie.NewDownlinkDataNotificationDelay(100*time.Millisecond),
+				ie.NewSuggestedBufferingPacketsCount(10),
+			),
+			description: "Valid Create BAR with buffering parameters",
+		},
+		{
+			input: NewBARBuilder().
+				WithID(2).
+				WithMethod(Update).
+				WithSuggestedBufferingPacketsCount(20),
+			expected: ie.NewUpdateBARWithinSessionModificationRequest(
+				ie.NewBARID(2),
+				ie.NewSuggestedBufferingPacketsCount(20),
+			),
+			description: "Valid Update BAR",
+		},
+		{
+			input: NewBARBuilder().
+				WithID(2).
+				WithMethod(Delete).
+				WithSuggestedBufferingPacketsCount(20),
+			expected: ie.NewRemoveBAR(
+				ie.NewBARID(2),
+			),
+			description: "Valid Remove BAR",
+		},
+	} {
+		t.Run(scenario.description, func(t *testing.T) {
+			assert.Equal(t, scenario.expected, scenario.input.Build())
+		})
+	}
+}
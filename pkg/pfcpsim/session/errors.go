@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRule is wrapped by the errors the builders return when the values set don't form a valid rule.
+var ErrInvalidRule = errors.New("invalid rule")
+
+// RuleError is returned by the BuildE methods of the builders when the values set don't form a valid rule.
+// It wraps ErrInvalidRule.
+type RuleError struct {
+	// Rule is the kind of rule being built, e.g. "PDR"
+	Rule string
+	// ID is the ID of the rule, 0 if not set
+	ID uint32
+	// IE is the name of the offending IE, e.g. "FAR ID"
+	IE string
+	// Reason tells what is wrong with the IE
+	Reason string
+}
+
+func newRuleError(rule string, id uint32, offendingIE string, reason string) *RuleError {
+	return &RuleError{
+		Rule:   rule,
+		ID:     id,
+		IE:     offendingIE,
+		Reason: reason,
+	}
+}
+
+func (e *RuleError) Error() string {
+	rule := e.Rule
+	if e.ID != 0 {
+		rule += fmt.Sprintf(" %v", e.ID)
+	}
+
+	return fmt.Sprintf("Tried building %v with invalid %v IE: %v", rule, e.IE, e.Reason)
+}
+
+func (e *RuleError) Unwrap() error {
+	return ErrInvalidRule
+}
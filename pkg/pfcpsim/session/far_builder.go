@@ -19,9 +19,24 @@ type farBuilder struct {
 	dstInterface uint8
 	endmarker    bool
 
+	// plain UDP outer header destination, used instead of a GTP-U tunnel
+	udpDstIP   string
+	udpDstPort uint16
+
+	redirectType   uint8
+	redirectTarget string
+
+	headerEnrichments []*ie.IE
+
+	barID      uint8
+	isBARIDSet bool
+
 	zeroBasedOuterHeader bool
 	isActionSet          bool
 	isInterfaceSet       bool
+
+	// minimalUpdate makes Update FARs carry only the fields that were set
+	minimalUpdate bool
 }
 
 // NewFARBuilder returns a farBuilder.
@@ -51,6 +66,8 @@ func (b *farBuilder) WithAction(action uint8) *farBuilder {
 	return b
 }
 
+// WithEndMarker requests the peer to send end markers on the previous tunnel.
+// It is honored only by Update FARs whose action is ActionForward.
 func (b *farBuilder) WithEndMarker(endmarker bool) *farBuilder {
 	b.endmarker = endmarker
 
@@ -79,28 +96,200 @@ func (b *farBuilder) WithUplinkIP(uplinkIP string) *farBuilder {
 	return b
 }
 
-func (b *farBuilder) validate() {
+// WithOuterHeaderUDP makes the peer encapsulate the traffic in a plain UDP/IP header towards ip and port,
+// without any GTP-U header. It can't be used together with WithDownlinkIP or WithUplinkIP.
+func (b *farBuilder) WithOuterHeaderUDP(ip string, port uint16) *farBuilder {
+	b.udpDstIP = ip
+	b.udpDstPort = port
+
+	return b
+}
+
+// WithRedirect makes the peer redirect the traffic towards target, whose type is one of the RedirectType constants.
+// Redirect FARs usually don't need an outer header creation: do not set uplink nor downlink IPs.
+func (b *farBuilder) WithRedirect(redirectType uint8, target string) *farBuilder {
+	b.redirectType = redirectType
+	b.redirectTarget = target
+
+	return b
+}
+
+// WithHeaderEnrichment makes the peer add the HTTP header name with the given value to the traffic.
+// It can be invoked several times to add several headers. It is honored only by FARs whose action is ActionForward.
+func (b *farBuilder) WithHeaderEnrichment(name, value string) *farBuilder {
+	b.headerEnrichments = append(b.headerEnrichments, ie.NewHeaderEnrichment(ie.HeaderTypeHTTP, name, value))
+	return b
+}
+
+// WithBARID links the FAR to the BAR controlling how the peer buffers the traffic.
+// It is honored only by FARs whose action includes ActionBuffer.
+func (b *farBuilder) WithBARID(id uint8) *farBuilder {
+	b.isBARIDSet = true
+	b.barID = id
+
+	return b
+}
+
+// AsMinimalUpdate makes the Update FAR carry only the fields that were set, e.g. just the Apply Action to make
+// the peer start buffering, instead of rebuilding all the forwarding parameters. The action and the destination
+// interface are then optional, and the Update Forwarding Parameters IE is omitted if none of its fields is set.
+// It can be used only with the Update method.
+func (b *farBuilder) AsMinimalUpdate() *farBuilder {
+	b.minimalUpdate = true
+	return b
+}
+
+// validate returns a RuleError naming the offending IE if the values set don't form a valid FAR.
+func (b *farBuilder) validate() error {
+	invalid := func(offendingIE string, reason string) error {
+		return newRuleError("FAR", b.farID, offendingIE, reason)
+	}
+
 	if b.farID == 0 {
-		panic("Tried building FAR without setting FAR ID")
+		return invalid("FAR ID", "not set")
+	}
+
+	if b.minimalUpdate && b.method != Update {
+		return invalid("Update FAR", "minimal update without the Update method")
+	}
+
+	if b.udpDstIP != "" && (b.downlinkIP != "" || b.uplinkIP != "" || b.zeroBasedOuterHeader) {
+		return invalid("Outer Header Creation", "both UDP and GTP-U outer headers set")
+	}
+
+	if b.minimalUpdate {
+		return nil
 	}
 
 	if !b.isInterfaceSet {
-		panic("Tried building FAR without setting a destination interface")
+		return invalid("Destination Interface", "not set")
 	}
 
 	if b.applyAction == ActionDrop|ActionForward {
-		panic("Tried building FAR with actions' ActionDrop and ActionForward flags")
+		return invalid("Apply Action", "both DROP and FORW flags set")
 	}
 
 	if !b.isActionSet {
-		panic("Tried building FAR without setting an action")
+		return invalid("Apply Action", "not set")
+	}
+
+	return nil
+}
+
+// newOuterHeaderCreation returns the Outer Header Creation IE of the FAR, nil if the FAR has no outer header.
+func (b *farBuilder) newOuterHeaderCreation() *ie.IE {
+	switch {
+	case b.udpDstIP != "":
+		return newUDPOuterHeaderCreation(b.udpDstIP, b.udpDstPort)
+	case b.zeroBasedOuterHeader:
+		return ie.NewOuterHeaderCreation(S_TAG, 0, "0.0.0.0", "", 0, 0, 0)
+	case b.downlinkIP != "": //TODO revisit code and improve its structure
+		// TEID and DownlinkIP are provided
+		return newOuterHeaderCreation(b.teid, b.downlinkIP)
+	case b.uplinkIP != "":
+		return newOuterHeaderCreation(b.teid, b.uplinkIP)
 	}
+
+	return nil
+}
+
+// allows returns true if the action of the FAR includes action, or if the action is not set by a minimal update.
+func (b *farBuilder) allows(action uint8) bool {
+	if b.minimalUpdate && !b.isActionSet {
+		return true
+	}
+
+	return b.applyAction&action != 0
+}
+
+// buildMinimalUpdate returns an Update FAR carrying only the fields that were set.
+func (b *farBuilder) buildMinimalUpdate() *ie.IE {
+	far := ie.NewUpdateFAR(ie.NewFARID(b.farID))
+
+	if b.isActionSet {
+		far.Add(ie.NewApplyAction(b.applyAction))
+	}
+
+	var params []*ie.IE
+
+	if b.isInterfaceSet {
+		params = append(params, ie.NewDestinationInterface(b.dstInterface))
+	}
+
+	if ohc := b.newOuterHeaderCreation(); ohc != nil {
+		params = append(params, ohc)
+	}
+
+	if b.endmarker && b.allows(ActionForward) {
+		params = append(params, ie.NewPFCPSMReqFlags(7))
+	}
+
+	if b.redirectTarget != "" {
+		params = append(params, ie.NewRedirectInformation(b.redirectType, b.redirectTarget))
+	}
+
+	if b.allows(ActionForward) {
+		params = append(params, b.headerEnrichments...)
+	}
+
+	if len(params) > 0 {
+		far.Add(ie.NewUpdateForwardingParameters(params...))
+	}
+
+	if b.isBARIDSet && b.allows(ActionBuffer) {
+		far.Add(ie.NewBARID(b.barID))
+	}
+
+	return far
+}
+
+// newOuterHeaderCreation returns a GTP-U Outer Header Creation IE towards tunnelDst,
+// which can be either an IPv4 or an IPv6 address.
+func newOuterHeaderCreation(teid uint32, tunnelDst string) *ie.IE {
+	if isIPv6(tunnelDst) {
+		return ie.NewOuterHeaderCreation(GTPU_UDP_IPV6, teid, "", tunnelDst, 0, 0, 0)
+	}
+
+	return ie.NewOuterHeaderCreation(S_TAG, teid, tunnelDst, "", 0, 0, 0)
+}
+
+// newUDPOuterHeaderCreation returns a plain UDP Outer Header Creation IE towards dst and port,
+// which can be either an IPv4 or an IPv6 address.
+func newUDPOuterHeaderCreation(dst string, port uint16) *ie.IE {
+	if isIPv6(dst) {
+		return ie.NewOuterHeaderCreation(UDP_IPV6, 0, "", dst, port, 0, 0)
+	}
+
+	return ie.NewOuterHeaderCreation(UDP_IPV4, 0, dst, "", port, 0, 0)
 }
 
 // BuildFAR returns a downlinkFAR if MarkAsDownlink was invoked.
 // Returns an UplinkFAR if MarkAsUplink was invoked.
+// Returns an Update FAR with only the fields set if AsMinimalUpdate was invoked.
+// Panics if the values set don't form a valid FAR, see BuildE.
 func (b *farBuilder) BuildFAR() *ie.IE {
-	b.validate()
+	far, err := b.BuildE()
+	if err != nil {
+		panic(err)
+	}
+
+	return far
+}
+
+// BuildE returns the FAR like BuildFAR, or a RuleError naming the offending IE
+// if the values set don't form a valid FAR.
+func (b *farBuilder) BuildE() (*ie.IE, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	return b.build(), nil
+}
+
+func (b *farBuilder) build() *ie.IE {
+	if b.minimalUpdate {
+		return b.buildMinimalUpdate()
+	}
 
 	fwdParams := ie.NewForwardingParameters(
 		ie.NewDestinationInterface(b.dstInterface),
@@ -109,7 +298,8 @@ func (b *farBuilder) BuildFAR() *ie.IE {
 	createFunc := ie.NewCreateFAR
 	if b.method == Update {
 		createFunc = ie.NewUpdateFAR
-		if b.endmarker {
+		// end markers are sent when switching the tunnel of forwarded traffic, never while buffering
+		if b.endmarker && b.applyAction&ActionForward != 0 {
 			fwdParams = ie.NewUpdateForwardingParameters(
 				ie.NewDestinationInterface(b.dstInterface),
 				ie.NewPFCPSMReqFlags(7), // add the end marker
@@ -122,13 +312,16 @@ func (b *farBuilder) BuildFAR() *ie.IE {
 
 	}
 
-	if b.zeroBasedOuterHeader {
-		fwdParams.Add(ie.NewOuterHeaderCreation(S_TAG, 0, "0.0.0.0", "", 0, 0, 0))
-	} else if b.downlinkIP != "" { //TODO revisit code and improve its structure
-		// TEID and DownlinkIP are provided
-		fwdParams.Add(ie.NewOuterHeaderCreation(S_TAG, b.teid, b.downlinkIP, "", 0, 0, 0))
-	} else if b.uplinkIP != "" {
-		fwdParams.Add(ie.NewOuterHeaderCreation(S_TAG, b.teid, b.uplinkIP, "", 0, 0, 0))
+	if ohc := b.newOuterHeaderCreation(); ohc != nil {
+		fwdParams.Add(ohc)
+	}
+
+	if b.redirectTarget != "" {
+		fwdParams.Add(ie.NewRedirectInformation(b.redirectType, b.redirectTarget))
+	}
+
+	if b.applyAction&ActionForward != 0 {
+		fwdParams.Add(b.headerEnrichments...)
 	}
 
 	far := createFunc(
@@ -137,6 +330,10 @@ func (b *farBuilder) BuildFAR() *ie.IE {
 		fwdParams,
 	)
 
+	if b.isBARIDSet && b.applyAction&ActionBuffer != 0 {
+		far.Add(ie.NewBARID(b.barID))
+	}
+
 	if b.method == Delete {
 		return ie.NewRemoveFAR(far)
 	}
@@ -4,6 +4,7 @@
 package session
 
 import (
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -76,6 +77,40 @@ func TestFARBuilderShouldPanic(t *testing.T) {
 			},
 			description: "Invalid FAR: Providing both forward and drop actions",
 		},
+		{
+			input: NewFARBuilder().WithMethod(Create).
+				WithID(1).
+				WithAction(ActionForward).
+				WithDstInterface(ie.DstInterfaceCore).
+				WithUplinkIP("10.0.0.1").
+				WithOuterHeaderUDP("10.0.0.2", 2152),
+			expected: &farBuilder{
+				farID:          1,
+				method:         Create,
+				applyAction:    ActionForward,
+				isActionSet:    true,
+				dstInterface:   ie.DstInterfaceCore,
+				isInterfaceSet: true,
+				uplinkIP:       "10.0.0.1",
+				udpDstIP:       "10.0.0.2",
+				udpDstPort:     2152,
+			},
+			description: "Invalid FAR: Providing both GTP-U and UDP outer headers",
+		},
+		{
+			input: NewFARBuilder().WithMethod(Create).
+				WithID(1).
+				WithAction(ActionBuffer).
+				AsMinimalUpdate(),
+			expected: &farBuilder{
+				farID:         1,
+				method:        Create,
+				applyAction:   ActionBuffer,
+				isActionSet:   true,
+				minimalUpdate: true,
+			},
+			description: "Invalid FAR: Minimal update without the Update method",
+		},
 	} {
 		t.Run(scenario.description, func(t *testing.T) {
 			assert.Panics(t, func() { scenario.input.BuildFAR() })
@@ -125,6 +160,24 @@ func TestFARBuilder(t *testing.T) {
 			),
 			description: "Valid FAR action with 2 flags",
 		},
+		{
+			input: NewFARBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithAction(ActionForward).
+				WithDstInterface(ie.DstInterfaceAccess).
+				WithTEID(12).
+				WithDownlinkIP("2001:db8::1"),
+			expected: ie.NewCreateFAR(
+				ie.NewFARID(1),
+				ie.NewApplyAction(ActionForward),
+				ie.NewForwardingParameters(
+					ie.NewDestinationInterface(ie.DstInterfaceAccess),
+					ie.NewOuterHeaderCreation(GTPU_UDP_IPV6, 12, "", "2001:db8::1", 0, 0, 0),
+				),
+			),
+			description: "Valid FAR with IPv6 tunnel destination",
+		},
 		{
 			input: NewFARBuilder().
 				WithID(1).
@@ -140,9 +193,383 @@ func TestFARBuilder(t *testing.T) {
 			),
 			description: "Valid FAR actions with 3 flags",
 		},
+		{
+			input: NewFARBuilder().
+				WithID(1).
+				WithMethod(Update).
+				WithAction(ActionForward).
+				WithDstInterface(ie.DstInterfaceAccess).
+				WithTEID(12).
+				WithDownlinkIP("10.0.0.1").
+				WithEndMarker(true),
+			expected: ie.NewUpdateFAR(
+				ie.NewFARID(1),
+				ie.NewApplyAction(ActionForward),
+				ie.NewUpdateForwardingParameters(
+					ie.NewDestinationInterface(ie.DstInterfaceAccess),
+					ie.NewPFCPSMReqFlags(7),
+					ie.NewOuterHeaderCreation(S_TAG, 12, "10.0.0.1", "", 0, 0, 0),
+				),
+			),
+			description: "Valid Update FAR with end marker",
+		},
+		{
+			input: NewFARBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithAction(ActionForward).
+				WithDstInterface(ie.DstInterfaceCore).
+				WithRedirect(RedirectTypeURL, "http://portal.example.com"),
+			expected: ie.NewCreateFAR(
+				ie.NewFARID(1),
+				ie.NewApplyAction(ActionForward),
+				ie.NewForwardingParameters(
+					ie.NewDestinationInterface(ie.DstInterfaceCore),
+					ie.NewRedirectInformation(RedirectTypeURL, "http://portal.example.com"),
+				),
+			),
+			description: "Valid FAR with redirect towards URL",
+		},
+		{
+			input: NewFARBuilder().
+				WithID(1).
+				WithMethod(Update).
+				WithAction(ActionBuffer | ActionNotify).
+				WithDstInterface(ie.DstInterfaceAccess).
+				WithDownlinkIP("10.0.0.1").
+				WithEndMarker(true),
+			expected: ie.NewUpdateFAR(
+				ie.NewFARID(1),
+				ie.NewApplyAction(ActionBuffer|ActionNotify),
+				ie.NewUpdateForwardingParameters(
+					ie.NewDestinationInterface(ie.DstInterfaceAccess),
+					ie.NewOuterHeaderCreation(S_TAG, 0, "10.0.0.1", "", 0, 0, 0),
+				),
+			),
+			description: "End marker ignored by buffering Update FAR",
+		},
+		{
+			input: NewFARBuilder().
+				WithID(1).
+				WithMethod(Update).
+				WithAction(ActionBuffer | ActionNotify).
+				WithDstInterface(ie.DstInterfaceAccess).
+				WithBARID(1),
+			expected: ie.NewUpdateFAR(
+				ie.NewFARID(1),
+				ie.NewApplyAction(ActionBuffer|ActionNotify),
+				ie.NewUpdateForwardingParameters(
+					ie.NewDestinationInterface(ie.DstInterfaceAccess),
+				),
+				ie.NewBARID(1),
+			),
+			description: "Valid buffering Update FAR with BAR ID",
+		},
+		{
+			input: NewFARBuilder().
+				WithID(1).
+				WithAction(ActionForward).
+				WithDstInterface(ie.DstInterfaceAccess).
+				WithBARID(1),
+			expected: ie.NewCreateFAR(
+				ie.NewFARID(1),
+				ie.NewApplyAction(ActionForward),
+				ie.NewForwardingParameters(
+					ie.NewDestinationInterface(ie.DstInterfaceAccess),
+				),
+			),
+			description: "BAR ID ignored by forwarding FAR",
+		},
 	} {
 		t.Run(scenario.description, func(t *testing.T) {
 			require.Equal(t, scenario.expected, scenario.input.BuildFAR())
 		})
 	}
 }
+
+func TestFARBuilderIPv6OuterHeaderCreation(t *testing.T) {
+	far := NewFARBuilder().
+		WithID(1).
+		WithAction(ActionForward).
+		WithDstInterface(ie.DstInterfaceCore).
+		WithTEID(12).
+		WithUplinkIP("2001:db8::1").
+		BuildFAR()
+
+	var ohc *ie.OuterHeaderCreationFields
+
+	for _, child := range far.ChildIEs {
+		if child.Type == ie.ForwardingParameters {
+			var err error
+			ohc, err = child.OuterHeaderCreation()
+			require.NoError(t, err)
+		}
+	}
+
+	require.NotNil(t, ohc)
+
+	require.Equal(t, uint16(GTPU_UDP_IPV6), ohc.OuterHeaderCreationDescription)
+	require.Nil(t, ohc.IPv4Address)
+	require.Equal(t, uint32(12), ohc.TEID)
+	require.True(t, ohc.IPv6Address.Equal(net.ParseIP("2001:db8::1")))
+}
+
+func TestFARBuilderUDPOuterHeaderCreation(t *testing.T) {
+	outerHeader := func(far *ie.IE) *ie.OuterHeaderCreationFields {
+		for _, child := range far.ChildIEs {
+			if child.Type == ie.ForwardingParameters {
+				ohc, err := child.OuterHeaderCreation()
+				require.NoError(t, err)
+
+				return ohc
+			}
+		}
+
+		return nil
+	}
+
+	gtpu := outerHeader(NewFARBuilder().
+		WithID(1).
+		WithAction(ActionForward).
+		WithDstInterface(ie.DstInterfaceCore).
+		WithTEID(12).
+		WithUplinkIP("10.0.0.1").
+		BuildFAR())
+	require.NotNil(t, gtpu)
+
+	udp := outerHeader(NewFARBuilder().
+		WithID(1).
+		WithAction(ActionForward).
+		WithDstInterface(ie.DstInterfaceCore).
+		WithOuterHeaderUDP("10.0.0.1", 8080).
+		BuildFAR())
+	require.NotNil(t, udp)
+
+	require.NotEqual(t, gtpu.OuterHeaderCreationDescription, udp.OuterHeaderCreationDescription)
+	require.Equal(t, uint32(12), gtpu.TEID)
+	require.Equal(t, uint16(UDP_IPV4), udp.OuterHeaderCreationDescription)
+	require.Zero(t, udp.TEID)
+	require.Equal(t, uint16(8080), udp.PortNumber)
+	require.True(t, udp.IPv4Address.Equal(net.ParseIP("10.0.0.1")))
+
+	udpIPv6 := outerHeader(NewFARBuilder().
+		WithID(1).
+		WithAction(ActionForward).
+		WithDstInterface(ie.DstInterfaceCore).
+		WithOuterHeaderUDP("2001:db8::1", 8080).
+		BuildFAR())
+	require.NotNil(t, udpIPv6)
+
+	require.Equal(t, uint16(UDP_IPV6), udpIPv6.OuterHeaderCreationDescription)
+	require.Nil(t, udpIPv6.IPv4Address)
+	require.True(t, udpIPv6.IPv6Address.Equal(net.ParseIP("2001:db8::1")))
+}
+
+func TestFARBuilderRedirect(t *testing.T) {
+	far := NewFARBuilder().
+		WithID(1).
+		WithAction(ActionForward).
+		WithDstInterface(ie.DstInterfaceCore).
+		WithRedirect(RedirectTypeURL, "http://portal.example.com").
+		BuildFAR()
+
+	var redirect *ie.RedirectInformationFields
+
+	for _, child := range far.ChildIEs {
+		if child.Type == ie.ForwardingParameters {
+			var err error
+			redirect, err = child.RedirectInformation()
+			require.NoError(t, err)
+		}
+	}
+
+	require.NotNil(t, redirect)
+
+	require.Equal(t, RedirectTypeURL, redirect.RedirectAddressType)
+	require.Equal(t, "http://portal.example.com", redirect.RedirectServerAddress)
+}
+
+func TestFARBuilderHeaderEnrichment(t *testing.T) {
+	t.Run("forwarding FAR", func(t *testing.T) {
+		far := NewFARBuilder().
+			WithID(1).
+			WithAction(ActionForward).
+			WithDstInterface(ie.DstInterfaceCore).
+			WithHeaderEnrichment("X-MSISDN", "1234567890").
+			WithHeaderEnrichment("X-IMSI", "001010000000001").
+			BuildFAR()
+
+		var enrichments []*ie.HeaderEnrichmentFields
+
+		for _, child := range far.ChildIEs {
+			if child.Type != ie.ForwardingParameters {
+				continue
+			}
+
+			for _, param := range child.ChildIEs {
+				if param.Type != ie.HeaderEnrichment {
+					continue
+				}
+
+				fields, err := param.HeaderEnrichment()
+				require.NoError(t, err)
+
+				enrichments = append(enrichments, fields)
+			}
+		}
+
+		require.Len(t, enrichments, 2)
+
+		require.Equal(t, ie.HeaderTypeHTTP, enrichments[0].HeaderType)
+		require.Equal(t, "X-MSISDN", enrichments[0].HeaderFieldName)
+		require.Equal(t, "1234567890", enrichments[0].HeaderFieldValue)
+
+		require.Equal(t, "X-IMSI", enrichments[1].HeaderFieldName)
+		require.Equal(t, "001010000000001", enrichments[1].HeaderFieldValue)
+	})
+
+	t.Run("dropping FAR", func(t *testing.T) {
+		far := NewFARBuilder().
+			WithID(1).
+			WithAction(ActionDrop).
+			WithDstInterface(ie.DstInterfaceCore).
+			WithHeaderEnrichment("X-MSISDN", "1234567890").
+			BuildFAR()
+
+		require.Equal(t, ie.NewCreateFAR(
+			ie.NewFARID(1),
+			ie.NewApplyAction(ActionDrop),
+			ie.NewForwardingParameters(
+				ie.NewDestinationInterface(ie.DstInterfaceCore),
+			),
+		), far)
+	})
+}
+
+func TestFARBuilderMinimalUpdate(t *testing.T) {
+	// the full update rebuilds all the forwarding parameters
+	full := NewFARBuilder().
+		WithID(1).
+		WithMethod(Update).
+		WithAction(ActionBuffer | ActionNotify).
+		WithDstInterface(ie.DstInterfaceAccess).
+		WithTEID(100).
+		WithDownlinkIP("10.0.0.1").
+		WithBARID(2).
+		BuildFAR()
+
+	require.Equal(t, ie.NewUpdateFAR(
+		ie.NewFARID(1),
+		ie.NewApplyAction(ActionBuffer|ActionNotify),
+		ie.NewUpdateForwardingParameters(
+			ie.NewDestinationInterface(ie.DstInterfaceAccess),
+			ie.NewOuterHeaderCreation(S_TAG, 100, "10.0.0.1", "", 0, 0, 0),
+		),
+		ie.NewBARID(2),
+	), full)
+
+	tests := []struct {
+		input       *farBuilder
+		expected    *ie.IE
+		description string
+	}{
+		{
+			input: NewFARBuilder().
+				WithID(1).
+				WithMethod(Update).
+				WithAction(ActionBuffer | ActionNotify).
+				WithBARID(2),
+			expected: ie.NewUpdateFAR(
+				ie.NewFARID(1),
+				ie.NewApplyAction(ActionBuffer|ActionNotify),
+				ie.NewBARID(2),
+			),
+			description: "Apply Action only",
+		},
+		{
+			input: NewFARBuilder().
+				WithID(1).
+				WithMethod(Update).
+				WithTEID(200).
+				WithDownlinkIP("10.0.0.2").
+				WithEndMarker(true),
+			expected: ie.NewUpdateFAR(
+				ie.NewFARID(1),
+				ie.NewUpdateForwardingParameters(
+					ie.NewOuterHeaderCreation(S_TAG, 200, "10.0.0.2", "", 0, 0, 0),
+					ie.NewPFCPSMReqFlags(7),
+				),
+			),
+			description: "Outer header creation only",
+		},
+		{
+			input: NewFARBuilder().
+				WithID(1).
+				WithMethod(Update).
+				WithAction(ActionDrop).
+				WithEndMarker(true).
+				WithBARID(2),
+			expected: ie.NewUpdateFAR(
+				ie.NewFARID(1),
+				ie.NewApplyAction(ActionDrop),
+			),
+			description: "Fields not honored by the action",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.input.AsMinimalUpdate().BuildFAR())
+		})
+	}
+}
+
+func TestFARBuilderBuildE(t *testing.T) {
+	tests := []struct {
+		description string
+		input       *farBuilder
+		offendingIE string
+	}{
+		{
+			description: "no ID",
+			input:       NewFARBuilder().WithAction(ActionForward).WithDstInterface(ie.DstInterfaceCore),
+			offendingIE: "FAR ID",
+		},
+		{
+			description: "no destination interface",
+			input:       NewFARBuilder().WithID(1).WithAction(ActionForward),
+			offendingIE: "Destination Interface",
+		},
+		{
+			description: "drop and forward",
+			input:       NewFARBuilder().WithID(1).WithAction(ActionDrop | ActionForward).WithDstInterface(ie.DstInterfaceCore),
+			offendingIE: "Apply Action",
+		},
+		{
+			description: "UDP and GTP-U outer headers",
+			input: NewFARBuilder().WithID(1).WithAction(ActionForward).WithDstInterface(ie.DstInterfaceCore).
+				WithUplinkIP("198.18.0.1").WithOuterHeaderUDP("198.18.0.2", 2152),
+			offendingIE: "Outer Header Creation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			far, err := tt.input.BuildE()
+			require.Nil(t, far)
+			require.ErrorIs(t, err, ErrInvalidRule)
+
+			var ruleErr *RuleError
+			require.ErrorAs(t, err, &ruleErr)
+			require.Equal(t, "FAR", ruleErr.Rule)
+			require.Equal(t, tt.offendingIE, ruleErr.IE)
+			require.Contains(t, err.Error(), tt.offendingIE)
+
+			assert.Panics(t, func() { tt.input.BuildFAR() })
+		})
+	}
+
+	far, err := NewFARBuilder().WithID(1).WithAction(ActionDrop).WithDstInterface(ie.DstInterfaceCore).BuildE()
+	require.NoError(t, err)
+	require.Equal(t, NewFARBuilder().WithID(1).WithAction(ActionDrop).WithDstInterface(ie.DstInterfaceCore).BuildFAR(), far)
+}
@@ -3,6 +3,8 @@
 
 package session
 
+import "net"
+
 type IEMethod uint8
 
 // Definitions for session rules
@@ -23,5 +25,24 @@ const (
 	ActionBuffer  uint8 = 0x4
 	ActionNotify  uint8 = 0x8
 
-	S_TAG = 0x100 // Refer to table 8.2.56-1 in PFCP specs Release 16
+	// Redirect Address Types, see table 8.2.20-1 in PFCP specs
+	RedirectTypeIPv4   uint8 = 0
+	RedirectTypeIPv6   uint8 = 1
+	RedirectTypeURL    uint8 = 2
+	RedirectTypeSIPURI uint8 = 3
+
+	S_TAG         = 0x100 // Refer to table 8.2.56-1 in PFCP specs Release 16
+	GTPU_UDP_IPV6 = 0x200 // Outer header creation description for GTP-U/UDP/IPv6. See table 8.2.56-1
+	UDP_IPV4      = 0x400 // Outer header creation description for UDP/IPv4. See table 8.2.56-1
+	UDP_IPV6      = 0x800 // Outer header creation description for UDP/IPv6. See table 8.2.56-1
+
+	REMOVE_GTPU_UDP_IPV4 uint8 = 0 // Outer header removal description for GTP-U/UDP/IPv4. See table 8.2.64-1
+	REMOVE_GTPU_UDP_IPV6 uint8 = 1 // Outer header removal description for GTP-U/UDP/IPv6. See table 8.2.64-1
 )
+
+// isIPv6 returns true if address is a valid IPv6 address.
+func isIPv6(address string) bool {
+	ip := net.ParseIP(address)
+
+	return ip != nil && ip.To4() == nil
+}
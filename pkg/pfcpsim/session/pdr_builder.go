@@ -4,7 +4,9 @@
 package session
 
 import (
+	"fmt"
 	"net"
+	"time"
 
 	"github.com/wmnsk/go-pfcp/ie"
 )
@@ -21,9 +23,29 @@ type pdrBuilder struct {
 
 	qerIDs []*ie.IE
 
-	ueAddress string
-	n3Address string
-	direction direction
+	ueAddress     string
+	ueIPv6Address string
+	n3Address     string
+	fteidAddress  string
+	direction     direction
+
+	// ueAddressPrefixLen and ueIPv6PrefixLen are the prefix lengths set with WithUEAddressPrefix
+	ueAddressPrefixLen   int
+	ueIPv6PrefixLen      int
+	isUEAddressPrefixSet bool
+	isUEIPv6PrefixSet    bool
+
+	activationTime   time.Time
+	deactivationTime time.Time
+
+	sourceInterface      uint8
+	isSourceInterfaceSet bool
+
+	// ethernetSrcMAC is the source MAC address set with WithEthernetFilter, ethernetSrcMACErr the error parsing it
+	ethernetSrcMAC      net.HardwareAddr
+	ethernetSrcMACErr   error
+	etherType           uint16
+	isEthernetFilterSet bool
 }
 
 func NewPDRBuilder() *pdrBuilder {
@@ -63,16 +85,64 @@ func (b *pdrBuilder) WithN3Address(n3Address string) *pdrBuilder {
 	return b
 }
 
+// WithFTEIDAddress overrides the N3 address in the F-TEID of uplink PDRs, e.g. to target another interface
+// of a multi-interface UPF. It must be of the same IP version as the N3 address, which still selects
+// the Outer Header Removal, and can't be combined with WithTeidAlloc.
+func (b *pdrBuilder) WithFTEIDAddress(address string) *pdrBuilder {
+	b.fteidAddress = address
+	return b
+}
+
 func (b *pdrBuilder) WithUEAddress(ueAddress string) *pdrBuilder {
 	b.ueAddress = ueAddress
+	b.isUEAddressPrefixSet = false
+
+	return b
+}
+
+// WithUEIPv6Address sets the IPv6 address of the UE. Combined with WithUEAddress,
+// the downlink PDR matches both the IPv4 and the IPv6 address of a dual-stack UE.
+func (b *pdrBuilder) WithUEIPv6Address(ueAddress string) *pdrBuilder {
+	b.ueIPv6Address = ueAddress
+	b.isUEIPv6PrefixSet = false
+
+	return b
+}
+
+// WithUEAddressPrefix makes the downlink PDR match the UEs of the prefix ip/prefixLen, e.g. all the UEs of a pool,
+// replacing the address of the same IP version set with WithUEAddress or WithUEIPv6Address.
+// IPv6 prefixes shorter than /128 are sent with the IPv6 Prefix Length of the UE IP Address IE.
+// PFCP carries no IPv4 prefix length: IPv4 prefixes other than /32, i.e. a single UE, are rejected by BuildE.
+func (b *pdrBuilder) WithUEAddressPrefix(ip string, prefixLen int) *pdrBuilder {
+	if isIPv6(ip) {
+		b.ueIPv6Address = ip
+		b.ueIPv6PrefixLen = prefixLen
+		b.isUEIPv6PrefixSet = true
+	} else {
+		b.ueAddress = ip
+		b.ueAddressPrefixLen = prefixLen
+		b.isUEAddressPrefixSet = true
+	}
+
 	return b
 }
 
+// AddQERID adds a QER to the ones applied by the PDR.
+// QER ID IEs are emitted in the order they are added, which some peers rely on.
 func (b *pdrBuilder) AddQERID(qerID uint32) *pdrBuilder {
 	b.qerIDs = append(b.qerIDs, ie.NewQERID(qerID))
 	return b
 }
 
+// AddQERIDs adds the given QERs, in order, to the ones applied by the PDR. See AddQERID.
+func (b *pdrBuilder) AddQERIDs(qerIDs ...uint32) *pdrBuilder {
+	for _, id := range qerIDs {
+		b.AddQERID(id)
+	}
+
+	return b
+}
+
 func (b *pdrBuilder) WithFARID(farID uint32) *pdrBuilder {
 	b.farID = farID
 	return b
@@ -83,6 +153,42 @@ func (b *pdrBuilder) WithTeidAlloc(teidAlloc bool) *pdrBuilder {
 	return b
 }
 
+// WithActivationTime sets the time at which the peer starts applying the PDR.
+func (b *pdrBuilder) WithActivationTime(t time.Time) *pdrBuilder {
+	b.activationTime = t
+	return b
+}
+
+// WithDeactivationTime sets the time at which the peer stops applying the PDR.
+func (b *pdrBuilder) WithDeactivationTime(t time.Time) *pdrBuilder {
+	b.deactivationTime = t
+	return b
+}
+
+// WithSourceInterface overrides the Source Interface of the PDI (e.g. ie.SrcInterfaceSGiLANN6LAN for N6 or
+// ie.SrcInterfaceCore for N9 uplink rules), which is otherwise Access for uplink PDRs and Core for downlink ones.
+func (b *pdrBuilder) WithSourceInterface(iface uint8) *pdrBuilder {
+	b.sourceInterface = iface
+	b.isSourceInterfaceSet = true
+
+	return b
+}
+
+// WithEthernetFilter makes the PDI match Ethernet frames with an Ethernet Packet Filter, for Ethernet PDU sessions.
+// srcMAC is the source MAC address and etherType the EtherType of the frames, each omitted if empty or 0.
+// Downlink PDRs matching Ethernet frames don't need the UE IP address. It can't be combined with WithSDFFilter.
+func (b *pdrBuilder) WithEthernetFilter(srcMAC string, etherType uint16) *pdrBuilder {
+	b.ethernetSrcMAC, b.ethernetSrcMACErr = nil, nil
+	if srcMAC != "" {
+		b.ethernetSrcMAC, b.ethernetSrcMACErr = net.ParseMAC(srcMAC)
+	}
+
+	b.etherType = etherType
+	b.isEthernetFilterSet = true
+
+	return b
+}
+
 func (b *pdrBuilder) MarkAsDownlink() *pdrBuilder {
 	b.direction = downlink
 	return b
@@ -93,33 +199,171 @@ func (b *pdrBuilder) MarkAsUplink() *pdrBuilder {
 	return b
 }
 
-func (b *pdrBuilder) validate() {
+// validate returns a RuleError naming the offending IE if the values set don't form a valid PDR.
+func (b *pdrBuilder) validate() error {
+	invalid := func(offendingIE string, reason string) error {
+		return newRuleError("PDR", uint32(b.id), offendingIE, reason)
+	}
+
 	if b.direction == notSet {
-		panic("Tried building a PDR without marking it as uplink or downlink")
+		return invalid("PDI", "PDR not marked as uplink or downlink")
 	}
 
 	if len(b.qerIDs) == 0 {
-		panic("Tried building PDR without providing QER IDs")
+		return invalid("QER ID", "not set")
 	}
 
 	if b.farID == 0 {
-		panic("Tried building PDR without providing FAR ID")
+		return invalid("FAR ID", "not set")
+	}
+
+	if !b.activationTime.IsZero() && !b.deactivationTime.IsZero() && !b.deactivationTime.After(b.activationTime) {
+		return invalid("Deactivation Time", "not after the Activation Time")
+	}
+
+	if b.isEthernetFilterSet {
+		if b.sdfFilter != "" {
+			return invalid("Ethernet Packet Filter", "combined with an SDF Filter")
+		}
+
+		if b.ethernetSrcMACErr != nil {
+			return invalid("MAC Address", fmt.Sprintf("invalid source MAC address: %v", b.ethernetSrcMACErr))
+		}
 	}
 
 	if b.direction == downlink {
-		if b.ueAddress == "" {
-			panic("Tried building downlink PDR without setting the UE IP address")
+		if b.ueAddress == "" && b.ueIPv6Address == "" && !b.isEthernetFilterSet {
+			return invalid("UE IP Address", "not set")
+		}
+
+		if b.isUEAddressPrefixSet {
+			if ip := net.ParseIP(b.ueAddress); ip == nil || ip.To4() == nil {
+				return invalid("UE IP Address", fmt.Sprintf("invalid address %v", b.ueAddress))
+			}
+
+			if b.ueAddressPrefixLen != net.IPv4len*8 {
+				return invalid("UE IP Address", fmt.Sprintf("IPv4 prefix length %v: only /32 can be sent", b.ueAddressPrefixLen))
+			}
+		}
+
+		if b.isUEIPv6PrefixSet && (b.ueIPv6PrefixLen < 1 || b.ueIPv6PrefixLen > net.IPv6len*8) {
+			return invalid("UE IP Address", fmt.Sprintf("IPv6 prefix length %v not between 1 and 128", b.ueIPv6PrefixLen))
 		}
 	}
 
 	if b.direction == uplink {
 		if b.n3Address == "" {
-			panic("Tried building uplink PDR without setting the N3Address")
+			return invalid("F-TEID", "N3 address not set")
 		}
 
 		if b.teid == 0 {
-			panic("Tried building uplink PDR without setting the TEID")
+			return invalid("F-TEID", "TEID not set")
 		}
+
+		if b.fteidAddress != "" {
+			if net.ParseIP(b.fteidAddress) == nil {
+				return invalid("F-TEID", fmt.Sprintf("invalid address %v", b.fteidAddress))
+			}
+
+			if isIPv6(b.fteidAddress) != isIPv6(b.n3Address) {
+				return invalid("F-TEID", fmt.Sprintf("address %v of another IP version than the N3 address %v", b.fteidAddress, b.n3Address))
+			}
+
+			if b.teidAlloc {
+				return invalid("F-TEID", "address set while the peer allocates it")
+			}
+		}
+	}
+
+	return nil
+}
+
+// fteidIPAddress returns the address of the F-TEID of uplink PDRs: the one set with WithFTEIDAddress, if any,
+// the N3 address otherwise.
+func (b *pdrBuilder) fteidIPAddress() net.IP {
+	if b.fteidAddress != "" {
+		return net.ParseIP(b.fteidAddress)
+	}
+
+	return net.ParseIP(b.n3Address)
+}
+
+// newUEIPAddress returns a UE IP Address IE carrying the IPv4 and/or the IPv6 address of the UE,
+// and the IPv6 prefix length set with WithUEAddressPrefix, if any.
+func (b *pdrBuilder) newUEIPAddress() *ie.IE {
+	var (
+		flags  uint8
+		v4, v6 string
+		v6pl   uint8
+	)
+
+	for _, address := range []string{b.ueAddress, b.ueIPv6Address} {
+		if address == "" {
+			continue
+		}
+
+		if isIPv6(address) {
+			flags |= 0x01
+			v6 = address
+		} else {
+			flags |= 0x02
+			v4 = address
+		}
+	}
+
+	// IP6PL, the IPv6 Prefix Length is present
+	if v6 != "" && b.isUEIPv6PrefixSet && b.ueIPv6PrefixLen < net.IPv6len*8 {
+		flags |= 0x40
+		v6pl = uint8(b.ueIPv6PrefixLen)
+	}
+
+	return ie.NewUEIPAddress(flags, v4, v6, 0, v6pl)
+}
+
+// newEthernetPacketFilter returns the Ethernet Packet Filter IE of the PDI, matching the source MAC address
+// and the EtherType set with WithEthernetFilter.
+func (b *pdrBuilder) newEthernetPacketFilter() *ie.IE {
+	var mac, etherType *ie.IE
+
+	if b.ethernetSrcMAC != nil {
+		mac = ie.NewMACAddress(b.ethernetSrcMAC, nil, nil, nil)
+	}
+
+	if b.etherType != 0 {
+		etherType = ie.NewEthertype(b.etherType)
+	}
+
+	return ie.NewEthernetPacketFilter(mac, etherType)
+}
+
+// newSourceInterface returns the Source Interface IE of the PDI: the one set with WithSourceInterface, if any,
+// inferred otherwise.
+func (b *pdrBuilder) newSourceInterface(inferred uint8) *ie.IE {
+	if b.isSourceInterfaceSet {
+		return ie.NewSourceInterface(b.sourceInterface)
+	}
+
+	return ie.NewSourceInterface(inferred)
+}
+
+// outerHeaderRemovalDescription returns the description of the header removed by uplink PDRs, which depends on
+// the IP version of the N3 address the GTP-U packets are received on.
+func (b *pdrBuilder) outerHeaderRemovalDescription() uint8 {
+	if isIPv6(b.n3Address) {
+		return REMOVE_GTPU_UDP_IPV6
+	}
+
+	return REMOVE_GTPU_UDP_IPV4
+}
+
+// addTimeIEs adds the Activation Time and Deactivation Time IEs to pdr, if set.
+func (b *pdrBuilder) addTimeIEs(pdr *ie.IE) {
+	if !b.activationTime.IsZero() {
+		pdr.Add(ie.NewActivationTime(b.activationTime))
+	}
+
+	if !b.deactivationTime.IsZero() {
+		pdr.Add(ie.NewDeactivationTime(b.deactivationTime))
 	}
 }
 
@@ -129,26 +373,50 @@ func newRemovePDR(pdr *ie.IE) *ie.IE {
 
 // BuildPDR returns by default an UplinkFAR.
 // Returns a DownlinkFAR if MarkAsDownlink was invoked.
+// Panics if the values set don't form a valid PDR, see BuildE.
 func (b *pdrBuilder) BuildPDR() *ie.IE {
-	b.validate()
+	pdr, err := b.BuildE()
+	if err != nil {
+		panic(err)
+	}
 
+	return pdr
+}
+
+// BuildE returns the PDR like BuildPDR, or a RuleError naming the offending IE
+// if the values set don't form a valid PDR.
+func (b *pdrBuilder) BuildE() (*ie.IE, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	return b.build(), nil
+}
+
+func (b *pdrBuilder) build() *ie.IE {
 	createFunc := ie.NewCreatePDR
 	if b.method == Update {
 		createFunc = ie.NewUpdatePDR
 	}
 
+	// with the CH flag set, the peer allocates the TEID and the address of the IP version flagged
 	var teid *ie.IE
-	if b.teidAlloc {
+	if b.teidAlloc && isIPv6(b.n3Address) {
+		teid = ie.NewFTEID(0x06, 0, nil, nil, 0)
+	} else if b.teidAlloc {
 		teid = ie.NewFTEID(0x05, 0, nil, nil, 0)
+	} else if isIPv6(b.n3Address) {
+		teid = ie.NewFTEID(0x02, b.teid, nil, b.fteidIPAddress(), 0)
 	} else {
-		teid = ie.NewFTEID(0x01, b.teid, net.ParseIP(b.n3Address), nil, 0)
+		teid = ie.NewFTEID(0x01, b.teid, b.fteidIPAddress(), nil, 0)
 	}
 
 	if b.direction == downlink {
-		pdi := ie.NewPDI(
-			ie.NewSourceInterface(ie.SrcInterfaceCore),
-			ie.NewUEIPAddress(0x2, b.ueAddress, "", 0, 0),
-		)
+		pdi := ie.NewPDI(b.newSourceInterface(ie.SrcInterfaceCore))
+
+		if b.ueAddress != "" || b.ueIPv6Address != "" {
+			pdi.Add(b.newUEIPAddress())
+		}
 
 		if b.sdfFilter != "" {
 			if !b.bidSdf {
@@ -158,6 +426,10 @@ func (b *pdrBuilder) BuildPDR() *ie.IE {
 			}
 		}
 
+		if b.isEthernetFilterSet {
+			pdi.Add(b.newEthernetPacketFilter())
+		}
+
 		pdr := createFunc(
 			ie.NewPDRID(b.id),
 			ie.NewPrecedence(b.precedence),
@@ -166,6 +438,7 @@ func (b *pdrBuilder) BuildPDR() *ie.IE {
 
 		pdr.Add(pdi)
 		pdr.Add(b.qerIDs...)
+		b.addTimeIEs(pdr)
 
 		if b.method == Delete {
 			return newRemovePDR(pdr)
@@ -176,7 +449,7 @@ func (b *pdrBuilder) BuildPDR() *ie.IE {
 
 	// UplinkPDR
 	pdi := ie.NewPDI(
-		ie.NewSourceInterface(ie.SrcInterfaceAccess),
+		b.newSourceInterface(ie.SrcInterfaceAccess),
 		teid,
 	)
 
@@ -184,15 +457,20 @@ func (b *pdrBuilder) BuildPDR() *ie.IE {
 		pdi.Add(ie.NewSDFFilter(b.sdfFilter, "", "", "", 1))
 	}
 
+	if b.isEthernetFilterSet {
+		pdi.Add(b.newEthernetPacketFilter())
+	}
+
 	pdr := createFunc(
 		ie.NewPDRID(b.id),
 		ie.NewPrecedence(b.precedence),
-		ie.NewOuterHeaderRemoval(0, 0),
+		ie.NewOuterHeaderRemoval(b.outerHeaderRemovalDescription(), 0),
 		ie.NewFARID(b.farID),
 	)
 
 	pdr.Add(pdi)
 	pdr.Add(b.qerIDs...)
+	b.addTimeIEs(pdr)
 
 	if b.method == Delete {
 		newRemovePDR(pdr)
@@ -200,4 +478,3 @@ func (b *pdrBuilder) BuildPDR() *ie.IE {
 
 	return pdr
 }
-
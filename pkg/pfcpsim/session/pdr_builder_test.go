@@ -6,8 +6,10 @@ package session
 import (
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wmnsk/go-pfcp/ie"
 )
 
@@ -109,7 +111,7 @@ func TestPDRBuilder(t *testing.T) {
 				WithN3Address("192.168.0.1").
 				WithFARID(3).
 				AddQERID(4).
-				WithSDFFilter("permit ip any to assigned").
+				WithSDFFilter("permit ip any to assigned", false).
 				MarkAsUplink(),
 			expected: ie.NewCreatePDR(
 				ie.NewPDRID(1),
@@ -125,6 +127,29 @@ func TestPDRBuilder(t *testing.T) {
 			),
 			description: "Valid Create Uplink PDR",
 		},
+		{
+			input: NewPDRBuilder().
+				WithID(1).
+				WithPrecedence(2).
+				WithTEID(100).
+				WithMethod(Create).
+				WithN3Address("2001:db8::1").
+				WithFARID(3).
+				AddQERID(4).
+				MarkAsUplink(),
+			expected: ie.NewCreatePDR(
+				ie.NewPDRID(1),
+				ie.NewPrecedence(2),
+				ie.NewOuterHeaderRemoval(REMOVE_GTPU_UDP_IPV6, 0),
+				ie.NewFARID(3),
+				ie.NewPDI(
+					ie.NewSourceInterface(ie.SrcInterfaceAccess),
+					ie.NewFTEID(0x02, 100, nil, net.ParseIP("2001:db8::1"), 0),
+				),
+				ie.NewQERID(4),
+			),
+			description: "Valid Create Uplink PDR with IPv6 N3 address",
+		},
 		{
 			input: NewPDRBuilder().
 				WithID(1).
@@ -133,7 +158,7 @@ func TestPDRBuilder(t *testing.T) {
 				WithUEAddress("172.16.0.1").
 				WithMethod(Update).
 				WithFARID(3).
-				WithSDFFilter("permit ip any to assigned").
+				WithSDFFilter("permit ip any to assigned", false).
 				AddQERID(4).
 				MarkAsDownlink(),
 			expected: ie.NewUpdatePDR(
@@ -171,6 +196,49 @@ func TestPDRBuilder(t *testing.T) {
 			),
 			description: "Valid Update Downlink PDR no SDF",
 		},
+		{
+			input: NewPDRBuilder().
+				WithID(1).
+				WithPrecedence(2).
+				WithUEAddress("2001:db8:1::1").
+				WithMethod(Create).
+				WithFARID(3).
+				AddQERID(4).
+				MarkAsDownlink(),
+			expected: ie.NewCreatePDR(
+				ie.NewPDRID(1),
+				ie.NewPrecedence(2),
+				ie.NewFARID(3),
+				ie.NewPDI(
+					ie.NewSourceInterface(ie.SrcInterfaceCore),
+					ie.NewUEIPAddress(0x1, "", "2001:db8:1::1", 0, 0),
+				),
+				ie.NewQERID(4),
+			),
+			description: "Valid Create Downlink PDR with IPv6 UE address",
+		},
+		{
+			input: NewPDRBuilder().
+				WithID(1).
+				WithPrecedence(2).
+				WithUEAddress("172.16.0.1").
+				WithUEIPv6Address("2001:db8:1::1").
+				WithMethod(Create).
+				WithFARID(3).
+				AddQERID(4).
+				MarkAsDownlink(),
+			expected: ie.NewCreatePDR(
+				ie.NewPDRID(1),
+				ie.NewPrecedence(2),
+				ie.NewFARID(3),
+				ie.NewPDI(
+					ie.NewSourceInterface(ie.SrcInterfaceCore),
+					ie.NewUEIPAddress(0x3, "172.16.0.1", "2001:db8:1::1", 0, 0),
+				),
+				ie.NewQERID(4),
+			),
+			description: "Valid Create Downlink PDR with dual-stack UE",
+		},
 		{
 			input: NewPDRBuilder().
 				WithID(1).
@@ -202,3 +270,533 @@ func TestPDRBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestPDRBuilderIPv6FTEID(t *testing.T) {
+	pdr := NewPDRBuilder().
+		WithID(1).
+		WithTEID(100).
+		WithN3Address("2001:db8::1").
+		WithFARID(3).
+		AddQERID(4).
+		MarkAsUplink().
+		BuildPDR()
+
+	var fteid *ie.FTEIDFields
+
+	for _, child := range pdr.ChildIEs {
+		if child.Type == ie.PDI {
+			var err error
+			fteid, err = child.FTEID()
+			require.NoError(t, err)
+		}
+	}
+
+	require.NotNil(t, fteid)
+
+	require.True(t, fteid.HasIPv6())
+	require.False(t, fteid.HasIPv4())
+	require.Equal(t, uint32(100), fteid.TEID)
+	require.True(t, fteid.IPv6Address.Equal(net.ParseIP("2001:db8::1")))
+}
+
+func TestPDRBuilderOuterHeaderRemoval(t *testing.T) {
+	tests := []struct {
+		n3Address string
+		teidAlloc bool
+		expected  byte
+	}{
+		{n3Address: "198.18.0.1", expected: REMOVE_GTPU_UDP_IPV4},
+		{n3Address: "198.18.0.1", teidAlloc: true, expected: REMOVE_GTPU_UDP_IPV4},
+		{n3Address: "2001:db8::1", expected: REMOVE_GTPU_UDP_IPV6},
+		{n3Address: "2001:db8::1", teidAlloc: true, expected: REMOVE_GTPU_UDP_IPV6},
+	}
+
+	for _, tt := range tests {
+		pdr := NewPDRBuilder().
+			WithID(1).
+			WithTEID(100).
+			WithN3Address(tt.n3Address).
+			WithTeidAlloc(tt.teidAlloc).
+			WithFARID(3).
+			AddQERID(4).
+			MarkAsUplink().
+			BuildPDR()
+
+		removal, err := pdr.OuterHeaderRemoval()
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, removal[0], tt.n3Address)
+	}
+}
+
+func TestPDRBuilderActivationTime(t *testing.T) {
+	activation := time.Date(2022, time.January, 1, 10, 0, 0, 0, time.UTC)
+	deactivation := activation.Add(time.Hour)
+
+	pdr := NewPDRBuilder().
+		WithID(1).
+		WithUEAddress("10.0.0.1").
+		WithFARID(3).
+		AddQERID(4).
+		WithActivationTime(activation).
+		WithDeactivationTime(deactivation).
+		MarkAsDownlink().
+		BuildPDR()
+
+	activationTime, err := pdr.ActivationTime()
+	require.NoError(t, err)
+	require.True(t, activationTime.Equal(activation))
+
+	deactivationTime, err := pdr.DeactivationTime()
+	require.NoError(t, err)
+	require.True(t, deactivationTime.Equal(deactivation))
+
+	assert.Panics(t, func() {
+		NewPDRBuilder().
+			WithID(1).
+			WithUEAddress("10.0.0.1").
+			WithFARID(3).
+			AddQERID(4).
+			WithActivationTime(deactivation).
+			WithDeactivationTime(activation).
+			MarkAsDownlink().
+			BuildPDR()
+	})
+}
+
+func TestPDRBuilderSourceInterface(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *pdrBuilder
+		expected uint8
+	}{
+		{
+			name:     "uplink default",
+			builder:  NewPDRBuilder().WithTEID(1).WithN3Address("10.0.0.1").MarkAsUplink(),
+			expected: ie.SrcInterfaceAccess,
+		},
+		{
+			name:     "downlink default",
+			builder:  NewPDRBuilder().WithUEAddress("10.0.0.1").MarkAsDownlink(),
+			expected: ie.SrcInterfaceCore,
+		},
+		{
+			name:     "uplink from core",
+			builder:  NewPDRBuilder().WithTEID(1).WithN3Address("10.0.0.1").WithSourceInterface(ie.SrcInterfaceCore).MarkAsUplink(),
+			expected: ie.SrcInterfaceCore,
+		},
+		{
+			name:     "downlink from SGi-LAN",
+			builder:  NewPDRBuilder().WithUEAddress("10.0.0.1").WithSourceInterface(ie.SrcInterfaceSGiLANN6LAN).MarkAsDownlink(),
+			expected: ie.SrcInterfaceSGiLANN6LAN,
+		},
+		{
+			name:     "explicit access",
+			builder:  NewPDRBuilder().WithUEAddress("10.0.0.1").WithSourceInterface(ie.SrcInterfaceAccess).MarkAsDownlink(),
+			expected: ie.SrcInterfaceAccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdr := tt.builder.WithID(1).WithFARID(2).AddQERID(3).BuildPDR()
+
+			iface, err := pdr.SourceInterface()
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, iface)
+		})
+	}
+}
+
+func TestPDRBuilderChooseFTEID(t *testing.T) {
+	tests := []struct {
+		name      string
+		n3Address string
+		teidAlloc bool
+		wantIPv6  bool
+	}{
+		{name: "IPv4 choose", n3Address: "10.0.0.1", teidAlloc: true},
+		{name: "IPv6 choose", n3Address: "2001:db8::1", teidAlloc: true, wantIPv6: true},
+		{name: "IPv4 explicit", n3Address: "10.0.0.1"},
+		{name: "IPv6 explicit", n3Address: "2001:db8::1", wantIPv6: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdr := NewPDRBuilder().
+				WithID(1).
+				WithTEID(100).
+				WithN3Address(tt.n3Address).
+				WithTeidAlloc(tt.teidAlloc).
+				WithFARID(3).
+				AddQERID(4).
+				MarkAsUplink().
+				BuildPDR()
+
+			pdi, err := pdr.PDI()
+			require.NoError(t, err)
+
+			var fteid *ie.FTEIDFields
+
+			for _, child := range pdi {
+				if child.Type == ie.FTEID {
+					fteid, err = child.FTEID()
+					require.NoError(t, err)
+				}
+			}
+
+			require.NotNil(t, fteid)
+			require.Equal(t, tt.teidAlloc, fteid.HasCh())
+			require.Equal(t, tt.wantIPv6, fteid.HasIPv6())
+			require.Equal(t, !tt.wantIPv6, fteid.HasIPv4())
+
+			if tt.teidAlloc {
+				// the peer chooses both the TEID and the address
+				require.Zero(t, fteid.TEID)
+				require.Nil(t, fteid.IPv4Address)
+				require.Nil(t, fteid.IPv6Address)
+			} else {
+				require.Equal(t, uint32(100), fteid.TEID)
+				require.True(t, net.ParseIP(tt.n3Address).Equal(fteid.IPv4Address) || net.ParseIP(tt.n3Address).Equal(fteid.IPv6Address))
+			}
+		})
+	}
+}
+
+func TestPDRBuilderQERIDsOrder(t *testing.T) {
+	pdr := NewPDRBuilder().
+		WithID(1).
+		WithUEAddress("10.0.0.1").
+		WithFARID(2).
+		AddQERID(7).
+		AddQERIDs(3, 9, 1).
+		MarkAsDownlink().
+		BuildPDR()
+
+	var qerIDs []uint32
+
+	for _, child := range pdr.ChildIEs {
+		if child.Type != ie.QERID {
+			continue
+		}
+
+		id, err := child.QERID()
+		require.NoError(t, err)
+
+		qerIDs = append(qerIDs, id)
+	}
+
+	require.Equal(t, []uint32{7, 3, 9, 1}, qerIDs)
+}
+
+func TestPDRBuilderFTEIDAddress(t *testing.T) {
+	tests := []struct {
+		name         string
+		n3Address    string
+		fteidAddress string
+		wantIPv6     bool
+	}{
+		{name: "IPv4", n3Address: "198.18.0.1", fteidAddress: "198.18.1.1"},
+		{name: "IPv6", n3Address: "2001:db8::1", fteidAddress: "2001:db8:1::1", wantIPv6: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdr := NewPDRBuilder().
+				WithID(1).
+				WithTEID(100).
+				WithN3Address(tt.n3Address).
+				WithFTEIDAddress(tt.fteidAddress).
+				WithFARID(3).
+				AddQERID(4).
+				MarkAsUplink().
+				BuildPDR()
+
+			pdi, err := pdr.PDI()
+			require.NoError(t, err)
+
+			var fteid *ie.FTEIDFields
+
+			for _, child := range pdi {
+				if child.Type == ie.FTEID {
+					fteid, err = child.FTEID()
+					require.NoError(t, err)
+				}
+			}
+
+			require.NotNil(t, fteid)
+			require.Equal(t, uint32(100), fteid.TEID)
+			require.Equal(t, tt.wantIPv6, fteid.HasIPv6())
+
+			address := fteid.IPv4Address
+			if tt.wantIPv6 {
+				address = fteid.IPv6Address
+			}
+
+			require.True(t, address.Equal(net.ParseIP(tt.fteidAddress)))
+		})
+	}
+
+	for _, builder := range []*pdrBuilder{
+		NewPDRBuilder().WithN3Address("198.18.0.1").WithFTEIDAddress("2001:db8::1"),
+		NewPDRBuilder().WithN3Address("2001:db8::1").WithFTEIDAddress("198.18.1.1"),
+		NewPDRBuilder().WithN3Address("198.18.0.1").WithFTEIDAddress("not-an-address"),
+		NewPDRBuilder().WithN3Address("198.18.0.1").WithFTEIDAddress("198.18.1.1").WithTeidAlloc(true),
+	} {
+		assert.Panics(t, func() {
+			builder.WithID(1).WithTEID(100).WithFARID(3).AddQERID(4).MarkAsUplink().BuildPDR()
+		}, builder.fteidAddress)
+	}
+}
+
+func TestPDRBuilderEthernetFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *pdrBuilder
+	}{
+		{
+			name:    "uplink",
+			builder: NewPDRBuilder().WithTEID(1).WithN3Address("198.18.0.1").MarkAsUplink(),
+		},
+		{
+			name:    "downlink without UE IP address",
+			builder: NewPDRBuilder().MarkAsDownlink(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdr := tt.builder.
+				WithID(1).
+				WithFARID(2).
+				AddQERID(3).
+				WithEthernetFilter("00:00:5e:00:53:01", 0x0800).
+				BuildPDR()
+
+			pdi, err := pdr.PDI()
+			require.NoError(t, err)
+
+			var filter *ie.IE
+
+			for _, child := range pdi {
+				require.NotEqual(t, ie.SDFFilter, child.Type)
+				require.NotEqual(t, ie.UEIPAddress, child.Type)
+
+				if child.Type == ie.EthernetPacketFilter {
+					filter = child
+				}
+			}
+
+			require.NotNil(t, filter)
+
+			var (
+				mac       *ie.IE
+				etherType uint16
+			)
+
+			for _, child := range filter.ChildIEs {
+				switch child.Type {
+				case ie.MACAddress:
+					mac = child
+				case ie.Ethertype:
+					etherType, err = child.Ethertype()
+					require.NoError(t, err)
+				}
+			}
+
+			require.NotNil(t, mac)
+
+			fields, err := mac.MACAddress()
+			require.NoError(t, err)
+			require.True(t, fields.HasSOUR())
+			require.False(t, fields.HasDEST())
+
+			// go-pfcp doesn't decode the addresses: the source one follows the flags octet
+			require.Len(t, mac.Payload, 1+6)
+			require.Equal(t, "00:00:5e:00:53:01", net.HardwareAddr(mac.Payload[1:]).String())
+			require.Equal(t, uint16(0x0800), etherType)
+		})
+	}
+
+	t.Run("SDF filter conflict", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewPDRBuilder().
+				WithID(1).
+				WithTEID(1).
+				WithN3Address("198.18.0.1").
+				WithFARID(2).
+				AddQERID(3).
+				WithSDFFilter("permit out ip from any to assigned", false).
+				WithEthernetFilter("00:00:5e:00:53:01", 0).
+				MarkAsUplink().
+				BuildPDR()
+		})
+	})
+
+	t.Run("invalid MAC address", func(t *testing.T) {
+		pdr, err := NewPDRBuilder().
+			WithID(1).
+			WithFARID(2).
+			AddQERID(3).
+			WithEthernetFilter("not-a-mac", 0).
+			MarkAsDownlink().
+			BuildE()
+		require.Nil(t, pdr)
+		require.ErrorIs(t, err, ErrInvalidRule)
+
+		var ruleErr *RuleError
+		require.ErrorAs(t, err, &ruleErr)
+		require.Equal(t, "MAC Address", ruleErr.IE)
+	})
+}
+
+func TestPDRBuilderBuildE(t *testing.T) {
+	tests := []struct {
+		description string
+		input       *pdrBuilder
+		offendingIE string
+	}{
+		{
+			description: "no direction",
+			input:       NewPDRBuilder().WithID(1).WithFARID(2).AddQERID(3),
+			offendingIE: "PDI",
+		},
+		{
+			description: "no QER ID",
+			input:       NewPDRBuilder().WithID(1).WithFARID(2).WithUEAddress("10.0.0.1").MarkAsDownlink(),
+			offendingIE: "QER ID",
+		},
+		{
+			description: "no FAR ID",
+			input:       NewPDRBuilder().WithID(1).AddQERID(3).WithUEAddress("10.0.0.1").MarkAsDownlink(),
+			offendingIE: "FAR ID",
+		},
+		{
+			description: "downlink without UE address",
+			input:       NewPDRBuilder().WithID(1).WithFARID(2).AddQERID(3).MarkAsDownlink(),
+			offendingIE: "UE IP Address",
+		},
+		{
+			description: "uplink without TEID",
+			input:       NewPDRBuilder().WithID(1).WithFARID(2).AddQERID(3).WithN3Address("198.18.0.1").MarkAsUplink(),
+			offendingIE: "F-TEID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			pdr, err := tt.input.BuildE()
+			require.Nil(t, pdr)
+			require.ErrorIs(t, err, ErrInvalidRule)
+
+			var ruleErr *RuleError
+			require.ErrorAs(t, err, &ruleErr)
+			require.Equal(t, "PDR", ruleErr.Rule)
+			require.Equal(t, uint32(1), ruleErr.ID)
+			require.Equal(t, tt.offendingIE, ruleErr.IE)
+			require.Contains(t, err.Error(), "PDR 1")
+
+			assert.Panics(t, func() { tt.input.BuildPDR() })
+		})
+	}
+
+	pdr, err := NewPDRBuilder().WithID(1).WithFARID(2).AddQERID(3).WithUEAddress("10.0.0.1").MarkAsDownlink().BuildE()
+	require.NoError(t, err)
+	require.Equal(t, NewPDRBuilder().WithID(1).WithFARID(2).AddQERID(3).WithUEAddress("10.0.0.1").MarkAsDownlink().BuildPDR(), pdr)
+}
+
+func TestPDRBuilderUEAddressPrefix(t *testing.T) {
+	tests := []struct {
+		name      string
+		ip        string
+		prefixLen int
+		expected  *ie.IE
+		wantErr   bool
+	}{
+		{
+			name:      "IPv4 host",
+			ip:        "10.0.0.1",
+			prefixLen: 32,
+			expected:  ie.NewUEIPAddress(0x2, "10.0.0.1", "", 0, 0),
+		},
+		{
+			name:      "IPv4 subnet",
+			ip:        "10.0.0.0",
+			prefixLen: 24,
+			wantErr:   true,
+		},
+		{
+			name:      "IPv6 prefix",
+			ip:        "2001:db8:1::",
+			prefixLen: 64,
+			expected:  ie.NewUEIPAddress(0x41, "", "2001:db8:1::", 0, 64),
+		},
+		{
+			name:      "IPv6 host",
+			ip:        "2001:db8:1::1",
+			prefixLen: 128,
+			expected:  ie.NewUEIPAddress(0x1, "", "2001:db8:1::1", 0, 0),
+		},
+		{
+			name:      "IPv6 prefix too long",
+			ip:        "2001:db8:1::",
+			prefixLen: 129,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid address",
+			ip:        "not-an-address",
+			prefixLen: 32,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdr, err := NewPDRBuilder().
+				WithID(1).
+				WithFARID(2).
+				AddQERID(3).
+				WithUEAddressPrefix(tt.ip, tt.prefixLen).
+				MarkAsDownlink().
+				BuildE()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidRule)
+
+				var ruleErr *RuleError
+				require.ErrorAs(t, err, &ruleErr)
+				require.Equal(t, "UE IP Address", ruleErr.IE)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			pdi, err := pdr.PDI()
+			require.NoError(t, err)
+
+			var ueIPAddress *ie.IE
+
+			for _, child := range pdi {
+				if child.Type == ie.UEIPAddress {
+					ueIPAddress = child
+				}
+			}
+
+			require.Equal(t, tt.expected, ueIPAddress)
+		})
+	}
+
+	// the IPv4 host and the IPv6 prefix of a dual-stack UE are sent in the same IE
+	pdr := NewPDRBuilder().
+		WithID(1).
+		WithFARID(2).
+		AddQERID(3).
+		WithUEAddress("10.0.0.1").
+		WithUEAddressPrefix("2001:db8:1::", 64).
+		MarkAsDownlink().
+		BuildPDR()
+
+	pdi, err := pdr.PDI()
+	require.NoError(t, err)
+	require.Contains(t, pdi, ie.NewUEIPAddress(0x43, "10.0.0.1", "2001:db8:1::", 0, 64))
+}
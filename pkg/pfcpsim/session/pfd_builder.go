@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import "github.com/wmnsk/go-pfcp/ie"
+
+type pfd struct {
+	flowDescription string
+	url             string
+	domainName      string
+}
+
+type pfdBuilder struct {
+	applicationID string
+	pfds          []pfd
+}
+
+// NewPFDBuilder returns a pfdBuilder, used to build the Application ID's PFDs IE
+// carried by PFD Management Requests.
+func NewPFDBuilder() *pfdBuilder {
+	return &pfdBuilder{}
+}
+
+func (b *pfdBuilder) WithApplicationID(id string) *pfdBuilder {
+	b.applicationID = id
+	return b
+}
+
+// AddPFD adds a PFD context for the application. Empty fields are omitted from the PFD contents.
+func (b *pfdBuilder) AddPFD(flowDescription, url, domainName string) *pfdBuilder {
+	b.pfds = append(b.pfds, pfd{
+		flowDescription: flowDescription,
+		url:             url,
+		domainName:      domainName,
+	})
+
+	return b
+}
+
+func (b *pfdBuilder) validate() {
+	if b.applicationID == "" {
+		panic("Tried building PFDs without setting the Application ID")
+	}
+
+	for _, p := range b.pfds {
+		if p.flowDescription == "" && p.url == "" && p.domainName == "" {
+			panic("Tried building an empty PFD")
+		}
+	}
+}
+
+// Build returns an Application ID's PFDs IE. If no PFD was added, the IE asks the peer
+// to remove all the PFDs of the application.
+func (b *pfdBuilder) Build() *ie.IE {
+	b.validate()
+
+	appPFDs := ie.NewApplicationIDsPFDs(ie.NewApplicationID(b.applicationID))
+
+	for _, p := range b.pfds {
+		appPFDs.Add(ie.NewPFDContext(
+			ie.NewPFDContents(p.flowDescription, p.url, p.domainName, "", "", nil, nil, nil),
+		))
+	}
+
+	return appPFDs
+}
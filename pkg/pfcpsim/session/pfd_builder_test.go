@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+func TestPFDBuilderShouldPanic(t *testing.T) {
+	type testCase struct {
+		input       *pfdBuilder
+		description string
+	}
+
+	for _, scenario := range []testCase{
+		{
+			input: NewPFDBuilder().
+				AddPFD("permit out ip from 10.0.0.1 to assigned", "", ""),
+			description: "Invalid PFDs: No Application ID provided",
+		},
+		{
+			input: NewPFDBuilder().
+				WithApplicationID("app1").
+				AddPFD("", "", ""),
+			description: "Invalid PFDs: empty PFD",
+		},
+	} {
+		t.Run(scenario.description, func(t *testing.T) {
+			assert.Panics(t, func() { scenario.input.Build() })
+		})
+	}
+}
+
+func TestPFDBuilder(t *testing.T) {
+	appPFDs := NewPFDBuilder().
+		WithApplicationID("app1").
+		AddPFD("permit out ip from 10.0.0.1 to assigned", "", "").
+		AddPFD("", "http://example.com", "").
+		Build()
+
+	require.Equal(t, ie.NewApplicationIDsPFDs(
+		ie.NewApplicationID("app1"),
+		ie.NewPFDContext(ie.NewPFDContents("permit out ip from 10.0.0.1 to assigned", "", "", "", "", nil, nil, nil)),
+		ie.NewPFDContext(ie.NewPFDContents("", "http://example.com", "", "", "", nil, nil, nil)),
+	), appPFDs)
+
+	// Round-trip the PFD set through a PFD Management Request
+	req := message.NewPFDManagementRequest(1, appPFDs)
+
+	b := make([]byte, req.MarshalLen())
+	require.NoError(t, req.MarshalTo(b))
+
+	parsed, err := message.ParsePFDManagementRequest(b)
+	require.NoError(t, err)
+	require.Len(t, parsed.ApplicationIDsPFDs, 1)
+
+	children, err := parsed.ApplicationIDsPFDs[0].ApplicationIDsPFDs()
+	require.NoError(t, err)
+	require.Len(t, children, 3)
+
+	appID, err := children[0].ApplicationID()
+	require.NoError(t, err)
+	require.Equal(t, "app1", appID)
+
+	var contents []*ie.PFDContentsFields
+
+	for _, pfdContext := range children[1:] {
+		ies, err := pfdContext.PFDContext()
+		require.NoError(t, err)
+		require.Len(t, ies, 1)
+
+		fields, err := ies[0].PFDContents()
+		require.NoError(t, err)
+
+		contents = append(contents, fields)
+	}
+
+	require.Equal(t, "permit out ip from 10.0.0.1 to assigned", contents[0].FlowDescription)
+	require.Equal(t, "http://example.com", contents[1].URL)
+}
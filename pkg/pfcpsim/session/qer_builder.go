@@ -16,6 +16,10 @@ type qerBuilder struct {
 	ulGbr      uint64
 	dlGbr      uint64
 	gateStatus uint8
+	// averagingWindow is in milliseconds
+	averagingWindow uint32
+	// correlationID is shared by the QERs that enforce the same aggregate rate limit
+	correlationID uint32
 
 	isIDSet bool
 }
@@ -71,10 +75,27 @@ func (b *qerBuilder) WithGateStatus(status uint8) *qerBuilder {
 	return b
 }
 
-func (b *qerBuilder) validate() {
+// WithAveragingWindow sets the window, in milliseconds, over which the peer measures the bitrate.
+// The IE is omitted if millis is 0.
+func (b *qerBuilder) WithAveragingWindow(millis uint32) *qerBuilder {
+	b.averagingWindow = millis
+	return b
+}
+
+// WithCorrelationID sets the QER Correlation ID, which lets QERs of different sessions
+// share the same aggregate rate limit. The IE is omitted if id is 0.
+func (b *qerBuilder) WithCorrelationID(id uint32) *qerBuilder {
+	b.correlationID = id
+	return b
+}
+
+// validate returns a RuleError naming the offending IE if the values set don't form a valid QER.
+func (b *qerBuilder) validate() error {
 	if !b.isIDSet {
-		panic("Tried to build a QER without setting the QER ID")
+		return newRuleError("QER", 0, "QER ID", "not set")
 	}
+
+	return nil
 }
 
 func (b *qerBuilder) WithMethod(method IEMethod) *qerBuilder {
@@ -82,9 +103,27 @@ func (b *qerBuilder) WithMethod(method IEMethod) *qerBuilder {
 	return b
 }
 
+// Build returns the QER. Panics if the values set don't form a valid QER, see BuildE.
 func (b *qerBuilder) Build() *ie.IE {
-	b.validate()
+	qer, err := b.BuildE()
+	if err != nil {
+		panic(err)
+	}
 
+	return qer
+}
+
+// BuildE returns the QER like Build, or a RuleError naming the offending IE
+// if the values set don't form a valid QER.
+func (b *qerBuilder) BuildE() (*ie.IE, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	return b.build(), nil
+}
+
+func (b *qerBuilder) build() *ie.IE {
 	createFunc := ie.NewCreateQER
 	if b.method == Update {
 		createFunc = ie.NewUpdateQER
@@ -109,6 +148,14 @@ func (b *qerBuilder) Build() *ie.IE {
 		qer.Add(ie.NewGBR(b.ulGbr, b.dlGbr))
 	}
 
+	if b.averagingWindow != 0 {
+		qer.Add(ie.NewAveragingWindow(b.averagingWindow))
+	}
+
+	if b.correlationID != 0 {
+		qer.Add(ie.NewQERCorrelationID(b.correlationID))
+	}
+
 	if b.method == Delete {
 		return ie.NewRemoveQER(qer)
 	}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wmnsk/go-pfcp/ie"
 )
 
@@ -84,6 +85,39 @@ func TestQERBuilder(t *testing.T) {
 			),
 			description: "Valid Update QER",
 		},
+		{
+			input: NewQERBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithQFI(2).
+				WithUplinkMBR(1000).
+				WithDownlinkMBR(2000).
+				WithAveragingWindow(2000),
+			expected: ie.NewCreateQER(
+				ie.NewQERID(1),
+				ie.NewQFI(2),
+				ie.NewGateStatus(0, 0),
+				ie.NewMBR(1000, 2000),
+				ie.NewAveragingWindow(2000),
+			),
+			description: "Valid Create QER with averaging window",
+		},
+		{
+			input: NewQERBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithQFI(2).
+				WithUplinkMBR(1000).
+				WithDownlinkMBR(2000).
+				WithAveragingWindow(0),
+			expected: ie.NewCreateQER(
+				ie.NewQERID(1),
+				ie.NewQFI(2),
+				ie.NewGateStatus(0, 0),
+				ie.NewMBR(1000, 2000),
+			),
+			description: "Valid Create QER without averaging window",
+		},
 		{
 			input: NewQERBuilder().
 				WithID(1).
@@ -108,3 +142,43 @@ func TestQERBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestQERBuilderCorrelationID(t *testing.T) {
+	// QERs of different sessions sharing the same aggregate rate limit
+	for _, id := range []uint32{1, 2} {
+		qer := NewQERBuilder().
+			WithID(id).
+			WithMethod(Create).
+			WithUplinkMBR(1000).
+			WithDownlinkMBR(2000).
+			WithCorrelationID(10).
+			Build()
+
+		correlationID, err := qer.QERCorrelationID()
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(10), correlationID)
+	}
+
+	qer := NewQERBuilder().
+		WithID(3).
+		WithMethod(Create).
+		Build()
+
+	_, err := qer.QERCorrelationID()
+	assert.Error(t, err)
+}
+
+func TestQERBuilderBuildE(t *testing.T) {
+	qer, err := NewQERBuilder().WithMethod(Create).WithQFI(1).BuildE()
+	require.Nil(t, qer)
+	require.ErrorIs(t, err, ErrInvalidRule)
+
+	var ruleErr *RuleError
+	require.ErrorAs(t, err, &ruleErr)
+	require.Equal(t, "QER", ruleErr.Rule)
+	require.Equal(t, "QER ID", ruleErr.IE)
+
+	qer, err = NewQERBuilder().WithID(1).WithQFI(1).BuildE()
+	require.NoError(t, err)
+	require.Equal(t, NewQERBuilder().WithID(1).WithQFI(1).Build(), qer)
+}
@@ -0,0 +1,327 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"time"
+
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+// Reporting Triggers flags, see 8.2.19 in PFCP specs. The 5th octet of the IE is the most significant
+const (
+	ReportingTriggerPERIO uint16 = 0x0100 // periodic reporting
+	ReportingTriggerVOLTH uint16 = 0x0200 // volume threshold
+	ReportingTriggerTIMTH uint16 = 0x0400 // time threshold
+	ReportingTriggerQUHTI uint16 = 0x0800 // quota holding time
+	ReportingTriggerSTART uint16 = 0x1000 // start of traffic
+	ReportingTriggerSTOPT uint16 = 0x2000 // stop of traffic
+	ReportingTriggerDROTH uint16 = 0x4000 // dropped DL traffic threshold
+	ReportingTriggerLIUSA uint16 = 0x8000 // linked usage reporting
+	ReportingTriggerVOLQU uint16 = 0x0001 // volume quota
+	ReportingTriggerTIMQU uint16 = 0x0002 // time quota
+	ReportingTriggerEVEQU uint16 = 0x0020 // event quota
+)
+
+type urrBuilder struct {
+	method IEMethod
+	urrID  uint32
+
+	// measurement method flags, either 0 or 1
+	measureEvent    int
+	measureVolume   int
+	measureDuration int
+	// triggers is the Reporting Triggers bitmask, see 8.2.19 in PFCP specs
+	triggers uint16
+
+	measurementPeriod time.Duration
+	// timeThreshold is in seconds
+	timeThreshold uint32
+
+	eventThreshold uint32
+	eventQuota     uint32
+	// measurementInfo is the Measurement Information bitmask, see 8.2.68 in PFCP specs
+	measurementInfo uint8
+	// numberOfReports caps the reports sent for the reporting triggers, 0 means unlimited
+	numberOfReports uint16
+	// subsequentTimeQuota is in seconds
+	subsequentTimeQuota uint32
+
+	// droppedDLFlags is the Dropped DL Traffic Threshold flags, 0x01 is DLPA and 0x02 is DLBY
+	droppedDLFlags   uint8
+	droppedDLPackets uint64
+	droppedDLBytes   uint64
+
+	// linkedURRIDs are the URRs whose usage is reported along with this one, see 5.2.2.4 in PFCP specs
+	linkedURRIDs []uint32
+
+	isIDSet                bool
+	isMeasurementMethodSet bool
+	isTriggersSet          bool
+}
+
+// NewURRBuilder returns an urrBuilder.
+func NewURRBuilder() *urrBuilder {
+	return &urrBuilder{}
+}
+
+func (b *urrBuilder) WithID(id uint32) *urrBuilder {
+	// Used to avoid using 0 as default value. It makes sure that WithID was invoked.
+	b.isIDSet = true
+	b.urrID = id
+
+	return b
+}
+
+func (b *urrBuilder) WithMethod(method IEMethod) *urrBuilder {
+	b.method = method
+	return b
+}
+
+// WithMeasurementMethodEvent enables (1) or disables (0) the event based measurement.
+func (b *urrBuilder) WithMeasurementMethodEvent(event int) *urrBuilder {
+	b.isMeasurementMethodSet = true
+	b.measureEvent = event
+
+	return b
+}
+
+// WithMeasurementMethodVolume enables (1) or disables (0) the volume measurement.
+func (b *urrBuilder) WithMeasurementMethodVolume(volume int) *urrBuilder {
+	b.isMeasurementMethodSet = true
+	b.measureVolume = volume
+
+	return b
+}
+
+// WithMeasurementMethodDuration enables (1) or disables (0) the duration measurement.
+func (b *urrBuilder) WithMeasurementMethodDuration(duration int) *urrBuilder {
+	b.isMeasurementMethodSet = true
+	b.measureDuration = duration
+
+	return b
+}
+
+// WithTriggers sets the Reporting Triggers bitmask, replacing the triggers added so far.
+// See the ReportingTrigger flags and the helpers adding them one by one, e.g. WithPeriodicReporting.
+func (b *urrBuilder) WithTriggers(triggers uint16) *urrBuilder {
+	b.isTriggersSet = true
+	b.triggers = triggers
+
+	return b
+}
+
+// WithPeriodicReporting adds the periodic reporting (PERIO) trigger, see WithMeasurementPeriod.
+func (b *urrBuilder) WithPeriodicReporting() *urrBuilder {
+	return b.addTrigger(ReportingTriggerPERIO)
+}
+
+// WithVolumeThresholdTrigger adds the volume threshold (VOLTH) trigger.
+func (b *urrBuilder) WithVolumeThresholdTrigger() *urrBuilder {
+	return b.addTrigger(ReportingTriggerVOLTH)
+}
+
+// WithTimeThresholdTrigger adds the time threshold (TIMTH) trigger, see WithTimeThreshold.
+func (b *urrBuilder) WithTimeThresholdTrigger() *urrBuilder {
+	return b.addTrigger(ReportingTriggerTIMTH)
+}
+
+// WithStartOfTraffic adds the start of traffic (START) trigger.
+func (b *urrBuilder) WithStartOfTraffic() *urrBuilder {
+	return b.addTrigger(ReportingTriggerSTART)
+}
+
+// WithStopOfTraffic adds the stop of traffic (STOPT) trigger.
+func (b *urrBuilder) WithStopOfTraffic() *urrBuilder {
+	return b.addTrigger(ReportingTriggerSTOPT)
+}
+
+// WithVolumeQuotaTrigger adds the volume quota (VOLQU) trigger.
+func (b *urrBuilder) WithVolumeQuotaTrigger() *urrBuilder {
+	return b.addTrigger(ReportingTriggerVOLQU)
+}
+
+// WithTimeQuotaTrigger adds the time quota (TIMQU) trigger.
+func (b *urrBuilder) WithTimeQuotaTrigger() *urrBuilder {
+	return b.addTrigger(ReportingTriggerTIMQU)
+}
+
+// addTrigger ORs trigger, one of the ReportingTrigger flags, into the Reporting Triggers bitmask.
+func (b *urrBuilder) addTrigger(trigger uint16) *urrBuilder {
+	b.isTriggersSet = true
+	b.triggers |= trigger
+
+	return b
+}
+
+func (b *urrBuilder) WithMeasurementPeriod(period time.Duration) *urrBuilder {
+	b.measurementPeriod = period
+	return b
+}
+
+// WithTimeThreshold sets the time threshold, in seconds.
+func (b *urrBuilder) WithTimeThreshold(seconds uint32) *urrBuilder {
+	b.timeThreshold = seconds
+	return b
+}
+
+// WithEventThreshold sets the number of events after which the peer reports the usage.
+// The IE is omitted if threshold is 0.
+func (b *urrBuilder) WithEventThreshold(threshold uint32) *urrBuilder {
+	b.eventThreshold = threshold
+	return b
+}
+
+// WithEventQuota sets the number of events after which the peer stops forwarding the traffic.
+// The IE is omitted if quota is 0.
+func (b *urrBuilder) WithEventQuota(quota uint32) *urrBuilder {
+	b.eventQuota = quota
+	return b
+}
+
+// WithMeasurementInformation sets the Measurement Information flags.
+// The IE is omitted if flags is 0. Bits, from the least significant:
+// MBQE (0x01, measure before QoS enforcement), INAM (0x02, inactive measurement),
+// RADI (0x04, reduced application detection information),
+// ISTM (0x08, immediate start time metering, i.e. start measuring without waiting for the first packet),
+// MNOP (0x10, measure number of packets).
+func (b *urrBuilder) WithMeasurementInformation(flags uint8) *urrBuilder {
+	b.measurementInfo = flags
+	return b
+}
+
+// WithNumberOfReports sets the maximum number of usage reports generated by the reporting triggers.
+// The IE is omitted if num is 0.
+func (b *urrBuilder) WithNumberOfReports(num uint16) *urrBuilder {
+	b.numberOfReports = num
+	return b
+}
+
+// WithSubsequentTimeQuota sets the time quota, in seconds, to apply once the current one is exhausted.
+// The IE is omitted if seconds is 0.
+func (b *urrBuilder) WithSubsequentTimeQuota(seconds uint32) *urrBuilder {
+	b.subsequentTimeQuota = seconds
+	return b
+}
+
+// WithDroppedDLTrafficThreshold sets the thresholds on the downlink traffic dropped by the peer.
+// flags selects which of packets (DLPA, 0x01) and bytes (DLBY, 0x02) are in use;
+// the IE is omitted if flags is 0.
+func (b *urrBuilder) WithDroppedDLTrafficThreshold(flags uint8, packets, bytes uint64) *urrBuilder {
+	b.droppedDLFlags = flags
+	b.droppedDLPackets = packets
+	b.droppedDLBytes = bytes
+
+	return b
+}
+
+// WithLinkedURRID links the URR to the URR identified by id, e.g. an aggregate URR accounting for the usage
+// of several per-application URRs. It can be invoked several times to link the URR to several URRs.
+// The Linked URR ID IEs are sent in the order they were added.
+func (b *urrBuilder) WithLinkedURRID(id uint32) *urrBuilder {
+	b.linkedURRIDs = append(b.linkedURRIDs, id)
+	return b
+}
+
+// validate returns a RuleError naming the offending IE if the values set don't form a valid URR.
+func (b *urrBuilder) validate() error {
+	if !b.isIDSet {
+		return newRuleError("URR", 0, "URR ID", "not set")
+	}
+
+	for _, id := range b.linkedURRIDs {
+		if id == b.urrID {
+			return newRuleError("URR", b.urrID, "Linked URR ID", "the URR can't be linked to itself")
+		}
+	}
+
+	return nil
+}
+
+// Build returns a Create URR IE by default, an Update URR IE if the Update method was set,
+// or a Remove URR IE if the Delete method was set.
+// Panics if the values set don't form a valid URR, see BuildE.
+func (b *urrBuilder) Build() *ie.IE {
+	urr, err := b.BuildE()
+	if err != nil {
+		panic(err)
+	}
+
+	return urr
+}
+
+// BuildE returns the URR like Build, or a RuleError naming the offending IE
+// if the values set don't form a valid URR.
+func (b *urrBuilder) BuildE() (*ie.IE, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	return b.build(), nil
+}
+
+func (b *urrBuilder) build() *ie.IE {
+	if b.method == Delete {
+		return ie.NewRemoveURR(ie.NewURRID(b.urrID))
+	}
+
+	createFunc := ie.NewCreateURR
+	if b.method == Update {
+		createFunc = ie.NewUpdateURR
+	}
+
+	urr := createFunc(ie.NewURRID(b.urrID))
+
+	// Measurement Method and Reporting Triggers are mandatory in Create URRs
+	if b.method != Update || b.isMeasurementMethodSet {
+		urr.Add(ie.NewMeasurementMethod(b.measureEvent, b.measureVolume, b.measureDuration))
+	}
+
+	if b.method != Update || b.isTriggersSet {
+		urr.Add(ie.NewReportingTriggers(b.triggers))
+	}
+
+	if b.measurementPeriod != 0 {
+		urr.Add(ie.NewMeasurementPeriod(b.measurementPeriod))
+	}
+
+	if b.timeThreshold != 0 {
+		urr.Add(ie.NewTimeThreshold(b.timeThreshold))
+	}
+
+	if b.eventThreshold != 0 {
+		urr.Add(ie.NewEventThreshold(b.eventThreshold))
+	}
+
+	if b.eventQuota != 0 {
+		urr.Add(ie.NewEventQuota(b.eventQuota))
+	}
+
+	if b.measurementInfo != 0 {
+		urr.Add(ie.NewMeasurementInformation(b.measurementInfo))
+	}
+
+	if b.numberOfReports != 0 {
+		urr.Add(ie.NewNumberOfReports(b.numberOfReports))
+	}
+
+	if b.subsequentTimeQuota != 0 {
+		urr.Add(ie.NewSubsequentTimeQuota(time.Duration(b.subsequentTimeQuota) * time.Second))
+	}
+
+	if b.droppedDLFlags != 0 {
+		urr.Add(ie.NewDroppedDLTrafficThreshold(
+			b.droppedDLFlags&0x01 != 0,
+			b.droppedDLFlags&0x02 != 0,
+			b.droppedDLPackets,
+			b.droppedDLBytes,
+		))
+	}
+
+	for _, id := range b.linkedURRIDs {
+		urr.Add(ie.NewLinkedURRID(id))
+	}
+
+	return urr
+}
@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+func TestURRBuilderShouldPanic(t *testing.T) {
+	assert.Panics(t, func() {
+		NewURRBuilder().
+			WithMethod(Create).
+			WithMeasurementMethodVolume(1).
+			Build()
+	})
+}
+
+func TestURRBuilder(t *testing.T) {
+	type testCase struct {
+		input       *urrBuilder
+		expected    *ie.IE
+		description string
+	}
+
+	for _, scenario := range []testCase{
+		{
+			input: NewURRBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithMeasurementMethodVolume(1).
+				WithMeasurementMethodDuration(1).
+				WithTriggers(0x0100). // PERIO
+				WithMeasurementPeriod(10 * time.Second),
+			expected: ie.NewCreateURR(
+				ie.NewURRID(1),
+				ie.NewMeasurementMethod(0, 1, 1),
+				ie.NewReportingTriggers(0x0100),
+				ie.NewMeasurementPeriod(10*time.Second),
+			),
+			description: "Valid Create URR with periodic reporting",
+		},
+		{
+			input: NewURRBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithMeasurementMethodEvent(1).
+				WithEventThreshold(10).
+				WithEventQuota(100),
+			expected: ie.NewCreateURR(
+				ie.NewURRID(1),
+				ie.NewMeasurementMethod(1, 0, 0),
+				ie.NewReportingTriggers(0),
+				ie.NewEventThreshold(10),
+				ie.NewEventQuota(100),
+			),
+			description: "Valid Create URR with event measurement",
+		},
+		{
+			input: NewURRBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithMeasurementMethodEvent(1).
+				WithEventThreshold(0).
+				WithEventQuota(0),
+			expected: ie.NewCreateURR(
+				ie.NewURRID(1),
+				ie.NewMeasurementMethod(1, 0, 0),
+				ie.NewReportingTriggers(0),
+			),
+			description: "Event threshold and quota omitted if 0",
+		},
+		{
+			input: NewURRBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithMeasurementMethodVolume(1).
+				WithMeasurementInformation(0x01), // MBQE
+			expected: ie.NewCreateURR(
+				ie.NewURRID(1),
+				ie.NewMeasurementMethod(0, 1, 0),
+				ie.NewReportingTriggers(0),
+				ie.NewMeasurementInformation(0x01),
+			),
+			description: "Valid Create URR with measurement before QoS enforcement",
+		},
+		{
+			input: NewURRBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithMeasurementMethodVolume(1).
+				WithTriggers(0x0100). // PERIO
+				WithMeasurementPeriod(10 * time.Second).
+				WithNumberOfReports(3),
+			expected: ie.NewCreateURR(
+				ie.NewURRID(1),
+				ie.NewMeasurementMethod(0, 1, 0),
+				ie.NewReportingTriggers(0x0100),
+				ie.NewMeasurementPeriod(10*time.Second),
+				ie.NewNumberOfReports(3),
+			),
+			description: "Valid Create URR with number of reports",
+		},
+		{
+			input: NewURRBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithMeasurementMethodDuration(1).
+				WithTriggers(0x0400). // TIMTH
+				WithTimeThreshold(60).
+				WithSubsequentTimeQuota(120),
+			expected: ie.NewCreateURR(
+				ie.NewURRID(1),
+				ie.NewMeasurementMethod(0, 0, 1),
+				ie.NewReportingTriggers(0x0400),
+				ie.NewTimeThreshold(60),
+				ie.NewSubsequentTimeQuota(120*time.Second),
+			),
+			description: "Valid Create URR with subsequent time quota",
+		},
+		{
+			input: NewURRBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithMeasurementMethodVolume(1).
+				WithNumberOfReports(1).
+				WithDroppedDLTrafficThreshold(0x03, 100, 2048),
+			expected: ie.NewCreateURR(
+				ie.NewURRID(1),
+				ie.NewMeasurementMethod(0, 1, 0),
+				ie.NewReportingTriggers(0),
+				ie.NewNumberOfReports(1),
+				ie.NewDroppedDLTrafficThreshold(true, true, 100, 2048),
+			),
+			description: "Valid Create URR with dropped DL traffic threshold",
+		},
+		{
+			input: NewURRBuilder().
+				WithID(1).
+				WithMethod(Create).
+				WithMeasurementMethodVolume(1).
+				WithPeriodicReporting().
+				WithVolumeThresholdTrigger().
+				WithMeasurementPeriod(10 * time.Second),
+			expected: ie.NewCreateURR(
+				ie.NewURRID(1),
+				ie.NewMeasurementMethod(0, 1, 0),
+				ie.NewReportingTriggers(0x0300),
+				ie.NewMeasurementPeriod(10*time.Second),
+			),
+			description: "Valid Create URR with trigger helpers",
+		},
+		{
+			input: NewURRBuilder().
+				WithID(2).
+				WithMethod(Update).
+				WithStartOfTraffic(),
+			expected: ie.NewUpdateURR(
+				ie.NewURRID(2),
+				ie.NewReportingTriggers(0x1000),
+			),
+			description: "Valid Update URR with trigger helpers",
+		},
+		{
+			input: NewURRBuilder().
+				WithID(2).
+				WithMethod(Update).
+				WithEventQuota(50),
+			expected: ie.NewUpdateURR(
+				ie.NewURRID(2),
+				ie.NewEventQuota(50),
+			),
+			description: "Valid Update URR",
+		},
+		{
+			input: NewURRBuilder().
+				WithID(2).
+				WithMethod(Delete).
+				WithMeasurementMethodEvent(1).
+				WithEventThreshold(10).
+				WithEventQuota(100).
+				WithSubsequentTimeQuota(120).
+				WithDroppedDLTrafficThreshold(0x01, 100, 0),
+			expected: ie.NewRemoveURR(
+				ie.NewURRID(2),
+			),
+			description: "Valid Remove URR",
+		},
+	} {
+		t.Run(scenario.description, func(t *testing.T) {
+			assert.Equal(t, scenario.expected, scenario.input.Build())
+		})
+	}
+}
+
+func TestURRBuilderTriggers(t *testing.T) {
+	for _, scenario := range []struct {
+		input       *urrBuilder
+		expected    uint16
+		description string
+	}{
+		{
+			input:       NewURRBuilder().WithPeriodicReporting(),
+			expected:    0x0100,
+			description: "periodic reporting",
+		},
+		{
+			input:       NewURRBuilder().WithVolumeThresholdTrigger().WithTimeThresholdTrigger(),
+			expected:    0x0600,
+			description: "volume and time thresholds",
+		},
+		{
+			input:       NewURRBuilder().WithStartOfTraffic().WithStopOfTraffic(),
+			expected:    0x3000,
+			description: "start and stop of traffic",
+		},
+		{
+			input:       NewURRBuilder().WithVolumeQuotaTrigger().WithTimeQuotaTrigger().WithPeriodicReporting(),
+			expected:    0x0103,
+			description: "quotas and periodic reporting",
+		},
+		{
+			input:       NewURRBuilder().WithPeriodicReporting().WithPeriodicReporting(),
+			expected:    0x0100,
+			description: "same trigger twice",
+		},
+		{
+			input:       NewURRBuilder().WithTriggers(ReportingTriggerTIMTH).WithStartOfTraffic(),
+			expected:    0x1400,
+			description: "helper after raw triggers",
+		},
+		{
+			input:       NewURRBuilder().WithStartOfTraffic().WithTriggers(ReportingTriggerTIMTH),
+			expected:    0x0400,
+			description: "raw triggers replace the helpers",
+		},
+	} {
+		t.Run(scenario.description, func(t *testing.T) {
+			assert.True(t, scenario.input.isTriggersSet)
+			assert.Equal(t, scenario.expected, scenario.input.triggers)
+		})
+	}
+}
+
+func TestURRBuilderBuildE(t *testing.T) {
+	urr, err := NewURRBuilder().WithMethod(Create).WithMeasurementMethodVolume(1).BuildE()
+	require.Nil(t, urr)
+	require.ErrorIs(t, err, ErrInvalidRule)
+
+	var ruleErr *RuleError
+	require.ErrorAs(t, err, &ruleErr)
+	require.Equal(t, "URR", ruleErr.Rule)
+	require.Equal(t, "URR ID", ruleErr.IE)
+
+	urr, err = NewURRBuilder().WithID(1).WithMeasurementMethodVolume(1).BuildE()
+	require.NoError(t, err)
+	require.Equal(t, NewURRBuilder().WithID(1).WithMeasurementMethodVolume(1).Build(), urr)
+}
+
+func TestURRBuilderLinkedURRID(t *testing.T) {
+	urr, err := NewURRBuilder().
+		WithID(2).
+		WithMethod(Create).
+		WithMeasurementMethodVolume(1).
+		WithLinkedURRID(1).
+		WithLinkedURRID(3).
+		BuildE()
+	require.NoError(t, err)
+
+	var linked []uint32
+
+	for _, child := range urr.ChildIEs {
+		if child.Type != ie.LinkedURRID {
+			continue
+		}
+
+		id, err := child.LinkedURRID()
+		require.NoError(t, err)
+
+		linked = append(linked, id)
+	}
+
+	require.Equal(t, []uint32{1, 3}, linked)
+
+	// a URR can't be linked to itself
+	_, err = NewURRBuilder().WithID(2).WithLinkedURRID(2).BuildE()
+	require.ErrorIs(t, err, ErrInvalidRule)
+
+	var ruleErr *RuleError
+	require.ErrorAs(t, err, &ruleErr)
+	require.Equal(t, "Linked URR ID", ruleErr.IE)
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"errors"
+	"time"
+
+	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// errMissingURRID is returned when a Usage Report IE lacks the mandatory URR ID.
+var errMissingURRID = errors.New("usage report without URR ID")
+
+// UsageReport is the content of a Usage Report IE, as sent by the peer in Session Report Requests
+// and in the Session Modification Responses to Query URRs.
+type UsageReport struct {
+	URRID uint32
+	// SequenceNumber is the UR-SEQN of the report, 0 if omitted
+	SequenceNumber uint32
+	// Trigger is the Usage Report Trigger bitmask, see 8.2.41 in PFCP specs. The first octet is the least significant
+	Trigger uint32
+
+	// TotalVolume, UplinkVolume and DownlinkVolume are in bytes, 0 if not measured
+	TotalVolume    uint64
+	UplinkVolume   uint64
+	DownlinkVolume uint64
+	// Duration is 0 if not measured
+	Duration time.Duration
+	// StartTime and EndTime delimit the period the usage was measured over, zero if omitted
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Usage Report Trigger flags, see 8.2.41 in PFCP specs
+const (
+	UsageReportTriggerPERIO uint32 = 1 << 0  // periodic reporting
+	UsageReportTriggerVOLTH uint32 = 1 << 1  // volume threshold
+	UsageReportTriggerTIMTH uint32 = 1 << 2  // time threshold
+	UsageReportTriggerQUHTI uint32 = 1 << 3  // quota holding time
+	UsageReportTriggerSTART uint32 = 1 << 4  // start of traffic
+	UsageReportTriggerSTOPT uint32 = 1 << 5  // stop of traffic
+	UsageReportTriggerDROTH uint32 = 1 << 6  // dropped DL traffic threshold
+	UsageReportTriggerIMMER uint32 = 1 << 7  // immediate report
+	UsageReportTriggerVOLQU uint32 = 1 << 8  // volume quota
+	UsageReportTriggerTIMQU uint32 = 1 << 9  // time quota
+	UsageReportTriggerLIUSA uint32 = 1 << 10 // linked usage reporting
+	UsageReportTriggerTERMR uint32 = 1 << 11 // termination report
+	UsageReportTriggerEVEQU uint32 = 1 << 16 // event quota
+)
+
+// HasTrigger returns true if the report was triggered by trigger, one of the UsageReportTrigger flags.
+func (r UsageReport) HasTrigger(trigger uint32) bool {
+	return r.Trigger&trigger != 0
+}
+
+// ParseUsageReports returns the Usage Reports carried by req, in order.
+func ParseUsageReports(req *message.SessionReportRequest) ([]UsageReport, error) {
+	reports := make([]UsageReport, 0, len(req.UsageReport))
+
+	for _, usageReport := range req.UsageReport {
+		report, err := ParseUsageReport(usageReport)
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// ParseUsageReport decodes a Usage Report IE. Returns error if the URR ID is missing or if any IE is malformed.
+func ParseUsageReport(usageReport *ie.IE) (UsageReport, error) {
+	var (
+		report   UsageReport
+		hasURRID bool
+	)
+
+	for _, child := range usageReport.ChildIEs {
+		switch child.Type {
+		case ie.URRID:
+			id, err := child.URRID()
+			if err != nil {
+				return UsageReport{}, err
+			}
+
+			report.URRID = id
+			hasURRID = true
+		case ie.URSEQN:
+			seq, err := child.URSEQN()
+			if err != nil {
+				return UsageReport{}, err
+			}
+
+			report.SequenceNumber = seq
+		case ie.UsageReportTrigger:
+			for i, octet := range child.Payload {
+				report.Trigger |= uint32(octet) << (8 * i)
+			}
+		case ie.VolumeMeasurement:
+			volume, err := child.VolumeMeasurement()
+			if err != nil {
+				return UsageReport{}, err
+			}
+
+			report.TotalVolume = volume.TotalVolume
+			report.UplinkVolume = volume.UplinkVolume
+			report.DownlinkVolume = volume.DownlinkVolume
+		case ie.DurationMeasurement:
+			duration, err := child.DurationMeasurement()
+			if err != nil {
+				return UsageReport{}, err
+			}
+
+			report.Duration = duration
+		case ie.StartTime:
+			start, err := child.StartTime()
+			if err != nil {
+				return UsageReport{}, err
+			}
+
+			report.StartTime = start
+		case ie.EndTime:
+			end, err := child.EndTime()
+			if err != nil {
+				return UsageReport{}, err
+			}
+
+			report.EndTime = end
+		}
+	}
+
+	if !hasURRID {
+		return UsageReport{}, errMissingURRID
+	}
+
+	return report, nil
+}
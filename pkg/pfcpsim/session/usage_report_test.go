@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+func TestParseUsageReports(t *testing.T) {
+	req := message.NewSessionReportRequest(0, 0, 1, 1, 0,
+		ie.NewReportType(0, 0, 1, 0),
+		ie.NewUsageReportWithinSessionReportRequest(
+			ie.NewURRID(1),
+			ie.NewURSEQN(3),
+			ie.NewUsageReportTrigger(0x02, 0x08, 0x00), // VOLTH and TERMR
+			ie.NewVolumeMeasurement(0x07, 300, 100, 200, 0, 0, 0),
+			ie.NewDurationMeasurement(10*time.Second),
+		),
+		ie.NewUsageReportWithinSessionReportRequest(
+			ie.NewURRID(2),
+			ie.NewUsageReportTrigger(0x00, 0x00, 0x01), // EVEQU
+		),
+	)
+
+	// the reports are decoded from the wire, as received from the peer
+	b, err := req.Marshal()
+	require.NoError(t, err)
+
+	received, err := message.ParseSessionReportRequest(b)
+	require.NoError(t, err)
+
+	reports, err := ParseUsageReports(received)
+	require.NoError(t, err)
+	require.Equal(t, []UsageReport{
+		{
+			URRID:          1,
+			SequenceNumber: 3,
+			Trigger:        UsageReportTriggerVOLTH | UsageReportTriggerTERMR,
+			TotalVolume:    300,
+			UplinkVolume:   100,
+			DownlinkVolume: 200,
+			Duration:       10 * time.Second,
+		},
+		{
+			URRID:   2,
+			Trigger: UsageReportTriggerEVEQU,
+		},
+	}, reports)
+
+	require.True(t, reports[0].HasTrigger(UsageReportTriggerVOLTH))
+	require.False(t, reports[0].HasTrigger(UsageReportTriggerPERIO))
+}
+
+func TestParseUsageReportsWithoutReports(t *testing.T) {
+	reports, err := ParseUsageReports(message.NewSessionReportRequest(0, 0, 1, 1, 0,
+		ie.NewReportType(0, 1, 0, 0),
+	))
+	require.NoError(t, err)
+	require.Empty(t, reports)
+}
+
+func TestParseUsageReportWithoutURRID(t *testing.T) {
+	_, err := ParseUsageReport(ie.NewUsageReportWithinSessionReportRequest(
+		ie.NewURSEQN(1),
+		ie.NewVolumeMeasurement(0x01, 300, 0, 0, 0, 0, 0),
+	))
+	require.Error(t, err)
+}
+
+func TestParseUsageReportDuration(t *testing.T) {
+	start := time.Date(2022, time.January, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Second)
+
+	usageReport := ie.NewUsageReportWithinSessionReportRequest(
+		ie.NewURRID(1),
+		ie.NewUsageReportTrigger(0x04, 0x00, 0x00), // TIMTH
+		ie.NewStartTime(start),
+		ie.NewEndTime(end),
+		ie.NewDurationMeasurement(90*time.Second),
+	)
+
+	b, err := usageReport.Marshal()
+	require.NoError(t, err)
+
+	received, err := ie.Parse(b)
+	require.NoError(t, err)
+
+	report, err := ParseUsageReport(received)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), report.URRID)
+	require.True(t, report.HasTrigger(UsageReportTriggerTIMTH))
+	require.Equal(t, 90*time.Second, report.Duration)
+	require.True(t, report.StartTime.Equal(start))
+	require.True(t, report.EndTime.Equal(end))
+
+	// the times are zero if omitted
+	report, err = ParseUsageReport(ie.NewUsageReportWithinSessionReportRequest(
+		ie.NewURRID(2),
+		ie.NewDurationMeasurement(time.Second),
+	))
+	require.NoError(t, err)
+	require.Equal(t, time.Second, report.Duration)
+	require.True(t, report.StartTime.IsZero())
+	require.True(t, report.EndTime.IsZero())
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// SetCSID sets the PDN connection set the sessions established afterwards belong to. The set is advertised to the peer
+// through the FQ-CSID IE of the Session Establishment Requests, so that DeleteSessionSet can delete its sessions at once.
+// Sessions belong to no set if csid is 0.
+func (c *PFCPClient) SetCSID(csid uint16) {
+	atomic.StoreUint32(&c.csid, uint32(csid))
+}
+
+// CSID returns the PDN connection set the sessions established by the client belong to, 0 if none.
+func (c *PFCPClient) CSID() uint16 {
+	return uint16(atomic.LoadUint32(&c.csid))
+}
+
+// newFQCSID returns the FQ-CSID IE identifying the PDN connection set csid of the client.
+func (c *PFCPClient) newFQCSID(csid uint16) *ieLib.IE {
+	if ip := c.localIPv4(); ip != nil {
+		return ieLib.NewFQCSID(ip.String(), csid)
+	}
+
+	return ieLib.NewFQCSID(c.localIPv6().String(), csid)
+}
+
+// DeleteSessionSet deletes all the sessions of the PDN connection set csid with a single Session Set Deletion Request,
+// waiting for the response until ctx is done. Returns the deleted sessions, sorted by local SEID.
+// Peers not supporting the Session Set Deletion procedure are expected to reject or ignore the request: their sessions
+// must then be deleted one by one.
+func (c *PFCPClient) DeleteSessionSet(ctx context.Context, csid uint16) (_ []*PFCPSession, err error) {
+	if !c.IsAssociationAlive() {
+		return nil, NewAssociationInactiveError()
+	}
+
+	defer func(start time.Time) {
+		observeExchange(opSessionSetDeletion, start, err)
+	}(time.Now())
+
+	req := message.NewSessionSetDeletionRequest(c.getNextSequenceNumber(), c.localNodeID(), c.newFQCSID(csid))
+
+	resp, err := c.exchange(ctx, OperationDeletion, req)
+	if err != nil {
+		return nil, err
+	}
+
+	setDelResp, ok := resp.(*message.SessionSetDeletionResponse)
+	if !ok {
+		return nil, NewUnexpectedResponseError(resp)
+	}
+
+	if err := checkCause(setDelResp.Cause, nil, setDelResp.OffendingIE); err != nil {
+		return nil, err
+	}
+
+	deleted := c.removeSessionSet(csid)
+	sessionsDeleted.Add(float64(len(deleted)))
+
+	return deleted, nil
+}
+
+// removeSessionSet forgets the sessions of the PDN connection set csid and returns them, sorted by local SEID.
+func (c *PFCPClient) removeSessionSet(csid uint16) []*PFCPSession {
+	c.sessionsLock.Lock()
+	defer c.sessionsLock.Unlock()
+
+	var removed []*PFCPSession
+
+	for seid, sess := range c.sessions {
+		if sess.csid != csid {
+			continue
+		}
+
+		removed = append(removed, sess)
+
+		delete(c.sessions, seid)
+	}
+
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].localSEID < removed[j].localSEID
+	})
+
+	return removed
+}